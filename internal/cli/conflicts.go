@@ -0,0 +1,93 @@
+// internal/cli/conflicts.go - Interactive resolution of the files edited since they were generated
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Answers of ResolveConflict, the names of the resolutions of the generator
+const (
+	ConflictKeep      = "keep"
+	ConflictOverwrite = "overwrite"
+	ConflictMerge     = "merge"
+	ConflictMarkers   = "markers"
+)
+
+// conflictOptions label the answers of ResolveConflict
+var conflictOptions = []struct{ answer, label string }{
+	{ConflictKeep, "Keep your version"},
+	{ConflictMerge, "Merge the changes of the templates into your version"},
+	{ConflictOverwrite, "Overwrite it with the generated version"},
+	{ConflictMarkers, "Write both versions with conflict markers, to resolve by hand"},
+}
+
+// ANSI colors of the diff lines
+const (
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorCyan  = "\033[36m"
+	colorBold  = "\033[1m"
+	colorReset = "\033[0m"
+)
+
+// ResolveConflict prints the colored diff between a file edited since it was
+// generated and its generated content to out, then asks how to resolve it;
+// merging is only offered when the changes can be merged
+func (w *Wizard) ResolveConflict(out io.Writer, path, diff string, mergeable bool) (string, error) {
+	fmt.Fprintf(out, "\n%s%s was edited since it was generated:%s\n", colorBold, path, colorReset)
+	fmt.Fprint(out, ColorDiff(diff))
+
+	options := []string{}
+	answers := map[string]string{}
+	for _, option := range conflictOptions {
+		if option.answer == ConflictMerge && !mergeable {
+			continue
+		}
+		options = append(options, option.label)
+		answers[option.label] = option.answer
+	}
+
+	selected := ""
+	prompt := &survey.Select{
+		Message: "Resolve " + path + ":",
+		Options: options,
+		Default: options[0],
+		Help:    "The decision is recorded in .goprojectgen.yaml, so later runs generating the same content don't ask again; --accept-all and --keep-all answer for every file",
+	}
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return "", err
+	}
+	return answers[selected], nil
+}
+
+// ColorDiff colors the lines of a unified diff: removed lines red, added
+// lines green and hunk headers cyan
+func ColorDiff(diff string) string {
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		color := ""
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			color = colorBold
+		case strings.HasPrefix(line, "@@"):
+			color = colorCyan
+		case strings.HasPrefix(line, "-"):
+			color = colorRed
+		case strings.HasPrefix(line, "+"):
+			color = colorGreen
+		}
+		if color == "" || line == "" {
+			b.WriteString(line)
+			continue
+		}
+		b.WriteString(color + strings.TrimSuffix(line, "\n") + colorReset)
+		if strings.HasSuffix(line, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -3,8 +3,7 @@ package cli
 
 import (
 	"fmt"
-	//"path/filepath"
-	//"strings"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/neor-it/go-project-gen/internal/config"
@@ -23,60 +22,639 @@ func NewWizard(log logger.Logger) *Wizard {
 	}
 }
 
-// Run runs the wizard and returns the project configuration
-func (w *Wizard) Run() (config.ProjectConfig, error) {
+// databaseOption stands for the database engines in the components prompt;
+// the engine is asked for separately
+const databaseOption = "database"
+
+// componentOptions maps component names to the labels shown in the wizard
+var componentOptions = []struct {
+	Name  string
+	Label string
+}{
+	{config.ComponentHTTP, "HTTP"},
+	{config.ComponentGRPC, "gRPC"},
+	{databaseOption, "Database"},
+	{config.ComponentRedis, "Redis"},
+	{config.ComponentDocker, "Docker"},
+	{config.ComponentCICD, "CI/CD"},
+	{config.ComponentMetrics, "Observability: metrics"},
+	{config.ComponentTracing, "Observability: tracing (OpenTelemetry)"},
+	{config.ComponentAuth, "Auth (JWT or PASETO)"},
+}
+
+// httpFrameworkOptions maps HTTP framework names to the labels shown in the wizard
+var httpFrameworkOptions = []struct {
+	Name  string
+	Label string
+}{
+	{config.HTTPFrameworkGin, "Gin"},
+	{config.HTTPFrameworkEcho, "Echo"},
+	{config.HTTPFrameworkChi, "Chi"},
+	{config.HTTPFrameworkStdlib, "net/http (standard library)"},
+}
+
+// tokenFormatOptions maps token formats to the labels shown in the wizard
+var tokenFormatOptions = []struct {
+	Name  string
+	Label string
+}{
+	{config.TokenFormatJWT, "JWT (HS256)"},
+	{config.TokenFormatPASETOLocal, "PASETO v4.local (encrypted, shared key)"},
+	{config.TokenFormatPASETOPublic, "PASETO v4.public (signed, Ed25519 key pair)"},
+}
+
+// jsonEngineOptions maps the JSON engines of Gin to the labels shown in the wizard
+var jsonEngineOptions = []struct {
+	Name  string
+	Label string
+}{
+	{config.JSONEngineStdlib, "encoding/json (standard library)"},
+	{config.JSONEngineGoJSON, "goccy/go-json"},
+	{config.JSONEngineJsoniter, "json-iterator/go"},
+	{config.JSONEngineSonic, "bytedance/sonic (amd64 only, encoding/json elsewhere)"},
+}
+
+// postgresDriverOptions maps the Postgres drivers to the labels shown in the wizard
+var postgresDriverOptions = []struct {
+	Name  string
+	Label string
+}{
+	{config.PostgresDriverPQ, "lib/pq"},
+	{config.PostgresDriverPgx, "pgx/v5 (pgxpool behind database/sql)"},
+}
+
+// dataLayerOptions maps the data layers to the labels shown in the wizard
+var dataLayerOptions = []struct {
+	Name  string
+	Label string
+}{
+	{config.DataLayerSQLX, "sqlx (hand-written repositories)"},
+	{config.DataLayerSQLC, "sqlc (queries generated from SQL)"},
+}
+
+// databaseOptions maps database engines to the labels shown in the wizard
+var databaseOptions = []struct {
+	Name  string
+	Label string
+}{
+	{config.ComponentPostgres, "PostgreSQL"},
+	{config.ComponentMySQL, "MySQL"},
+	{config.ComponentSQLite, "SQLite"},
+}
+
+// ciProviderOptions maps CI providers to the labels shown in the wizard
+var ciProviderOptions = []struct {
+	Name  string
+	Label string
+}{
+	{config.CIProviderGitHub, "GitHub Actions"},
+	{config.CIProviderGitLab, "GitLab CI"},
+	{config.CIProviderBitbucket, "Bitbucket Pipelines"},
+	{config.CIProviderGitea, "Gitea Actions"},
+	{config.CIProviderNone, "None (no pipeline file)"},
+}
+
+// allCIProvidersLabel is the CI provider option listing the providers of other hosts
+const allCIProvidersLabel = "Other (show every CI provider)"
+
+// registryOptions maps registry kinds to the labels shown in the wizard
+var registryOptions = []struct {
+	Name  string
+	Label string
+}{
+	{config.RegistryDockerHub, "Docker Hub"},
+	{config.RegistryGHCR, "GitHub Container Registry (ghcr.io)"},
+	{config.RegistryGitLab, "GitLab Container Registry (registry.gitlab.com)"},
+	{config.RegistryECR, "Amazon ECR"},
+	{config.RegistryGAR, "Google Artifact Registry"},
+	{config.RegistryCustom, "Other registry"},
+}
+
+// customPresetLabel is the preset option leading to the components prompt
+const customPresetLabel = "Custom (choose the components)"
+
+// presetLabel returns the label of a preset shown in the wizard
+func presetLabel(preset config.Preset) string {
+	return preset.Name + " - " + preset.Description
+}
+
+// Run runs the wizard and returns the project configuration.
+// Answers already provided on the command line are used as-is and not asked again.
+func (w *Wizard) Run(cfg *config.Config) (config.ProjectConfig, error) {
 	w.log.Info("Starting interactive project configuration wizard")
 
-	// Define project configuration
-	var projectCfg config.ProjectConfig
+	// Start from the values provided on the command line
+	projectCfg := cfg.ProjectConfig
 
 	// Ask for username
-	username := ""
-	prompt := &survey.Input{
-		Message: "GitHub username or organization:",
-		Help:    "This will be used to create the module path (e.g., github.com/username/project-name)",
-	}
-	if err := survey.AskOne(prompt, &username, survey.WithValidator(survey.Required)); err != nil {
-		return projectCfg, err
+	if projectCfg.Username == "" {
+		prompt := &survey.Input{
+			Message: "GitHub username or organization:",
+			Help:    "This will be used to create the module path (e.g., github.com/username/project-name)",
+		}
+		username, err := w.askName(prompt, config.ValidateUsername, config.SanitizeUsername)
+		if err != nil {
+			return projectCfg, err
+		}
+		projectCfg.Username = username
 	}
-	projectCfg.Username = username
 
 	// Ask for project name
-	projectName := ""
-	prompt = &survey.Input{
-		Message: "Project name:",
-		Help:    "This will be used as the directory name and in the module path",
+	if projectCfg.ProjectName == "" {
+		prompt := &survey.Input{
+			Message: "Project name:",
+			Help:    "This will be used as the directory name, in the module path and the image name; lowercase letters, digits and dashes",
+		}
+		projectName, err := w.askName(prompt, config.ValidateProjectName, config.SanitizeProjectName)
+		if err != nil {
+			return projectCfg, err
+		}
+		projectCfg.ProjectName = projectName
 	}
-	if err := survey.AskOne(prompt, &projectName, survey.WithValidator(survey.Required)); err != nil {
-		return projectCfg, err
+
+	// Ask for the module path unless it was provided; the GitHub-style path is the default
+	if projectCfg.ModuleName == "" {
+		modulePath := ""
+		prompt := &survey.Input{
+			Message: "Module path:",
+			Default: config.DefaultModulePath(projectCfg.Username, projectCfg.ProjectName),
+			Help:    "The Go module path in go.mod and the imports, e.g. gitlab.example.com/team/project-name",
+		}
+		validate := func(answer interface{}) error {
+			return config.ValidateModulePath(fmt.Sprint(answer))
+		}
+		if err := survey.AskOne(prompt, &modulePath, survey.WithValidator(validate)); err != nil {
+			return projectCfg, err
+		}
+		projectCfg.ModuleName = modulePath
 	}
-	projectCfg.ProjectName = projectName
 
-	// Create module name
-	projectCfg.ModuleName = fmt.Sprintf("github.com/%s/%s", username, projectName)
+	// Ask for the Go version shared by go.mod, the Docker build image and the CI pipeline
+	if !cfg.Provided["go-version"] {
+		prompt := &survey.Input{
+			Message: "Go version:",
+			Default: projectCfg.Go(),
+			Help:    "The go directive of go.mod, also used by the Docker build image and the CI pipeline; " + config.MinGoVersion + " to " + config.MaxGoVersion,
+		}
+		validate := func(answer interface{}) error {
+			return config.ValidateGoVersion(fmt.Sprint(answer))
+		}
+		if err := survey.AskOne(prompt, &projectCfg.GoVersion, survey.WithValidator(validate)); err != nil {
+			return projectCfg, err
+		}
+	}
+
+	// Offer the presets as a shortcut for the components
+	presetSelected := false
+	if !cfg.Provided["components"] {
+		options := []string{customPresetLabel}
+		for _, preset := range config.Presets {
+			options = append(options, presetLabel(preset))
+		}
+
+		selected := ""
+		presetPrompt := &survey.Select{
+			Message: "Start from a preset:",
+			Options: options,
+			Default: customPresetLabel,
+		}
+		if err := survey.AskOne(presetPrompt, &selected); err != nil {
+			return projectCfg, err
+		}
+
+		for _, preset := range config.Presets {
+			if presetLabel(preset) != selected {
+				continue
+			}
+			components, err := config.ParseComponents(preset.Components)
+			if err != nil {
+				return projectCfg, err
+			}
+			components.HTTPFramework = projectCfg.Components.HTTPFramework
+			components.CIProvider = projectCfg.Components.CIProvider
+			components.TokenFormat = projectCfg.Components.TokenFormat
+			components.JSONEngine = projectCfg.Components.JSONEngine
+			components.PostgresDriver = projectCfg.Components.PostgresDriver
+			components.DataLayer = projectCfg.Components.DataLayer
+			projectCfg.Components = components
+			presetSelected = true
+		}
+	}
 
 	// Ask for components
-	components := []string{}
-	componentsPrompt := &survey.MultiSelect{
-		Message: "Select components to include:",
-		Options: []string{
-			"HTTP (Gin)",
-			"PostgreSQL",
-			"Docker",
-			"CI/CD",
-		},
-		Default: []string{"HTTP (Gin)"},
-	}
-	if err := survey.AskOne(componentsPrompt, &components); err != nil {
-		return projectCfg, err
+	if !cfg.Provided["components"] && !presetSelected {
+		options := []string{}
+		defaults := []string{}
+		for _, option := range componentOptions {
+			options = append(options, option.Label)
+			if contains(projectCfg.Components.Names(), option.Name) ||
+				(option.Name == databaseOption && projectCfg.Components.HasDatabase()) {
+				defaults = append(defaults, option.Label)
+			}
+		}
+
+		selected := []string{}
+		componentsPrompt := &survey.MultiSelect{
+			Message: "Select components to include:",
+			Options: options,
+			Default: defaults,
+		}
+		if err := survey.AskOne(componentsPrompt, &selected); err != nil {
+			return projectCfg, err
+		}
+
+		database := projectCfg.Components.Database
+		if database == "" {
+			database = config.DefaultDatabase
+		}
+
+		names := []string{}
+		for _, option := range componentOptions {
+			if !contains(selected, option.Label) {
+				continue
+			}
+			if option.Name == databaseOption {
+				names = append(names, database)
+			} else {
+				names = append(names, option.Name)
+			}
+		}
+
+		components, err := config.ParseComponents(names)
+		if err != nil {
+			return projectCfg, err
+		}
+		components.HTTPFramework = projectCfg.Components.HTTPFramework
+		components.CIProvider = projectCfg.Components.CIProvider
+		components.TokenFormat = projectCfg.Components.TokenFormat
+		components.JSONEngine = projectCfg.Components.JSONEngine
+		components.PostgresDriver = projectCfg.Components.PostgresDriver
+		components.DataLayer = projectCfg.Components.DataLayer
+		projectCfg.Components = components
+
+		// Ask for the database engine
+		if components.HasDatabase() {
+			options := []string{}
+			defaultLabel := ""
+			for _, option := range databaseOptions {
+				options = append(options, option.Label)
+				if option.Name == components.Database {
+					defaultLabel = option.Label
+				}
+			}
+
+			selected := ""
+			databasePrompt := &survey.Select{
+				Message: "Select the database:",
+				Options: options,
+				Default: defaultLabel,
+			}
+			if err := survey.AskOne(databasePrompt, &selected); err != nil {
+				return projectCfg, err
+			}
+
+			for _, option := range databaseOptions {
+				if option.Label == selected {
+					projectCfg.Components.Database = option.Name
+				}
+			}
+		}
+	}
+
+	// Ask for the driver of the postgres database
+	if projectCfg.Components.Database == config.ComponentPostgres && !cfg.Provided["postgres-driver"] {
+		options := []string{}
+		defaultLabel := ""
+		for _, option := range postgresDriverOptions {
+			options = append(options, option.Label)
+			if option.Name == projectCfg.Components.PostgresDriver {
+				defaultLabel = option.Label
+			}
+		}
+
+		selected := ""
+		driverPrompt := &survey.Select{
+			Message: "Select the Postgres driver:",
+			Options: options,
+			Default: defaultLabel,
+		}
+		if err := survey.AskOne(driverPrompt, &selected); err != nil {
+			return projectCfg, err
+		}
+
+		for _, option := range postgresDriverOptions {
+			if option.Label == selected {
+				projectCfg.Components.PostgresDriver = option.Name
+			}
+		}
+	}
+
+	// Ask for the data layer of the postgres database
+	if projectCfg.Components.Database == config.ComponentPostgres && !cfg.Provided["data-layer"] {
+		options := []string{}
+		defaultLabel := ""
+		for _, option := range dataLayerOptions {
+			options = append(options, option.Label)
+			if option.Name == projectCfg.Components.DataLayer {
+				defaultLabel = option.Label
+			}
+		}
+
+		selected := ""
+		layerPrompt := &survey.Select{
+			Message: "Select the data layer:",
+			Options: options,
+			Default: defaultLabel,
+		}
+		if err := survey.AskOne(layerPrompt, &selected); err != nil {
+			return projectCfg, err
+		}
+
+		for _, option := range dataLayerOptions {
+			if option.Label == selected {
+				projectCfg.Components.DataLayer = option.Name
+			}
+		}
+	}
+
+	// Ask for the HTTP framework
+	if projectCfg.Components.HTTP && !cfg.Provided["http-framework"] {
+		options := []string{}
+		defaultLabel := ""
+		for _, option := range httpFrameworkOptions {
+			options = append(options, option.Label)
+			if option.Name == projectCfg.Components.HTTPFramework {
+				defaultLabel = option.Label
+			}
+		}
+
+		selected := ""
+		frameworkPrompt := &survey.Select{
+			Message: "Select the HTTP framework:",
+			Options: options,
+			Default: defaultLabel,
+		}
+		if err := survey.AskOne(frameworkPrompt, &selected); err != nil {
+			return projectCfg, err
+		}
+
+		for _, option := range httpFrameworkOptions {
+			if option.Label == selected {
+				projectCfg.Components.HTTPFramework = option.Name
+			}
+		}
+	}
+
+	// Ask for the JSON engine of Gin
+	if projectCfg.Components.HTTP && projectCfg.Components.HTTPFramework == config.HTTPFrameworkGin && !cfg.Provided["json-engine"] {
+		options := []string{}
+		defaultLabel := ""
+		for _, option := range jsonEngineOptions {
+			options = append(options, option.Label)
+			if option.Name == projectCfg.Components.JSONEngine {
+				defaultLabel = option.Label
+			}
+		}
+
+		selected := ""
+		enginePrompt := &survey.Select{
+			Message: "Select the JSON engine of Gin:",
+			Options: options,
+			Default: defaultLabel,
+		}
+		if err := survey.AskOne(enginePrompt, &selected); err != nil {
+			return projectCfg, err
+		}
+
+		for _, option := range jsonEngineOptions {
+			if option.Label == selected {
+				projectCfg.Components.JSONEngine = option.Name
+			}
+		}
+	}
+
+	// Ask for the format of the auth tokens
+	if projectCfg.Components.Auth && !cfg.Provided["token-format"] {
+		options := []string{}
+		defaultLabel := ""
+		for _, option := range tokenFormatOptions {
+			options = append(options, option.Label)
+			if option.Name == projectCfg.Components.TokenFormat {
+				defaultLabel = option.Label
+			}
+		}
+
+		selected := ""
+		formatPrompt := &survey.Select{
+			Message: "Select the format of the auth tokens:",
+			Options: options,
+			Default: defaultLabel,
+		}
+		if err := survey.AskOne(formatPrompt, &selected); err != nil {
+			return projectCfg, err
+		}
+
+		for _, option := range tokenFormatOptions {
+			if option.Label == selected {
+				projectCfg.Components.TokenFormat = option.Name
+			}
+		}
+	}
+
+	// Ask for the CI provider; the providers of the module host come first,
+	// the others are one more question away
+	if projectCfg.Components.CICD && !cfg.Provided["ci-provider"] {
+		if provider := config.HostCIProvider(projectCfg.ModuleName); provider != "" {
+			projectCfg.Components.CIProvider = provider
+		}
+
+		compatible := config.CompatibleCIProviders(projectCfg.ModuleName)
+		selected, err := askCIProvider(compatible, projectCfg.Components.CIProvider, len(compatible) < len(config.CIProviders))
+		if err != nil {
+			return projectCfg, err
+		}
+		if selected == allCIProvidersLabel {
+			if selected, err = askCIProvider(config.CIProviders, projectCfg.Components.CIProvider, false); err != nil {
+				return projectCfg, err
+			}
+		}
+
+		for _, option := range ciProviderOptions {
+			if option.Label == selected {
+				projectCfg.Components.CIProvider = option.Name
+			}
+		}
+	}
+
+	// Ask for the container registry
+	if projectCfg.Components.Docker && !cfg.Provided["registry"] {
+		options := []string{}
+		defaultLabel := ""
+		for _, option := range registryOptions {
+			options = append(options, option.Label)
+			if option.Name == projectCfg.Registry.Kind {
+				defaultLabel = option.Label
+			}
+		}
+
+		selected := ""
+		registryPrompt := &survey.Select{
+			Message: "Select the container registry:",
+			Options: options,
+			Default: defaultLabel,
+		}
+		if err := survey.AskOne(registryPrompt, &selected); err != nil {
+			return projectCfg, err
+		}
+
+		for _, option := range registryOptions {
+			if option.Label == selected && option.Name != projectCfg.Registry.Kind {
+				projectCfg.Registry = config.Registry{Kind: option.Name}
+			}
+		}
+	}
+
+	// Ask for the registry host when the registry needs one
+	if projectCfg.Components.Docker && config.RegistryNeedsHost(projectCfg.Registry.Kind) && projectCfg.Registry.Host == "" {
+		host := ""
+		kind := projectCfg.Registry.Kind
+		prompt := &survey.Input{
+			Message: "Registry host:",
+			Help:    "e.g., 123456789012.dkr.ecr.us-east-1.amazonaws.com, us-central1-docker.pkg.dev/my-project/my-repo or registry.example.com",
+		}
+		validate := func(answer interface{}) error {
+			_, err := config.ParseRegistry(kind, fmt.Sprint(answer))
+			return err
+		}
+		if err := survey.AskOne(prompt, &host, survey.WithValidator(validate)); err != nil {
+			return projectCfg, err
+		}
+		registry, err := config.ParseRegistry(kind, host)
+		if err != nil {
+			return projectCfg, err
+		}
+		projectCfg.Registry = registry
+	}
+
+	// Ask for cross-compilation targets
+	if !cfg.Provided["build-targets"] {
+		buildTargets := []string{}
+		targetsPrompt := &survey.MultiSelect{
+			Message: "Select cross-compilation targets for the Makefile:",
+			Options: config.SupportedBuildTargets,
+			Default: projectCfg.BuildTargets,
+		}
+		if err := survey.AskOne(targetsPrompt, &buildTargets); err != nil {
+			return projectCfg, err
+		}
+		projectCfg.BuildTargets = buildTargets
+	}
+
+	// Ask whether to generate the unit tests
+	if !cfg.Provided["no-tests"] {
+		generateTests := !projectCfg.NoTests
+		testsPrompt := &survey.Confirm{
+			Message: "Generate unit tests alongside the scaffolded code?",
+			Default: generateTests,
+		}
+		if err := survey.AskOne(testsPrompt, &generateTests); err != nil {
+			return projectCfg, err
+		}
+		projectCfg.NoTests = !generateTests
 	}
 
-	// Set components
-	projectCfg.Components = config.Components{
-		HTTP:     contains(components, "HTTP (Gin)"),
-		Postgres: contains(components, "PostgreSQL"),
-		Docker:   contains(components, "Docker"),
-		CICD:     contains(components, "CI/CD"),
+	// Offer HTTPS with local development certificates
+	if !cfg.Provided["tls"] && projectCfg.Components.HTTP {
+		tlsPrompt := &survey.Confirm{
+			Message: "Support HTTPS (TLS certificate settings and make certs for local development certificates)?",
+			Default: projectCfg.TLS,
+		}
+		if err := survey.AskOne(tlsPrompt, &projectCfg.TLS); err != nil {
+			return projectCfg, err
+		}
+	}
+
+	// Offer the request coalescing example, which serves statistics of the users table
+	if !cfg.Provided["coalescing-example"] && projectCfg.Components.HTTP && projectCfg.Components.HasDatabase() {
+		examplePrompt := &survey.Confirm{
+			Message: "Generate the request coalescing example (GET /api/v1/stats)?",
+			Default: projectCfg.CoalescingExample,
+		}
+		if err := survey.AskOne(examplePrompt, &projectCfg.CoalescingExample); err != nil {
+			return projectCfg, err
+		}
+	}
+
+	// Offer the response cache, kept in Redis when it is selected
+	if !cfg.Provided["response-cache"] && projectCfg.Components.HTTP {
+		cachePrompt := &survey.Confirm{
+			Message: "Cache the responses of selected GET routes (in Redis, or in memory without it)?",
+			Default: projectCfg.ResponseCache,
+		}
+		if err := survey.AskOne(cachePrompt, &projectCfg.ResponseCache); err != nil {
+			return projectCfg, err
+		}
+	}
+
+	// Ask for the owners reported by the service descriptor of the HTTP server
+	if !cfg.Provided["owners"] && projectCfg.Components.HTTP {
+		ownersPrompt := &survey.Input{
+			Message: "Service owners:",
+			Default: strings.Join(projectCfg.ServiceOwners(), ","),
+			Help:    "Comma-separated teams or people owning the service, reported by /.well-known/service-descriptor to the service catalog",
+		}
+		validate := func(answer interface{}) error {
+			_, err := config.ParseOwnerList(fmt.Sprint(answer))
+			return err
+		}
+		var owners string
+		if err := survey.AskOne(ownersPrompt, &owners, survey.WithValidator(validate)); err != nil {
+			return projectCfg, err
+		}
+		projectCfg.Owners, _ = config.ParseOwnerList(owners)
+	}
+
+	// Ask for the default branch the CI pipeline builds and deploys
+	if !cfg.Provided["default-branch"] && projectCfg.Components.CICD && projectCfg.Components.CIProvider != config.CIProviderNone {
+		branchPrompt := &survey.Input{
+			Message: "Default branch:",
+			Default: projectCfg.Branch(),
+			Help:    "The branch the CI pipeline runs on pushes to and deploys from, e.g. main or master",
+		}
+		validate := func(answer interface{}) error {
+			return config.ValidateBranchName(fmt.Sprint(answer))
+		}
+		if err := survey.AskOne(branchPrompt, &projectCfg.DefaultBranch, survey.WithValidator(validate)); err != nil {
+			return projectCfg, err
+		}
+	}
+
+	// Offer the release automation, which needs a CI pipeline
+	if !cfg.Provided["release"] && projectCfg.Components.CICD && projectCfg.Components.CIProvider != config.CIProviderNone {
+		tool := "semantic-release"
+		if projectCfg.Components.CIProvider == config.CIProviderGitHub {
+			tool = "release-please"
+		}
+		releasePrompt := &survey.Confirm{
+			Message: "Automate releases and CHANGELOG.md from conventional commits with " + tool + "?",
+			Default: projectCfg.Release,
+		}
+		if err := survey.AskOne(releasePrompt, &projectCfg.Release); err != nil {
+			return projectCfg, err
+		}
+	}
+
+	// Offer the conventional commits setup; releases are cut from them
+	if projectCfg.HasRelease() {
+		projectCfg.ConventionalCommits = true
+	} else if !cfg.Provided["conventional-commits"] {
+		commitsPrompt := &survey.Confirm{
+			Message: "Enforce conventional commit messages (commitlint config and commit-msg hook)?",
+			Default: projectCfg.ConventionalCommits,
+		}
+		if err := survey.AskOne(commitsPrompt, &projectCfg.ConventionalCommits); err != nil {
+			return projectCfg, err
+		}
 	}
 
 	// Print configuration
@@ -84,10 +662,34 @@ func (w *Wizard) Run() (config.ProjectConfig, error) {
 		"username", projectCfg.Username,
 		"projectName", projectCfg.ProjectName,
 		"moduleName", projectCfg.ModuleName,
+		"goVersion", projectCfg.Go(),
 		"http", projectCfg.Components.HTTP,
-		"postgres", projectCfg.Components.Postgres,
+		"httpFramework", projectCfg.Components.HTTPFramework,
+		"grpc", projectCfg.Components.GRPC,
+		"database", projectCfg.Components.Database,
+		"databases", projectCfg.Databases,
+		"postgresDriver", projectCfg.Components.PostgresDriver,
+		"dataLayer", projectCfg.Components.DataLayer,
+		"redis", projectCfg.Components.Redis,
 		"docker", projectCfg.Components.Docker,
 		"cicd", projectCfg.Components.CICD,
+		"ciProvider", projectCfg.Components.CIProvider,
+		"metrics", projectCfg.Components.Metrics,
+		"tracing", projectCfg.Components.Tracing,
+		"auth", projectCfg.Components.Auth,
+		"tokenFormat", projectCfg.Components.TokenFormat,
+		"jsonEngine", projectCfg.Components.JSONEngine,
+		"image", projectCfg.Image(),
+		"buildTargets", projectCfg.BuildTargets,
+		"pkgModules", projectCfg.PkgModules,
+		"tests", !projectCfg.NoTests,
+		"coalescingExample", projectCfg.HasCoalescingExample(),
+		"responseCache", projectCfg.HasResponseCache(),
+		"owners", projectCfg.ServiceOwners(),
+		"tls", projectCfg.HasTLS(),
+		"defaultBranch", projectCfg.Branch(),
+		"conventionalCommits", projectCfg.ConventionalCommits,
+		"release", projectCfg.HasRelease(),
 	)
 
 	// Ask for confirmation
@@ -101,12 +703,98 @@ func (w *Wizard) Run() (config.ProjectConfig, error) {
 	}
 
 	if !confirmed {
-		return w.Run()
+		return w.Run(cfg)
 	}
 
 	return projectCfg, nil
 }
 
+// OfferSave asks whether to save the answers to a project config file for reproducible runs
+func (w *Wizard) OfferSave(path string, projectCfg config.ProjectConfig) error {
+	save := false
+	prompt := &survey.Confirm{
+		Message: fmt.Sprintf("Save these answers to %s for reproducible runs?", path),
+		Help:    "Re-run the generator later with --config " + path + " to get the same scaffold without the wizard",
+		Default: false,
+	}
+	if err := survey.AskOne(prompt, &save); err != nil {
+		return err
+	}
+
+	if !save {
+		return nil
+	}
+
+	if err := config.SaveProjectFile(path, projectCfg); err != nil {
+		return err
+	}
+
+	w.log.Info("Saved project configuration", "path", path)
+	return nil
+}
+
+// askName asks for a name until it is valid. An invalid answer is not just
+// rejected: its sanitized form is offered instead, and asked again if declined.
+func (w *Wizard) askName(prompt *survey.Input, validate func(string) error, sanitize func(string) string) (string, error) {
+	for {
+		name := ""
+		if err := survey.AskOne(prompt, &name, survey.WithValidator(survey.Required)); err != nil {
+			return "", err
+		}
+		err := validate(name)
+		if err == nil {
+			return name, nil
+		}
+
+		suggestion := sanitize(name)
+		if suggestion == "" {
+			w.log.Warn("Invalid name", "error", err)
+			continue
+		}
+
+		accept := false
+		confirm := &survey.Confirm{
+			Message: fmt.Sprintf("%q is not valid. Use %q instead?", name, suggestion),
+			Help:    err.Error(),
+			Default: true,
+		}
+		if err := survey.AskOne(confirm, &accept); err != nil {
+			return "", err
+		}
+		if accept {
+			return suggestion, nil
+		}
+	}
+}
+
+// ConfirmCreateDir asks whether to create a missing output directory
+func (w *Wizard) ConfirmCreateDir(path string) (bool, error) {
+	create := false
+	prompt := &survey.Confirm{
+		Message: fmt.Sprintf("Output directory %s does not exist. Create it?", path),
+		Default: true,
+	}
+	if err := survey.AskOne(prompt, &create); err != nil {
+		return false, err
+	}
+	return create, nil
+}
+
+// ConfirmPrune asks whether to delete the files the generator no longer
+// produces, listed before the question
+func (w *Wizard) ConfirmPrune(count int) (bool, error) {
+	prune := false
+	prompt := &survey.Confirm{
+		Message: fmt.Sprintf("Delete the %d files listed above?", count),
+		Help:    "They are unchanged since they were generated and no current template writes them; re-run with --prune to delete them without asking",
+		Default: false,
+	}
+	if err := survey.AskOne(prompt, &prune); err != nil {
+		return false, err
+	}
+	return prune, nil
+}
+
 // contains checks if a string is in a slice
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -116,3 +804,33 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
+
+// askCIProvider asks for one of the CI providers, with an option listing all
+// of them when more is set, and returns the selected label
+func askCIProvider(providers []string, current string, more bool) (string, error) {
+	options := []string{}
+	defaultLabel := ""
+	for _, option := range ciProviderOptions {
+		if !contains(providers, option.Name) {
+			continue
+		}
+		options = append(options, option.Label)
+		if option.Name == current {
+			defaultLabel = option.Label
+		}
+	}
+	if more {
+		options = append(options, allCIProvidersLabel)
+	}
+
+	selected := ""
+	providerPrompt := &survey.Select{
+		Message: "Select the CI provider:",
+		Options: options,
+		Default: defaultLabel,
+	}
+	if err := survey.AskOne(providerPrompt, &selected); err != nil {
+		return "", err
+	}
+	return selected, nil
+}
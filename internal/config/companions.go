@@ -0,0 +1,86 @@
+// internal/config/companions.go - Companion modules developed alongside the project
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Companion is a module developed alongside the project, such as a shared library
+type Companion struct {
+	// Module path of the companion (read from its go.mod when empty)
+	Module string `yaml:"module,omitempty"`
+	// Directory of the companion, relative to the generated project or absolute
+	Path string `yaml:"path"`
+}
+
+// parseCompanions parses a comma-separated list of companion directories
+func parseCompanions(list string) []Companion {
+	var companions []Companion
+	for _, path := range strings.Split(list, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		companions = append(companions, Companion{Path: path})
+	}
+	return companions
+}
+
+// ResolveCompanions checks that every companion directory exists relative to the
+// project directory and fills in missing module paths from the companions' go.mod
+func ResolveCompanions(projectDir string, companions []Companion) ([]Companion, error) {
+	resolved := make([]Companion, 0, len(companions))
+	for _, companion := range companions {
+		dir := companion.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(projectDir, dir)
+		}
+
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, fmt.Errorf("companion %s: directory %s does not exist", companion.Path, dir)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("companion %s: %s is not a directory", companion.Path, dir)
+		}
+
+		module, err := readModulePath(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, fmt.Errorf("companion %s: %w", companion.Path, err)
+		}
+		if companion.Module == "" {
+			companion.Module = module
+		} else if companion.Module != module {
+			return nil, fmt.Errorf("companion %s: module %s does not match go.mod (%s)", companion.Path, companion.Module, module)
+		}
+
+		resolved = append(resolved, companion)
+	}
+	return resolved, nil
+}
+
+// readModulePath returns the module path declared in a go.mod file
+func readModulePath(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if module, ok := strings.CutPrefix(line, "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	return "", fmt.Errorf("no module directive in %s", path)
+}
@@ -1,6 +1,14 @@
 // internal/config/config.go - Configuration structures for the project generator
 package config
 
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
 // Config represents the main configuration for the generator
 type Config struct {
 	// Is the generator running in interactive mode
@@ -9,6 +17,36 @@ type Config struct {
 	OutputDir string
 	// Configuration for the project to be generated
 	ProjectConfig ProjectConfig
+	// Names of the flags explicitly provided on the command line
+	Provided map[string]bool
+	// Workspace is set in monorepo mode, when the config file lists several services
+	Workspace *WorkspaceConfig
+	// Skip running go build, go vet and go test on the generated project
+	SkipVerify bool
+	// Also boot the generated project with Docker Compose and check /health and the migrations
+	VerifyDocker bool
+	// Print the files that would be generated without writing anything
+	DryRun bool
+	// Print a diff of the generated files against the files on disk without writing anything
+	Plan bool
+	// With Plan, write the new files and the files unchanged since they were generated
+	Apply bool
+	// With Plan, also delete the files the generator no longer produces that are
+	// unchanged since they were generated
+	Prune bool
+	// With Plan, resolve the conflicts of the edited files by overwriting them
+	// with the generated content, or by keeping them, instead of asking
+	AcceptAll bool
+	KeepAll   bool
+	// Skip the environment checks run before generating
+	NoDoctor bool
+	// Omit the ownership header from the generated files
+	NoHeaders bool
+	// Commands run in order in the generated project after go mod tidy
+	PostHooks []string
+	// Generate without network access: skip go mod tidy, write the indirect
+	// requirements into go.mod and skip the steps that need the module proxy
+	Offline bool
 }
 
 // ProjectConfig represents the configuration for the project to be generated
@@ -21,30 +59,1091 @@ type ProjectConfig struct {
 	ModuleName string
 	// Components to include in the project
 	Components Components
+	// Names of the database connections when there are several, main one first;
+	// empty for a single connection
+	Databases []string
+	// Cross-compilation targets for the Makefile (e.g., linux/amd64)
+	BuildTargets []string
+	// Modules developed alongside the project, added to a generated go.work
+	Companions []Companion
+	// Also add replace directives for the companions to go.mod
+	CompanionReplaces bool
+	// Subdirectories of pkg/ published as their own modules, e.g. client for pkg/client
+	PkgModules []string
+	// Container registry the Docker image is pushed to
+	Registry Registry
+	// Vendor the dependencies and build the Docker image from vendor/
+	Vendor bool
+	// Omit the generated tests
+	NoTests bool
+	// Generate the request coalescing example, GET /api/v1/stats; needs HTTP and a database
+	CoalescingExample bool
+	// Cache the responses of selected GET routes, in Redis when it is selected and
+	// in memory otherwise; needs HTTP
+	ResponseCache bool
+	// One-line summary of the service, reported by its service descriptor
+	// (defaults to "<project> service")
+	Description string
+	// Teams or people owning the service, reported by its service descriptor
+	// (defaults to the username)
+	Owners []string
+	// Default branch of the repository, which the CI pipeline builds and deploys
+	DefaultBranch string
+	// Enforce conventional commit messages with a commitlint config and a commit-msg hook
+	ConventionalCommits bool
+	// Automate releases and CHANGELOG.md from the conventional commits, with
+	// release-please on GitHub and semantic-release on the other CI providers
+	Release bool
+	// Go version of go.mod, the Docker build image and the CI pipeline (e.g., 1.23)
+	GoVersion string
+	// Serve HTTPS when a certificate is configured, with a generator of local
+	// development certificates; needs HTTP
+	TLS bool
+}
+
+// WorkspaceConfig represents a monorepo of several services sharing a go.work
+type WorkspaceConfig struct {
+	// Name of the workspace root directory
+	Name string
+	// Module path prefix for the shared pkg module and the services
+	ModuleName string
+	// Services generated into services/<name>, each from its own configuration
+	Services []ProjectConfig
+}
+
+// SupportedBuildTargets lists the GOOS/GOARCH pairs offered for cross-compilation
+var SupportedBuildTargets = []string{
+	"linux/amd64",
+	"linux/arm64",
+	"darwin/amd64",
+	"darwin/arm64",
+	"windows/amd64",
+}
+
+// DefaultBuildTargets lists the cross-compilation targets selected by default
+var DefaultBuildTargets = []string{
+	"linux/amd64",
+	"linux/arm64",
+	"darwin/arm64",
 }
 
 // Components represents the components to include in the project
 type Components struct {
-	// Include HTTP server with Gin
+	// Include HTTP server
 	HTTP bool
-	// Include PostgreSQL database
-	Postgres bool
+	// Framework used by the HTTP server (gin, echo, chi or stdlib)
+	HTTPFramework string
+	// Include gRPC server with protobuf scaffolding
+	GRPC bool
+	// Database engine (postgres, mysql or sqlite); empty when no database is included
+	Database string
+	// Include Redis cache
+	Redis bool
 	// Include Docker support
 	Docker bool
 	// Include CI/CD configuration
 	CICD bool
+	// CI provider the pipeline is generated for (github, gitlab, bitbucket, gitea or none)
+	CIProvider string
+	// Include Prometheus metrics for the HTTP server
+	Metrics bool
+	// Include OpenTelemetry tracing
+	Tracing bool
+	// Include token authentication for the HTTP server
+	Auth bool
+	// Format of the tokens issued by the auth component (jwt, paseto-local or paseto-public)
+	TokenFormat string
+	// JSON engine of the Gin framework (stdlib, go-json, jsoniter or sonic)
+	JSONEngine string
+	// Driver of the postgres database (pq or pgx)
+	PostgresDriver string
+	// Data layer of the postgres database (sqlx or sqlc)
+	DataLayer string
+}
+
+// Component names accepted on the command line
+const (
+	ComponentHTTP     = "http"
+	ComponentGRPC     = "grpc"
+	ComponentPostgres = "postgres"
+	ComponentMySQL    = "mysql"
+	ComponentSQLite   = "sqlite"
+	ComponentRedis    = "redis"
+	ComponentDocker   = "docker"
+	ComponentCICD     = "cicd"
+	ComponentMetrics  = "metrics"
+	ComponentTracing  = "tracing"
+	ComponentAuth     = "auth"
+)
+
+// ComponentNames lists all component names in display order
+var ComponentNames = []string{
+	ComponentHTTP,
+	ComponentGRPC,
+	ComponentPostgres,
+	ComponentMySQL,
+	ComponentSQLite,
+	ComponentRedis,
+	ComponentDocker,
+	ComponentCICD,
+	ComponentMetrics,
+	ComponentTracing,
+	ComponentAuth,
+}
+
+// Databases lists the database engines in display order; each is selected as a component
+var Databases = []string{
+	ComponentPostgres,
+	ComponentMySQL,
+	ComponentSQLite,
+}
+
+// DefaultDatabase is the database engine offered first
+const DefaultDatabase = ComponentPostgres
+
+// DefaultComponents lists the components selected by default
+var DefaultComponents = []string{
+	ComponentHTTP,
+}
+
+// HTTP frameworks accepted on the command line
+const (
+	HTTPFrameworkGin    = "gin"
+	HTTPFrameworkEcho   = "echo"
+	HTTPFrameworkChi    = "chi"
+	HTTPFrameworkStdlib = "stdlib"
+)
+
+// HTTPFrameworks lists all HTTP frameworks in display order
+var HTTPFrameworks = []string{
+	HTTPFrameworkGin,
+	HTTPFrameworkEcho,
+	HTTPFrameworkChi,
+	HTTPFrameworkStdlib,
+}
+
+// DefaultHTTPFramework is the HTTP framework used when none is selected
+const DefaultHTTPFramework = HTTPFrameworkGin
+
+// ParseHTTPFramework validates an HTTP framework name
+func ParseHTTPFramework(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DefaultHTTPFramework, nil
+	}
+	if !contains(HTTPFrameworks, name) {
+		return "", fmt.Errorf("unknown HTTP framework %q (available: %s)", name, strings.Join(HTTPFrameworks, ", "))
+	}
+	return name, nil
+}
+
+// CI providers accepted on the command line
+const (
+	CIProviderGitHub    = "github"
+	CIProviderGitLab    = "gitlab"
+	CIProviderBitbucket = "bitbucket"
+	CIProviderGitea     = "gitea"
+	CIProviderNone      = "none"
+)
+
+// CIProviders lists all CI providers in display order
+var CIProviders = []string{
+	CIProviderGitHub,
+	CIProviderGitLab,
+	CIProviderBitbucket,
+	CIProviderGitea,
+	CIProviderNone,
+}
+
+// DefaultCIProvider is the CI provider used when none is selected
+const DefaultCIProvider = CIProviderGitHub
+
+// DefaultBranch is the default branch name used when none is given
+const DefaultBranch = "main"
+
+// ParseCIProvider validates a CI provider name
+func ParseCIProvider(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DefaultCIProvider, nil
+	}
+	if !contains(CIProviders, name) {
+		return "", fmt.Errorf("unknown CI provider %q (available: %s)", name, strings.Join(CIProviders, ", "))
+	}
+	return name, nil
+}
+
+// Token formats of the auth component accepted on the command line
+const (
+	TokenFormatJWT          = "jwt"
+	TokenFormatPASETOLocal  = "paseto-local"
+	TokenFormatPASETOPublic = "paseto-public"
+)
+
+// TokenFormats lists all token formats in display order
+var TokenFormats = []string{
+	TokenFormatJWT,
+	TokenFormatPASETOLocal,
+	TokenFormatPASETOPublic,
+}
+
+// DefaultTokenFormat is the token format used when none is selected
+const DefaultTokenFormat = TokenFormatJWT
+
+// ParseTokenFormat validates a token format name
+func ParseTokenFormat(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DefaultTokenFormat, nil
+	}
+	if !contains(TokenFormats, name) {
+		return "", fmt.Errorf("unknown token format %q (available: %s)", name, strings.Join(TokenFormats, ", "))
+	}
+	return name, nil
+}
+
+// JSON engines of the Gin framework accepted on the command line
+const (
+	JSONEngineStdlib   = "stdlib"
+	JSONEngineGoJSON   = "go-json"
+	JSONEngineJsoniter = "jsoniter"
+	JSONEngineSonic    = "sonic"
+)
+
+// JSONEngines lists all JSON engines in display order
+var JSONEngines = []string{
+	JSONEngineStdlib,
+	JSONEngineGoJSON,
+	JSONEngineJsoniter,
+	JSONEngineSonic,
+}
+
+// DefaultJSONEngine is the JSON engine used when none is selected; encoding/json
+// builds everywhere, without cgo or assembly
+const DefaultJSONEngine = JSONEngineStdlib
+
+// jsonEngineTags maps the JSON engines to the build tags selecting them in Gin; sonic
+// also needs avx, and Gin falls back to encoding/json outside of amd64
+var jsonEngineTags = map[string]string{
+	JSONEngineGoJSON:   "go_json",
+	JSONEngineJsoniter: "jsoniter",
+	JSONEngineSonic:    "sonic,avx",
+}
+
+// ParseJSONEngine validates a JSON engine name
+func ParseJSONEngine(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DefaultJSONEngine, nil
+	}
+	if !contains(JSONEngines, name) {
+		return "", fmt.Errorf("unknown JSON engine %q (available: %s)", name, strings.Join(JSONEngines, ", "))
+	}
+	return name, nil
+}
+
+// Postgres drivers accepted on the command line
+const (
+	PostgresDriverPQ  = "pq"
+	PostgresDriverPgx = "pgx"
+)
+
+// PostgresDrivers lists all Postgres drivers in display order
+var PostgresDrivers = []string{
+	PostgresDriverPQ,
+	PostgresDriverPgx,
+}
+
+// DefaultPostgresDriver is the Postgres driver used when none is selected; lib/pq
+// keeps the projects generated before pgx was offered unchanged
+const DefaultPostgresDriver = PostgresDriverPQ
+
+// ParsePostgresDriver validates a Postgres driver name
+func ParsePostgresDriver(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DefaultPostgresDriver, nil
+	}
+	if !contains(PostgresDrivers, name) {
+		return "", fmt.Errorf("unknown Postgres driver %q (available: %s)", name, strings.Join(PostgresDrivers, ", "))
+	}
+	return name, nil
+}
+
+// Data layers accepted on the command line
+const (
+	DataLayerSQLX = "sqlx"
+	DataLayerSQLC = "sqlc"
+)
+
+// DataLayers lists all data layers in display order
+var DataLayers = []string{
+	DataLayerSQLX,
+	DataLayerSQLC,
+}
+
+// DefaultDataLayer is the data layer used when none is selected; the hand-written
+// sqlx repositories keep the projects generated before sqlc was offered unchanged
+const DefaultDataLayer = DataLayerSQLX
+
+// ParseDataLayer validates a data layer name
+func ParseDataLayer(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DefaultDataLayer, nil
+	}
+	if !contains(DataLayers, name) {
+		return "", fmt.Errorf("unknown data layer %q (available: %s)", name, strings.Join(DataLayers, ", "))
+	}
+	return name, nil
+}
+
+// ParseComponents builds Components from a list of component names
+func ParseComponents(names []string) (Components, error) {
+	var components Components
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+			continue
+		case ComponentHTTP:
+			components.HTTP = true
+		case ComponentGRPC:
+			components.GRPC = true
+		case ComponentPostgres, ComponentMySQL, ComponentSQLite:
+			database := strings.ToLower(strings.TrimSpace(name))
+			if components.Database != "" && components.Database != database {
+				return components, fmt.Errorf("only one database can be selected, got %s and %s", components.Database, database)
+			}
+			components.Database = database
+		case ComponentRedis:
+			components.Redis = true
+		case ComponentDocker:
+			components.Docker = true
+		case ComponentCICD:
+			components.CICD = true
+		case ComponentMetrics:
+			components.Metrics = true
+		case ComponentTracing:
+			components.Tracing = true
+		case ComponentAuth:
+			components.Auth = true
+		default:
+			return components, fmt.Errorf("unknown component %q (available: %s)", name, strings.Join(ComponentNames, ", "))
+		}
+	}
+
+	// Metrics are collected by the HTTP middleware and served on /metrics
+	if components.Metrics && !components.HTTP {
+		return components, fmt.Errorf("the %s component requires %s", ComponentMetrics, ComponentHTTP)
+	}
+
+	// Authentication adds middleware and endpoints to the HTTP server
+	if components.Auth && !components.HTTP {
+		return components, fmt.Errorf("the %s component requires %s", ComponentAuth, ComponentHTTP)
+	}
+	return components, nil
+}
+
+// HasDatabase reports whether a database engine is selected
+func (c Components) HasDatabase() bool {
+	return c.Database != ""
+}
+
+// HasPASETO reports whether the auth component issues PASETO tokens instead of JWTs
+func (c Components) HasPASETO() bool {
+	return c.Auth && (c.TokenFormat == TokenFormatPASETOLocal || c.TokenFormat == TokenFormatPASETOPublic)
+}
+
+// HasJSONEngine reports whether Gin is built with another JSON engine than encoding/json;
+// the wizard may drop HTTP or switch frameworks after the engine was set
+func (c Components) HasJSONEngine() bool {
+	return c.HTTP && c.HTTPFramework == HTTPFrameworkGin && jsonEngineTags[c.JSONEngine] != ""
+}
+
+// HasPgx reports whether the postgres database is accessed with pgx instead of lib/pq;
+// the wizard may switch databases after the driver was set
+func (c Components) HasPgx() bool {
+	return c.Database == ComponentPostgres && c.PostgresDriver == PostgresDriverPgx
+}
+
+// HasSQLC reports whether the user queries of the postgres database are generated by
+// sqlc instead of written with sqlx; the wizard may switch databases after the data
+// layer was set
+func (c Components) HasSQLC() bool {
+	return c.Database == ComponentPostgres && c.DataLayer == DataLayerSQLC
+}
+
+// HasModelGenerator reports whether the project has the model generator, which
+// derives the models and schema docs from the migrations; sqlc replaces it
+func (c Components) HasModelGenerator() bool {
+	return c.HasDatabase() && !c.HasSQLC()
+}
+
+// BuildTags returns the comma-separated build tags the project is built, tested and
+// linted with, which select the JSON engine of Gin; empty when there are none
+func (c Components) BuildTags() string {
+	if !c.HasJSONEngine() {
+		return ""
+	}
+	return jsonEngineTags[c.JSONEngine]
+}
+
+// HasLoginAttemptsTable reports whether the failed logins counted by the auth lockout
+// are stored in the login_attempts table, which is when there is a database but no Redis
+func (c Components) HasLoginAttemptsTable() bool {
+	return c.Auth && c.HasDatabase() && !c.Redis
+}
+
+// Without returns the components without the named one, which must be
+// selected; it fails when another selected component requires it
+func (c Components) Without(name string) (Components, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	names := c.Names()
+	if !contains(names, name) {
+		return c, fmt.Errorf("the %s component is not selected (selected: %s)", name, strings.Join(names, ", "))
+	}
+
+	var remaining []string
+	for _, selected := range names {
+		if selected != name {
+			remaining = append(remaining, selected)
+		}
+	}
+	without, err := ParseComponents(remaining)
+	if err != nil {
+		return c, fmt.Errorf("cannot remove the %s component: %w", name, err)
+	}
+	without.HTTPFramework, without.CIProvider, without.TokenFormat, without.JSONEngine = c.HTTPFramework, c.CIProvider, c.TokenFormat, c.JSONEngine
+	without.PostgresDriver, without.DataLayer = c.PostgresDriver, c.DataLayer
+	return without, nil
+}
+
+// With returns the components with the named one added, which must not be
+// selected yet; it fails when the component needs one that isn't selected
+func (c Components) With(name string) (Components, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	names := c.Names()
+	if contains(names, name) {
+		return c, fmt.Errorf("the %s component is already selected", name)
+	}
+
+	with, err := ParseComponents(append(names, name))
+	if err != nil {
+		return c, fmt.Errorf("cannot add the %s component: %w", name, err)
+	}
+	with.HTTPFramework, with.CIProvider, with.TokenFormat, with.JSONEngine = c.HTTPFramework, c.CIProvider, c.TokenFormat, c.JSONEngine
+	with.PostgresDriver, with.DataLayer = c.PostgresDriver, c.DataLayer
+	return with, nil
+}
+
+// Branch returns the default branch of the repository, main unless another was configured
+func (p ProjectConfig) Branch() string {
+	if p.DefaultBranch == "" {
+		return DefaultBranch
+	}
+	return p.DefaultBranch
+}
+
+// HasTLS reports whether HTTPS support is generated; the wizard may drop HTTP
+// after the option was set
+func (p ProjectConfig) HasTLS() bool {
+	return p.TLS && p.Components.HTTP
+}
+
+// HasRelease reports whether the release automation is generated; the wizard
+// may drop the CI pipeline after the option was set
+func (p ProjectConfig) HasRelease() bool {
+	return p.Release && p.Components.CICD && p.Components.CIProvider != CIProviderNone
+}
+
+// HasCoalescingExample reports whether the request coalescing example is generated;
+// the wizard may drop HTTP or the database after the option was set
+func (p ProjectConfig) HasCoalescingExample() bool {
+	return p.CoalescingExample && p.Components.HTTP && p.Components.HasDatabase()
+}
+
+// HasResponseCache reports whether the HTTP response cache is generated; the
+// wizard may drop HTTP after the option was set
+func (p ProjectConfig) HasResponseCache() bool {
+	return p.ResponseCache && p.Components.HTTP
+}
+
+// ServiceDescription returns the description reported by the service descriptor
+func (p ProjectConfig) ServiceDescription() string {
+	if p.Description != "" {
+		return p.Description
+	}
+	return p.ProjectName + " service"
 }
 
+// ServiceOwners returns the owners reported by the service descriptor
+func (p ProjectConfig) ServiceOwners() []string {
+	if len(p.Owners) > 0 {
+		return p.Owners
+	}
+	return []string{p.Username}
+}
+
+// Names returns the names of the enabled components
+func (c Components) Names() []string {
+	var names []string
+	if c.HTTP {
+		names = append(names, ComponentHTTP)
+	}
+	if c.GRPC {
+		names = append(names, ComponentGRPC)
+	}
+	if c.Database != "" {
+		names = append(names, c.Database)
+	}
+	if c.Redis {
+		names = append(names, ComponentRedis)
+	}
+	if c.Docker {
+		names = append(names, ComponentDocker)
+	}
+	if c.CICD {
+		names = append(names, ComponentCICD)
+	}
+	if c.Metrics {
+		names = append(names, ComponentMetrics)
+	}
+	if c.Tracing {
+		names = append(names, ComponentTracing)
+	}
+	if c.Auth {
+		names = append(names, ComponentAuth)
+	}
+	return names
+}
+
+// updateFixedFlags are the flags rejected when updating a project, which is
+// regenerated in place, from the configuration recorded in its manifest
+var updateFixedFlags = []string{"config", "output", "username", "project", "module", "dry-run", "apply"}
+
 // ParseArgs parses command line arguments
 func ParseArgs(args []string) (*Config, error) {
+	return parseArgs(args, nil)
+}
+
+// ParseUpdateArgs parses the arguments of the update command. recorded is the
+// configuration the project was last generated with, which the flags change
+// like the values of a config file; the run applies a plan, or only prints it
+// with --plan.
+func ParseUpdateArgs(args []string, recorded *ProjectFile) (*Config, error) {
+	return parseArgs(args, recorded)
+}
+
+// parseArgs parses command line arguments on top of recorded, when updating a project
+func parseArgs(args []string, recorded *ProjectFile) (*Config, error) {
 	// Default configuration with interactive mode
 	cfg := &Config{
 		IsInteractive: true,
 		OutputDir:     ".",
+		Provided:      map[string]bool{},
+	}
+
+	var (
+		configPath     string
+		components     string
+		preset         string
+		httpFramework  string
+		ciProvider     string
+		tokenFormat    string
+		jsonEngine     string
+		postgresDriver string
+		dataLayer      string
+		buildTargets   string
+		databases      string
+		companions     string
+		pkgModules     string
+		registry       string
+		registryHost   string
+		owners         string
+		hooks          hookList
+	)
+
+	fs := flag.NewFlagSet("go-project-gen", flag.ContinueOnError)
+	fs.StringVar(&configPath, "config", "", "Path to a YAML or JSON project config file")
+	fs.StringVar(&cfg.OutputDir, "output", ".", "Directory to generate the project in (created if missing)")
+	fs.StringVar(&cfg.ProjectConfig.Username, "username", "", "GitHub username or organization")
+	fs.StringVar(&cfg.ProjectConfig.ProjectName, "project", "", "Project name")
+	fs.StringVar(&cfg.ProjectConfig.ModuleName, "module", "", "Go module path (default github.com/<username>/<project>)")
+	fs.StringVar(&cfg.ProjectConfig.GoVersion, "go-version", DefaultGoVersion, "Go version of go.mod, the Docker build image and the CI pipeline ("+MinGoVersion+" to "+MaxGoVersion+")")
+	fs.StringVar(&components, "components", strings.Join(DefaultComponents, ","), "Comma-separated components to include ("+strings.Join(ComponentNames, ", ")+")")
+	fs.StringVar(&preset, "preset", "", "Named component set ("+strings.Join(PresetNames(), ", ")+"); replaces --components")
+	fs.StringVar(&httpFramework, "http-framework", DefaultHTTPFramework, "HTTP framework ("+strings.Join(HTTPFrameworks, ", ")+")")
+	fs.StringVar(&ciProvider, "ci-provider", DefaultCIProvider, "CI provider for the cicd component ("+strings.Join(CIProviders, ", ")+")")
+	fs.StringVar(&tokenFormat, "token-format", DefaultTokenFormat, "Format of the tokens issued by the auth component ("+strings.Join(TokenFormats, ", ")+")")
+	fs.StringVar(&jsonEngine, "json-engine", DefaultJSONEngine, "JSON engine Gin encodes and decodes with, selected by build tags ("+strings.Join(JSONEngines, ", ")+")")
+	fs.StringVar(&postgresDriver, "postgres-driver", DefaultPostgresDriver, "Driver of the postgres component ("+strings.Join(PostgresDrivers, ", ")+"); pgx pools connections with pgxpool")
+	fs.StringVar(&dataLayer, "data-layer", DefaultDataLayer, "Data layer of the postgres component ("+strings.Join(DataLayers, ", ")+"); sqlc generates the user queries from SQL")
+	fs.StringVar(&buildTargets, "build-targets", strings.Join(DefaultBuildTargets, ","), "Comma-separated GOOS/GOARCH cross-compilation targets")
+	fs.StringVar(&databases, "databases", "", "Comma-separated names of the database connections, main one first (e.g. main,analytics)")
+	fs.StringVar(&companions, "companions", "", "Comma-separated directories of companion modules to add to go.work, relative to the project")
+	fs.BoolVar(&cfg.ProjectConfig.CompanionReplaces, "companion-replaces", false, "Also add replace directives for the companion modules to go.mod")
+	fs.StringVar(&pkgModules, "pkg-modules", "", "Comma-separated pkg/ subdirectories to publish as their own Go modules (e.g. client,events)")
+	fs.StringVar(&registry, "registry", DefaultRegistry, "Container registry for the Docker image ("+strings.Join(Registries, ", ")+")")
+	fs.StringVar(&registryHost, "registry-host", "", "Registry host for ecr, gar and custom registries")
+	fs.BoolVar(&cfg.ProjectConfig.Vendor, "vendor", false, "Run go mod vendor and build the Docker image from vendor/ without network access")
+	fs.BoolVar(&cfg.ProjectConfig.NoTests, "no-tests", false, "Omit the generated unit tests")
+	fs.StringVar(&cfg.ProjectConfig.DefaultBranch, "default-branch", DefaultBranch, "Default branch of the repository, built and deployed by the CI pipeline")
+	fs.BoolVar(&cfg.ProjectConfig.ConventionalCommits, "conventional-commits", false, "Enforce conventional commit messages with a commitlint config and a commit-msg hook")
+	fs.BoolVar(&cfg.ProjectConfig.Release, "release", false, "Automate releases and CHANGELOG.md from conventional commits in the CI pipeline (release-please on GitHub, semantic-release elsewhere)")
+	fs.BoolVar(&cfg.ProjectConfig.TLS, "tls", false, "Serve HTTPS when TLS_CERT_FILE and TLS_KEY_FILE are set, and generate make certs for local development certificates")
+	fs.BoolVar(&cfg.ProjectConfig.CoalescingExample, "coalescing-example", false, "Generate an example endpoint, GET /api/v1/stats, coalescing concurrent requests with singleflight")
+	fs.BoolVar(&cfg.ProjectConfig.ResponseCache, "response-cache", false, "Cache the responses of selected GET routes, such as /status, in Redis or in memory")
+	fs.StringVar(&cfg.ProjectConfig.Description, "description", "", "One-line summary of the service, reported by /.well-known/service-descriptor (default \"<project> service\")")
+	fs.StringVar(&owners, "owners", "", "Comma-separated teams or people owning the service, reported by /.well-known/service-descriptor (default the username)")
+	fs.BoolVar(&cfg.DryRun, "dry-run", false, "Print the files and directories that would be generated without writing anything")
+	fs.BoolVar(&cfg.Plan, "plan", false, "Print a diff of the generated files against an existing project without writing anything")
+	fs.BoolVar(&cfg.Apply, "apply", false, "With --plan, write the new files and the files unchanged since they were generated, keeping edited ones")
+	fs.BoolVar(&cfg.AcceptAll, "accept-all", false, "With --plan --apply, update or add, overwrite the files edited since they were generated with the generated content instead of asking")
+	fs.BoolVar(&cfg.KeepAll, "keep-all", false, "With --plan --apply, update or add, keep the files edited since they were generated without asking, recording the decision")
+	fs.BoolVar(&cfg.Prune, "prune", false, "With --plan --apply or update, delete the files no longer generated that are unchanged since they were generated, without asking")
+	fs.BoolVar(&cfg.NoDoctor, "no-doctor", false, "Skip the environment checks run before generating")
+	fs.BoolVar(&cfg.NoHeaders, "no-headers", false, "Omit the \"Code generated by go-project-gen\" header from the generated files")
+	fs.BoolVar(&cfg.SkipVerify, "skip-verify", false, "Skip running go build, go vet and go test on the generated project")
+	fs.Var(&hooks, "post-hook", "Command to run in the generated project after go mod tidy, with PROJECT_NAME, MODULE_NAME and OUTPUT_DIR set; repeat to run several in order")
+	fs.BoolVar(&cfg.Offline, "offline", false, "Generate without network access: skip go mod tidy and the verification and write a fully specified go.mod (default when GOPROXY=off or GOFLAGS has -mod=vendor)")
+	fs.BoolVar(&cfg.VerifyDocker, "verify-docker", false, "Also build the image, start the project with docker compose, check /health and the applied migrations, then tear it down")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		cfg.Provided[f.Name] = true
+	})
+
+	if cfg.Provided["preset"] && cfg.Provided["components"] {
+		return nil, fmt.Errorf("--preset and --components cannot be combined")
+	}
+	if cfg.SkipVerify && cfg.VerifyDocker {
+		return nil, fmt.Errorf("--skip-verify and --verify-docker cannot be combined")
+	}
+	if cfg.Apply && !cfg.Plan {
+		return nil, fmt.Errorf("--apply requires --plan")
+	}
+	if cfg.Prune && !cfg.Plan && recorded == nil {
+		return nil, fmt.Errorf("--prune requires --plan")
+	}
+	if cfg.AcceptAll && cfg.KeepAll {
+		return nil, fmt.Errorf("--accept-all and --keep-all cannot be combined")
+	}
+	if (cfg.AcceptAll || cfg.KeepAll) && !cfg.Plan && recorded == nil {
+		return nil, fmt.Errorf("--accept-all and --keep-all require --plan")
+	}
+	if cfg.Plan && cfg.DryRun {
+		return nil, fmt.Errorf("--plan and --dry-run cannot be combined")
+	}
+
+	// go mod tidy cannot reach the module proxy when the go command may not, so
+	// such an environment turns on --offline unless it was given
+	offlineSetting := OfflineEnvironment()
+	if offlineSetting != "" && !cfg.Provided["offline"] {
+		cfg.Offline = true
+	}
+	if cfg.Offline && cfg.VerifyDocker {
+		if !cfg.Provided["offline"] {
+			return nil, fmt.Errorf("%s turns on --offline, which cannot be combined with --verify-docker", offlineSetting)
+		}
+		return nil, fmt.Errorf("--offline and --verify-docker cannot be combined")
+	}
+
+	// Updating regenerates the project in place and applies the plan unless only asked for it
+	if recorded != nil {
+		for _, name := range updateFixedFlags {
+			if cfg.Provided[name] {
+				return nil, fmt.Errorf("--%s cannot be used on an existing project, which stays where it is", name)
+			}
+		}
+		cfg.Plan, cfg.Apply = true, !cfg.Plan
+	}
+
+	// Load the project config file, or take the recorded configuration when
+	// updating; explicit flags take precedence over its values
+	file := recorded
+	if configPath != "" {
+		var err error
+		file, err = LoadProjectFile(configPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if file != nil {
+		fileCfg := file.ProjectConfig()
+
+		if !cfg.Provided["username"] {
+			cfg.ProjectConfig.Username = fileCfg.Username
+		}
+		if !cfg.Provided["project"] {
+			cfg.ProjectConfig.ProjectName = fileCfg.ProjectName
+		}
+		if !cfg.Provided["module"] && !cfg.Provided["username"] && !cfg.Provided["project"] {
+			cfg.ProjectConfig.ModuleName = fileCfg.ModuleName
+		}
+		if !cfg.Provided["components"] && !cfg.Provided["preset"] && file.Preset != "" {
+			preset = file.Preset
+			cfg.Provided["preset"] = true
+		}
+		if !cfg.Provided["components"] && file.Components != nil {
+			components = strings.Join(file.Components, ",")
+			cfg.Provided["components"] = true
+		}
+		if !cfg.Provided["http-framework"] && file.HTTPFramework != "" {
+			httpFramework = file.HTTPFramework
+			cfg.Provided["http-framework"] = true
+		}
+		if !cfg.Provided["ci-provider"] && file.CIProvider != "" {
+			ciProvider = file.CIProvider
+			cfg.Provided["ci-provider"] = true
+		}
+		if !cfg.Provided["token-format"] && file.TokenFormat != "" {
+			tokenFormat = file.TokenFormat
+			cfg.Provided["token-format"] = true
+		}
+		if !cfg.Provided["json-engine"] && file.JSONEngine != "" {
+			jsonEngine = file.JSONEngine
+			cfg.Provided["json-engine"] = true
+		}
+		if !cfg.Provided["postgres-driver"] && file.PostgresDriver != "" {
+			postgresDriver = file.PostgresDriver
+			cfg.Provided["postgres-driver"] = true
+		}
+		if !cfg.Provided["data-layer"] && file.DataLayer != "" {
+			dataLayer = file.DataLayer
+			cfg.Provided["data-layer"] = true
+		}
+		if !cfg.Provided["build-targets"] && file.BuildTargets != nil {
+			buildTargets = strings.Join(file.BuildTargets, ",")
+			cfg.Provided["build-targets"] = true
+		}
+		if !cfg.Provided["databases"] && file.Databases != nil {
+			databases = strings.Join(file.Databases, ",")
+			cfg.Provided["databases"] = true
+		}
+		if !cfg.Provided["registry"] && file.Registry != "" {
+			registry = file.Registry
+			cfg.Provided["registry"] = true
+		}
+		if !cfg.Provided["registry-host"] && file.RegistryHost != "" {
+			registryHost = file.RegistryHost
+			cfg.Provided["registry-host"] = true
+		}
+		if !cfg.Provided["companions"] {
+			cfg.ProjectConfig.Companions = file.Companions
+		}
+		if !cfg.Provided["companion-replaces"] {
+			cfg.ProjectConfig.CompanionReplaces = file.CompanionReplaces
+		}
+		if !cfg.Provided["pkg-modules"] && file.PkgModules != nil {
+			pkgModules = strings.Join(file.PkgModules, ",")
+			cfg.Provided["pkg-modules"] = true
+		}
+		if !cfg.Provided["vendor"] {
+			cfg.ProjectConfig.Vendor = file.Vendor
+		}
+		if !cfg.Provided["no-tests"] {
+			cfg.ProjectConfig.NoTests = file.NoTests
+		}
+		if !cfg.Provided["coalescing-example"] {
+			cfg.ProjectConfig.CoalescingExample = file.CoalescingExample
+		}
+		if !cfg.Provided["response-cache"] {
+			cfg.ProjectConfig.ResponseCache = file.ResponseCache
+		}
+		if !cfg.Provided["description"] {
+			cfg.ProjectConfig.Description = file.Description
+		}
+		if !cfg.Provided["owners"] && file.Owners != nil {
+			owners = strings.Join(file.Owners, ",")
+			cfg.Provided["owners"] = true
+		}
+		if !cfg.Provided["default-branch"] && file.DefaultBranch != "" {
+			cfg.ProjectConfig.DefaultBranch = file.DefaultBranch
+			cfg.Provided["default-branch"] = true
+		}
+		if !cfg.Provided["conventional-commits"] {
+			cfg.ProjectConfig.ConventionalCommits = file.ConventionalCommits
+		}
+		if !cfg.Provided["release"] {
+			cfg.ProjectConfig.Release = file.Release
+		}
+		if !cfg.Provided["tls"] {
+			cfg.ProjectConfig.TLS = file.TLS
+		}
+		if !cfg.Provided["go-version"] && file.GoVersion != "" {
+			cfg.ProjectConfig.GoVersion = file.GoVersion
+			cfg.Provided["go-version"] = true
+		}
+		cfg.PostHooks = file.Hooks
+	}
+
+	// Hooks given on the command line replace those from the config file
+	if cfg.Provided["post-hook"] {
+		cfg.PostHooks = hooks
 	}
 
-	// TODO: Add argument parsing logic if needed
-	// For now, just return the default configuration
+	// A preset replaces the components, including those from the config file
+	if cfg.Provided["preset"] {
+		selected, err := ParsePreset(preset)
+		if err != nil {
+			return nil, err
+		}
+		components = strings.Join(selected.Components, ",")
+		cfg.Provided["components"] = true
+	}
+
+	// Companions given on the command line replace those from the config file
+	if cfg.Provided["companions"] {
+		cfg.ProjectConfig.Companions = parseCompanions(companions)
+	}
+
+	// Validate and set components
+	parsed, err := ParseComponents(strings.Split(components, ","))
+	if err != nil {
+		return nil, err
+	}
+	cfg.ProjectConfig.Components = parsed
+
+	// Validate and set the HTTP framework
+	framework, err := ParseHTTPFramework(httpFramework)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ProjectConfig.Components.HTTPFramework = framework
+
+	// Validate and set the CI provider
+	provider, err := ParseCIProvider(ciProvider)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ProjectConfig.Components.CIProvider = provider
+
+	// Validate and set the token format of the auth component
+	format, err := ParseTokenFormat(tokenFormat)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ProjectConfig.Components.TokenFormat = format
+
+	// Validate and set the JSON engine, which is selected by the build tags of Gin
+	engine, err := ParseJSONEngine(jsonEngine)
+	if err != nil {
+		return nil, err
+	}
+	if engine != JSONEngineStdlib && !(parsed.HTTP && framework == HTTPFrameworkGin) {
+		return nil, fmt.Errorf("--json-engine %s requires the %s component with the %s framework", engine, ComponentHTTP, HTTPFrameworkGin)
+	}
+	cfg.ProjectConfig.Components.JSONEngine = engine
+
+	// Validate and set the driver of the postgres database
+	driver, err := ParsePostgresDriver(postgresDriver)
+	if err != nil {
+		return nil, err
+	}
+	if driver != DefaultPostgresDriver && parsed.Database != ComponentPostgres {
+		return nil, fmt.Errorf("--postgres-driver %s requires the %s component", driver, ComponentPostgres)
+	}
+	cfg.ProjectConfig.Components.PostgresDriver = driver
+
+	// Validate and set the data layer of the postgres database
+	layer, err := ParseDataLayer(dataLayer)
+	if err != nil {
+		return nil, err
+	}
+	if layer != DefaultDataLayer && parsed.Database != ComponentPostgres {
+		return nil, fmt.Errorf("--data-layer %s requires the %s component", layer, ComponentPostgres)
+	}
+	cfg.ProjectConfig.Components.DataLayer = layer
+
+	// Validate and set the database connections; names need a database engine
+	names, err := parseDatabaseNames(databases)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) > 0 && !parsed.HasDatabase() {
+		return nil, fmt.Errorf("--databases requires a database component (%s)", strings.Join(Databases, ", "))
+	}
+	cfg.ProjectConfig.Databases = names
+
+	// Validate and set the nested modules of pkg/
+	modules, err := parsePkgModules(pkgModules)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ProjectConfig.PkgModules = modules
+
+	// The coalescing example serves statistics of the users table over HTTP
+	if cfg.ProjectConfig.CoalescingExample && !(parsed.HTTP && parsed.HasDatabase()) {
+		return nil, fmt.Errorf("--coalescing-example requires the %s component and a database component (%s)", ComponentHTTP, strings.Join(Databases, ", "))
+	}
+
+	// Responses are cached by the middleware of the HTTP server
+	if cfg.ProjectConfig.ResponseCache && !parsed.HTTP {
+		return nil, fmt.Errorf("--response-cache requires the %s component", ComponentHTTP)
+	}
+
+	// The service descriptor is served by the HTTP server
+	if err := ValidateDescription(cfg.ProjectConfig.Description); err != nil {
+		return nil, err
+	}
+	serviceOwners, err := ParseOwnerList(owners)
+	if err != nil {
+		return nil, err
+	}
+	if (cfg.ProjectConfig.Description != "" || len(serviceOwners) > 0) && !parsed.HTTP {
+		return nil, fmt.Errorf("--description and --owners require the %s component", ComponentHTTP)
+	}
+	cfg.ProjectConfig.Owners = serviceOwners
+
+	// HTTPS is served by the HTTP server
+	if cfg.ProjectConfig.TLS && !parsed.HTTP {
+		return nil, fmt.Errorf("--tls requires the %s component", ComponentHTTP)
+	}
+
+	// Releases are cut by the CI pipeline from the conventional commits
+	if cfg.ProjectConfig.Release {
+		if !parsed.CICD || provider == CIProviderNone {
+			return nil, fmt.Errorf("--release requires the %s component with a CI provider", ComponentCICD)
+		}
+		if cfg.Provided["conventional-commits"] && !cfg.ProjectConfig.ConventionalCommits {
+			return nil, fmt.Errorf("--release requires conventional commits, which --conventional-commits=false turns off")
+		}
+		cfg.ProjectConfig.ConventionalCommits = true
+	}
+
+	// Validate and set build targets
+	targets, err := parseBuildTargets(buildTargets)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ProjectConfig.BuildTargets = targets
+
+	// Validate the names provided so far; the wizard validates its own answers
+	if err := ValidateBranchName(cfg.ProjectConfig.DefaultBranch); err != nil {
+		return nil, err
+	}
+	if err := ValidateGoVersion(cfg.ProjectConfig.GoVersion); err != nil {
+		return nil, err
+	}
+	if cfg.ProjectConfig.Username != "" {
+		if err := ValidateUsername(cfg.ProjectConfig.Username); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.ProjectConfig.ProjectName != "" {
+		if err := ValidateProjectName(cfg.ProjectConfig.ProjectName); err != nil {
+			return nil, err
+		}
+	}
+
+	// Skip the wizard when all required answers are provided
+	if cfg.ProjectConfig.Username != "" && cfg.ProjectConfig.ProjectName != "" {
+		cfg.IsInteractive = false
+		if cfg.ProjectConfig.ModuleName == "" {
+			cfg.ProjectConfig.ModuleName = DefaultModulePath(cfg.ProjectConfig.Username, cfg.ProjectConfig.ProjectName)
+		}
+	}
+	if cfg.ProjectConfig.ModuleName != "" {
+		if err := ValidateModulePath(cfg.ProjectConfig.ModuleName); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate and set the container registry; the wizard asks for a missing host
+	parsedRegistry, err := ParseRegistry(registry, registryHost)
+	switch {
+	case err == nil:
+		cfg.ProjectConfig.Registry = parsedRegistry
+	case cfg.IsInteractive && strings.TrimSpace(registryHost) == "" && RegistryNeedsHost(strings.ToLower(strings.TrimSpace(registry))):
+		cfg.ProjectConfig.Registry = Registry{Kind: strings.ToLower(strings.TrimSpace(registry))}
+	default:
+		return nil, err
+	}
+
+	// Switch to monorepo mode when the config file lists services
+	if file != nil && len(file.Services) > 0 {
+		// Services resolve the shared pkg module through go.work, which go mod vendor ignores
+		if cfg.ProjectConfig.Vendor {
+			return nil, fmt.Errorf("--vendor is not supported for workspaces: services depend on the shared pkg module through go.work")
+		}
+		cfg.Workspace = file.WorkspaceConfig(cfg.ProjectConfig)
+	}
 
 	return cfg, nil
 }
+
+// OfflineEnvironment returns the environment setting keeping the go command off the
+// network, GOPROXY=off or -mod=vendor in GOFLAGS, or "" when there is none
+func OfflineEnvironment() string {
+	if os.Getenv("GOPROXY") == "off" {
+		return "GOPROXY=off"
+	}
+	for _, flag := range strings.Fields(os.Getenv("GOFLAGS")) {
+		if strings.TrimLeft(flag, "-") == "mod=vendor" {
+			return "GOFLAGS=-mod=vendor"
+		}
+	}
+	return ""
+}
+
+// ResolveOutputDir expands a leading ~ and makes the output directory absolute.
+// It reports whether the directory already exists and fails when the path is a file.
+func ResolveOutputDir(path string) (string, bool, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false, fmt.Errorf("failed to expand ~ in output path: %w", err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid output path %s: %w", path, err)
+	}
+
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return dir, false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to check output path %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return "", false, fmt.Errorf("output path %s exists but is not a directory", dir)
+	}
+
+	return dir, true, nil
+}
+
+// parseBuildTargets parses and validates a comma-separated list of GOOS/GOARCH targets
+func parseBuildTargets(list string) ([]string, error) {
+	var targets []string
+	for _, target := range strings.Split(list, ",") {
+		target = strings.TrimSpace(target)
+		if target == "" {
+			continue
+		}
+		if !contains(SupportedBuildTargets, target) {
+			return nil, fmt.Errorf("unsupported build target %q (available: %s)", target, strings.Join(SupportedBuildTargets, ", "))
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// contains checks if a string is in a slice
+func contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
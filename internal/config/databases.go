@@ -0,0 +1,85 @@
+// internal/config/databases.go - Named database connections of a project
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// databaseNamePattern matches connection names that are usable both as Go
+// constants and as environment variable prefixes (DB_<NAME>_...)
+var databaseNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// reservedDatabaseConstants are identifiers of the generated db package that
+// the constants of the connection names must not shadow
+var reservedDatabaseConstants = []string{
+	"Database",
+	"Databases",
+	"Names",
+	"NewDatabase",
+	"NewDatabases",
+	"Settings",
+}
+
+// parseDatabaseNames parses a comma-separated list of database connection names
+func parseDatabaseNames(list string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return ParseDatabaseNames(names)
+}
+
+// ParseDatabaseNames validates the names of the database connections. Named
+// connections take two or more names; the first one is the main connection,
+// which receives the migrations and stores the users.
+func ParseDatabaseNames(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if len(names) == 1 {
+		return nil, fmt.Errorf("named database connections need at least two names, got %q; a single database needs no name", names[0])
+	}
+
+	constants := map[string]string{}
+	for _, name := range names {
+		if !databaseNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid database name %q: use lowercase letters, digits and underscores, starting with a letter", name)
+		}
+		constant := DatabaseConstant(name)
+		if other, ok := constants[constant]; ok {
+			if other == name {
+				return nil, fmt.Errorf("duplicate database name %q", name)
+			}
+			return nil, fmt.Errorf("database names %q and %q both map to the Go constant %s", other, name, constant)
+		}
+		if contains(reservedDatabaseConstants, constant) {
+			return nil, fmt.Errorf("database name %q clashes with db.%s in the generated code", name, constant)
+		}
+		constants[constant] = name
+	}
+	return names, nil
+}
+
+// DatabaseConstant returns the Go constant of a connection name in the generated
+// db package, e.g. read_replica -> ReadReplica
+func DatabaseConstant(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if word == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// HasNamedDatabases reports whether the project declares named database connections
+func (p ProjectConfig) HasNamedDatabases() bool {
+	return p.Components.HasDatabase() && len(p.Databases) > 1
+}
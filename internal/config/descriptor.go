@@ -0,0 +1,68 @@
+// internal/config/descriptor.go - Metadata of the generated service descriptor
+package config
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// maxDescriptionLength bounds the description of the service, a one-line summary
+const maxDescriptionLength = 200
+
+// ParseOwnerList parses and validates a comma-separated list of service owners
+func ParseOwnerList(list string) ([]string, error) {
+	var owners []string
+	for _, owner := range strings.Split(list, ",") {
+		owner = strings.TrimSpace(owner)
+		if owner == "" {
+			continue
+		}
+		owners = append(owners, owner)
+	}
+	return ParseOwners(owners)
+}
+
+// ParseOwners validates the owners of the service, such as team names or email
+// addresses, which the service descriptor reports to the service catalog
+func ParseOwners(owners []string) ([]string, error) {
+	seen := map[string]bool{}
+	for _, owner := range owners {
+		if strings.TrimSpace(owner) == "" {
+			return nil, fmt.Errorf("owners must not be empty")
+		}
+		if err := checkText(owner); err != nil {
+			return nil, fmt.Errorf("invalid owner %q: %w", owner, err)
+		}
+		if seen[owner] {
+			return nil, fmt.Errorf("duplicate owner %q", owner)
+		}
+		seen[owner] = true
+	}
+	return owners, nil
+}
+
+// ValidateDescription checks the description of the service
+func ValidateDescription(description string) error {
+	if err := checkText(description); err != nil {
+		return fmt.Errorf("invalid description %q: %w", description, err)
+	}
+	if len(description) > maxDescriptionLength {
+		return fmt.Errorf("description is %d characters long, the limit is %d", len(description), maxDescriptionLength)
+	}
+	return nil
+}
+
+// checkText checks that s fits on one line of a Go string, a YAML document and
+// the README; the generated files are templates, so it must not hold their delimiters
+func checkText(s string) error {
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return fmt.Errorf("use printable characters on one line")
+		}
+	}
+	if strings.Contains(s, "{{") || strings.Contains(s, "}}") {
+		return fmt.Errorf("must not contain {{ or }}")
+	}
+	return nil
+}
@@ -0,0 +1,560 @@
+// internal/config/file.go - Loading and saving project configuration files
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFile is the on-disk representation of a project configuration (YAML or JSON)
+type ProjectFile struct {
+	Username    string `yaml:"username"`
+	ProjectName string `yaml:"projectName"`
+	ModuleName  string `yaml:"moduleName,omitempty"`
+	// Preset is a named component set replacing Components
+	Preset        string   `yaml:"preset,omitempty"`
+	Components    []string `yaml:"components"`
+	HTTPFramework string   `yaml:"httpFramework,omitempty"`
+	CIProvider    string   `yaml:"ciProvider,omitempty"`
+	// TokenFormat is the format of the tokens issued by the auth component (defaults to jwt)
+	TokenFormat string `yaml:"tokenFormat,omitempty"`
+	// JSONEngine is the JSON engine of the Gin framework (defaults to stdlib)
+	JSONEngine string `yaml:"jsonEngine,omitempty"`
+	// PostgresDriver is the driver of the postgres component (defaults to pq)
+	PostgresDriver string `yaml:"postgresDriver,omitempty"`
+	// DataLayer is the data layer of the postgres component (defaults to sqlx)
+	DataLayer    string   `yaml:"dataLayer,omitempty"`
+	BuildTargets []string `yaml:"buildTargets,omitempty"`
+	// Databases names the database connections when there are several, main one first
+	Databases []string `yaml:"databases,omitempty"`
+	// Registry is the container registry the Docker image is pushed to
+	Registry     string `yaml:"registry,omitempty"`
+	RegistryHost string `yaml:"registryHost,omitempty"`
+	// Companions are modules developed alongside the project
+	Companions        []Companion `yaml:"companions,omitempty"`
+	CompanionReplaces bool        `yaml:"companionReplaces,omitempty"`
+	// PkgModules are the pkg/ subdirectories published as their own modules
+	PkgModules []string `yaml:"pkgModules,omitempty"`
+	// Vendor commits the dependencies and builds the Docker image from vendor/
+	Vendor bool `yaml:"vendor,omitempty"`
+	// NoTests omits the generated unit tests
+	NoTests bool `yaml:"noTests,omitempty"`
+	// CoalescingExample generates the request coalescing example endpoint
+	CoalescingExample bool `yaml:"coalescingExample,omitempty"`
+	// ResponseCache caches the responses of selected GET routes
+	ResponseCache bool `yaml:"responseCache,omitempty"`
+	// Description and Owners are reported by the service descriptor of the HTTP server
+	Description string   `yaml:"description,omitempty"`
+	Owners      []string `yaml:"owners,omitempty"`
+	// DefaultBranch is the branch the CI pipeline builds and deploys (defaults to main)
+	DefaultBranch string `yaml:"defaultBranch,omitempty"`
+	// ConventionalCommits enforces conventional commit messages
+	ConventionalCommits bool `yaml:"conventionalCommits,omitempty"`
+	// Release automates releases and CHANGELOG.md from the conventional commits; implies conventionalCommits
+	Release bool `yaml:"release,omitempty"`
+	// TLS serves HTTPS with a configured certificate and generates local development certificates
+	TLS bool `yaml:"tls,omitempty"`
+	// GoVersion is the Go version of go.mod, the Docker build image and the CI pipeline (defaults to 1.23)
+	GoVersion string `yaml:"goVersion,omitempty"`
+	// Hooks are commands run in order in the generated project after go mod tidy
+	Hooks []string `yaml:"hooks,omitempty"`
+	// Services switches to monorepo mode; each entry is generated into services/<projectName>
+	Services []ProjectFile `yaml:"services,omitempty"`
+}
+
+// FileError describes an invalid value in a project configuration file
+type FileError struct {
+	Path  string
+	Line  int
+	Field string
+	Msg   string
+}
+
+// Error implements the error interface
+func (e *FileError) Error() string {
+	return fmt.Sprintf("%s:%d: %s: %s", e.Path, e.Line, e.Field, e.Msg)
+}
+
+// LoadProjectFile loads and validates a project configuration file.
+// JSON files are accepted as well since JSON is a subset of YAML.
+func LoadProjectFile(path string) (*ProjectFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// Parse into a node tree first to keep line information for validation errors
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("%s: malformed config file: %w", path, err)
+	}
+
+	// Check the structure and values against the schema, which reports the path
+	// of the offending field
+	schema, err := ProjectFileSchema()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSchema(path, &root, schema); err != nil {
+		return nil, err
+	}
+
+	// Decode strictly so misspelled keys are reported instead of ignored
+	var file ProjectFile
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: invalid config file: %w", path, err)
+	}
+
+	if err := file.validate(path, &root, ""); err != nil {
+		return nil, err
+	}
+
+	// Services resolve the shared pkg module through go.work, which go mod vendor ignores
+	if len(file.Services) > 0 && file.Vendor {
+		return nil, &FileError{Path: path, Line: fieldLine(&root, "vendor"), Field: "vendor", Msg: "is not supported for workspaces"}
+	}
+
+	if len(file.Services) > 0 && len(file.PkgModules) > 0 {
+		return nil, &FileError{Path: path, Line: fieldLine(&root, "pkgModules"), Field: "pkgModules", Msg: "is not supported for workspaces; the services share the pkg module"}
+	}
+
+	// Validate services; they inherit the username from the workspace
+	seen := map[string]bool{}
+	for i := range file.Services {
+		service := &file.Services[i]
+		node := itemNode(&root, "services", i)
+		if node == nil {
+			node = &root
+		}
+		prefix := fmt.Sprintf("services[%d].", i)
+
+		if service.Username == "" {
+			service.Username = file.Username
+		}
+		if err := service.validate(path, node, prefix); err != nil {
+			return nil, err
+		}
+		if service.Vendor {
+			return nil, &FileError{Path: path, Line: fieldLine(node, "vendor"), Field: prefix + "vendor", Msg: "is not supported for workspace services"}
+		}
+		if len(service.PkgModules) > 0 {
+			return nil, &FileError{Path: path, Line: fieldLine(node, "pkgModules"), Field: prefix + "pkgModules", Msg: "is not supported for workspace services; they share the pkg module of the workspace"}
+		}
+		if len(service.Hooks) > 0 {
+			return nil, &FileError{Path: path, Line: fieldLine(node, "hooks"), Field: prefix + "hooks", Msg: "is not supported for workspace services; the hooks of the workspace run in its directory"}
+		}
+		if seen[service.ProjectName] {
+			return nil, &FileError{Path: path, Line: fieldLine(node, "projectName"), Field: prefix + "projectName", Msg: fmt.Sprintf("duplicate service %q", service.ProjectName)}
+		}
+		seen[service.ProjectName] = true
+	}
+
+	return &file, nil
+}
+
+// validate checks required fields and known values; prefix qualifies field names in errors
+func (f *ProjectFile) validate(path string, node *yaml.Node, prefix string) error {
+	if strings.TrimSpace(f.Username) == "" {
+		return &FileError{Path: path, Line: fieldLine(node, "username"), Field: prefix + "username", Msg: "is required"}
+	}
+	if strings.TrimSpace(f.ProjectName) == "" {
+		return &FileError{Path: path, Line: fieldLine(node, "projectName"), Field: prefix + "projectName", Msg: "is required"}
+	}
+	if err := ValidateUsername(f.Username); err != nil {
+		return &FileError{Path: path, Line: fieldLine(node, "username"), Field: prefix + "username", Msg: err.Error()}
+	}
+	if err := ValidateProjectName(f.ProjectName); err != nil {
+		return &FileError{Path: path, Line: fieldLine(node, "projectName"), Field: prefix + "projectName", Msg: err.Error()}
+	}
+
+	if f.ModuleName != "" {
+		if err := ValidateModulePath(f.ModuleName); err != nil {
+			return &FileError{Path: path, Line: fieldLine(node, "moduleName"), Field: prefix + "moduleName", Msg: err.Error()}
+		}
+	}
+
+	for i, name := range f.Components {
+		if _, err := ParseComponents([]string{name}); err != nil {
+			return &FileError{Path: path, Line: itemLine(node, "components", i), Field: fmt.Sprintf("%scomponents[%d]", prefix, i), Msg: err.Error()}
+		}
+	}
+
+	if f.Preset != "" {
+		if _, err := ParsePreset(f.Preset); err != nil {
+			return &FileError{Path: path, Line: fieldLine(node, "preset"), Field: prefix + "preset", Msg: err.Error()}
+		}
+		if len(f.Components) > 0 {
+			return &FileError{Path: path, Line: fieldLine(node, "preset"), Field: prefix + "preset", Msg: "cannot be combined with components"}
+		}
+	}
+
+	if _, err := ParseHTTPFramework(f.HTTPFramework); err != nil {
+		return &FileError{Path: path, Line: fieldLine(node, "httpFramework"), Field: prefix + "httpFramework", Msg: err.Error()}
+	}
+
+	if _, err := ParseCIProvider(f.CIProvider); err != nil {
+		return &FileError{Path: path, Line: fieldLine(node, "ciProvider"), Field: prefix + "ciProvider", Msg: err.Error()}
+	}
+
+	if _, err := ParseTokenFormat(f.TokenFormat); err != nil {
+		return &FileError{Path: path, Line: fieldLine(node, "tokenFormat"), Field: prefix + "tokenFormat", Msg: err.Error()}
+	}
+
+	engine, err := ParseJSONEngine(f.JSONEngine)
+	if err != nil {
+		return &FileError{Path: path, Line: fieldLine(node, "jsonEngine"), Field: prefix + "jsonEngine", Msg: err.Error()}
+	}
+	if engine != JSONEngineStdlib {
+		components, _ := ParseComponents(f.componentNames())
+		if framework, _ := ParseHTTPFramework(f.HTTPFramework); !components.HTTP || framework != HTTPFrameworkGin {
+			return &FileError{Path: path, Line: fieldLine(node, "jsonEngine"), Field: prefix + "jsonEngine", Msg: "requires the http component with the gin framework"}
+		}
+	}
+
+	driver, err := ParsePostgresDriver(f.PostgresDriver)
+	if err != nil {
+		return &FileError{Path: path, Line: fieldLine(node, "postgresDriver"), Field: prefix + "postgresDriver", Msg: err.Error()}
+	}
+	if driver != DefaultPostgresDriver {
+		if components, _ := ParseComponents(f.componentNames()); components.Database != ComponentPostgres {
+			return &FileError{Path: path, Line: fieldLine(node, "postgresDriver"), Field: prefix + "postgresDriver", Msg: "requires the postgres component"}
+		}
+	}
+
+	layer, err := ParseDataLayer(f.DataLayer)
+	if err != nil {
+		return &FileError{Path: path, Line: fieldLine(node, "dataLayer"), Field: prefix + "dataLayer", Msg: err.Error()}
+	}
+	if layer != DefaultDataLayer {
+		if components, _ := ParseComponents(f.componentNames()); components.Database != ComponentPostgres {
+			return &FileError{Path: path, Line: fieldLine(node, "dataLayer"), Field: prefix + "dataLayer", Msg: "requires the postgres component"}
+		}
+	}
+
+	for i, target := range f.BuildTargets {
+		if _, err := parseBuildTargets(target); err != nil {
+			return &FileError{Path: path, Line: itemLine(node, "buildTargets", i), Field: fmt.Sprintf("%sbuildTargets[%d]", prefix, i), Msg: err.Error()}
+		}
+	}
+
+	if _, err := ParseDatabaseNames(f.Databases); err != nil {
+		return &FileError{Path: path, Line: fieldLine(node, "databases"), Field: prefix + "databases", Msg: err.Error()}
+	}
+	if len(f.Databases) > 0 {
+		components, _ := ParseComponents(f.componentNames())
+		if !components.HasDatabase() {
+			return &FileError{Path: path, Line: fieldLine(node, "databases"), Field: prefix + "databases", Msg: "requires a database component"}
+		}
+	}
+
+	if _, err := ParsePkgModules(f.PkgModules); err != nil {
+		return &FileError{Path: path, Line: fieldLine(node, "pkgModules"), Field: prefix + "pkgModules", Msg: err.Error()}
+	}
+
+	if f.CoalescingExample {
+		components, _ := ParseComponents(f.componentNames())
+		if !components.HTTP || !components.HasDatabase() {
+			return &FileError{Path: path, Line: fieldLine(node, "coalescingExample"), Field: prefix + "coalescingExample", Msg: "requires the http component and a database component"}
+		}
+	}
+
+	if f.ResponseCache {
+		components, _ := ParseComponents(f.componentNames())
+		if !components.HTTP {
+			return &FileError{Path: path, Line: fieldLine(node, "responseCache"), Field: prefix + "responseCache", Msg: "requires the http component"}
+		}
+	}
+
+	if f.Description != "" {
+		if err := ValidateDescription(f.Description); err != nil {
+			return &FileError{Path: path, Line: fieldLine(node, "description"), Field: prefix + "description", Msg: err.Error()}
+		}
+		components, _ := ParseComponents(f.componentNames())
+		if !components.HTTP {
+			return &FileError{Path: path, Line: fieldLine(node, "description"), Field: prefix + "description", Msg: "requires the http component"}
+		}
+	}
+
+	if _, err := ParseOwners(f.Owners); err != nil {
+		return &FileError{Path: path, Line: fieldLine(node, "owners"), Field: prefix + "owners", Msg: err.Error()}
+	}
+	if len(f.Owners) > 0 {
+		components, _ := ParseComponents(f.componentNames())
+		if !components.HTTP {
+			return &FileError{Path: path, Line: fieldLine(node, "owners"), Field: prefix + "owners", Msg: "requires the http component"}
+		}
+	}
+
+	if f.TLS {
+		components, _ := ParseComponents(f.componentNames())
+		if !components.HTTP {
+			return &FileError{Path: path, Line: fieldLine(node, "tls"), Field: prefix + "tls", Msg: "requires the http component"}
+		}
+	}
+
+	if f.Release {
+		components, _ := ParseComponents(f.componentNames())
+		if provider, _ := ParseCIProvider(f.CIProvider); !components.CICD || provider == CIProviderNone {
+			return &FileError{Path: path, Line: fieldLine(node, "release"), Field: prefix + "release", Msg: "requires the cicd component with a CI provider"}
+		}
+	}
+
+	if f.DefaultBranch != "" {
+		if err := ValidateBranchName(f.DefaultBranch); err != nil {
+			return &FileError{Path: path, Line: fieldLine(node, "defaultBranch"), Field: prefix + "defaultBranch", Msg: err.Error()}
+		}
+	}
+
+	if f.GoVersion != "" {
+		if err := ValidateGoVersion(f.GoVersion); err != nil {
+			return &FileError{Path: path, Line: fieldLine(node, "goVersion"), Field: prefix + "goVersion", Msg: err.Error()}
+		}
+	}
+
+	if f.Registry != "" || f.RegistryHost != "" {
+		if _, err := ParseRegistry(f.Registry, f.RegistryHost); err != nil {
+			field := "registry"
+			if f.RegistryHost != "" {
+				field = "registryHost"
+			}
+			return &FileError{Path: path, Line: fieldLine(node, field), Field: prefix + field, Msg: err.Error()}
+		}
+	}
+
+	for i, companion := range f.Companions {
+		if strings.TrimSpace(companion.Path) == "" {
+			return &FileError{Path: path, Line: itemLine(node, "companions", i), Field: fmt.Sprintf("%scompanions[%d].path", prefix, i), Msg: "is required"}
+		}
+	}
+
+	for i, hook := range f.Hooks {
+		if _, err := ParseHook(hook); err != nil {
+			return &FileError{Path: path, Line: itemLine(node, "hooks", i), Field: fmt.Sprintf("%shooks[%d]", prefix, i), Msg: err.Error()}
+		}
+	}
+
+	return nil
+}
+
+// componentNames returns the components of the file, those of its preset when it has one
+func (f *ProjectFile) componentNames() []string {
+	if preset, err := ParsePreset(f.Preset); err == nil && f.Preset != "" {
+		return preset.Components
+	}
+	return f.Components
+}
+
+// ProjectConfig converts the file into a ProjectConfig
+func (f *ProjectFile) ProjectConfig() ProjectConfig {
+	components, _ := ParseComponents(f.componentNames())
+	components.HTTPFramework, _ = ParseHTTPFramework(f.HTTPFramework)
+	components.CIProvider, _ = ParseCIProvider(f.CIProvider)
+	components.TokenFormat, _ = ParseTokenFormat(f.TokenFormat)
+	components.JSONEngine, _ = ParseJSONEngine(f.JSONEngine)
+	components.PostgresDriver, _ = ParsePostgresDriver(f.PostgresDriver)
+	components.DataLayer, _ = ParseDataLayer(f.DataLayer)
+
+	projectCfg := ProjectConfig{
+		Username:            f.Username,
+		ProjectName:         f.ProjectName,
+		ModuleName:          f.ModuleName,
+		Components:          components,
+		BuildTargets:        f.BuildTargets,
+		Databases:           f.Databases,
+		Companions:          f.Companions,
+		CompanionReplaces:   f.CompanionReplaces,
+		PkgModules:          f.PkgModules,
+		Vendor:              f.Vendor,
+		NoTests:             f.NoTests,
+		CoalescingExample:   f.CoalescingExample,
+		ResponseCache:       f.ResponseCache,
+		Description:         f.Description,
+		Owners:              f.Owners,
+		DefaultBranch:       f.DefaultBranch,
+		ConventionalCommits: f.ConventionalCommits || f.Release,
+		Release:             f.Release,
+		GoVersion:           f.GoVersion,
+		TLS:                 f.TLS,
+	}
+	projectCfg.Registry, _ = ParseRegistry(f.Registry, f.RegistryHost)
+	if projectCfg.ModuleName == "" {
+		projectCfg.ModuleName = DefaultModulePath(f.Username, f.ProjectName)
+	}
+	if projectCfg.BuildTargets == nil {
+		projectCfg.BuildTargets = DefaultBuildTargets
+	}
+	if projectCfg.DefaultBranch == "" {
+		projectCfg.DefaultBranch = DefaultBranch
+	}
+	if projectCfg.GoVersion == "" {
+		projectCfg.GoVersion = DefaultGoVersion
+	}
+
+	return projectCfg
+}
+
+// WorkspaceConfig converts the services of the file into a WorkspaceConfig.
+// root is the resolved workspace configuration; services default their module
+// path to <root module>/services/<name> and inherit the root build targets, CI provider, registry, default branch and Go version.
+func (f *ProjectFile) WorkspaceConfig(root ProjectConfig) *WorkspaceConfig {
+	workspace := &WorkspaceConfig{
+		Name:       root.ProjectName,
+		ModuleName: root.ModuleName,
+	}
+
+	for _, service := range f.Services {
+		serviceCfg := service.ProjectConfig()
+		if service.ModuleName == "" {
+			serviceCfg.ModuleName = fmt.Sprintf("%s/services/%s", root.ModuleName, service.ProjectName)
+		}
+		if service.BuildTargets == nil {
+			serviceCfg.BuildTargets = root.BuildTargets
+		}
+		if service.CIProvider == "" {
+			serviceCfg.Components.CIProvider = root.Components.CIProvider
+		}
+		if service.Registry == "" && service.RegistryHost == "" {
+			serviceCfg.Registry = root.Registry
+		}
+		if service.DefaultBranch == "" {
+			serviceCfg.DefaultBranch = root.DefaultBranch
+		}
+		if service.GoVersion == "" {
+			serviceCfg.GoVersion = root.GoVersion
+		}
+		workspace.Services = append(workspace.Services, serviceCfg)
+	}
+
+	return workspace
+}
+
+// SaveProjectFile writes the project configuration to a YAML file
+func SaveProjectFile(path string, projectCfg ProjectConfig) error {
+	file := NewProjectFile(projectCfg)
+	content, err := yaml.Marshal(&file)
+	if err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// NewProjectFile converts a ProjectConfig into its on-disk representation;
+// settings of components that are not selected are left out
+func NewProjectFile(projectCfg ProjectConfig) ProjectFile {
+	file := ProjectFile{
+		Username:            projectCfg.Username,
+		ProjectName:         projectCfg.ProjectName,
+		ModuleName:          projectCfg.ModuleName,
+		Components:          projectCfg.Components.Names(),
+		HTTPFramework:       projectCfg.Components.HTTPFramework,
+		CIProvider:          projectCfg.Components.CIProvider,
+		TokenFormat:         projectCfg.Components.TokenFormat,
+		BuildTargets:        projectCfg.BuildTargets,
+		Databases:           projectCfg.Databases,
+		Companions:          projectCfg.Companions,
+		CompanionReplaces:   projectCfg.CompanionReplaces,
+		PkgModules:          projectCfg.PkgModules,
+		Vendor:              projectCfg.Vendor,
+		NoTests:             projectCfg.NoTests,
+		CoalescingExample:   projectCfg.CoalescingExample,
+		ResponseCache:       projectCfg.HasResponseCache(),
+		Description:         projectCfg.Description,
+		Owners:              projectCfg.Owners,
+		DefaultBranch:       projectCfg.DefaultBranch,
+		ConventionalCommits: projectCfg.ConventionalCommits,
+		Release:             projectCfg.HasRelease(),
+		GoVersion:           projectCfg.GoVersion,
+		TLS:                 projectCfg.HasTLS(),
+	}
+	if file.Components == nil {
+		file.Components = []string{}
+	}
+	if !projectCfg.Components.HTTP {
+		file.HTTPFramework = ""
+		file.Description = ""
+		file.Owners = nil
+	}
+	if !projectCfg.Components.CICD {
+		file.CIProvider = ""
+	}
+	if !projectCfg.Components.Auth {
+		file.TokenFormat = ""
+	}
+	if projectCfg.Components.HasJSONEngine() {
+		file.JSONEngine = projectCfg.Components.JSONEngine
+	}
+	if projectCfg.Components.HasPgx() {
+		file.PostgresDriver = projectCfg.Components.PostgresDriver
+	}
+	if projectCfg.Components.HasSQLC() {
+		file.DataLayer = projectCfg.Components.DataLayer
+	}
+	if projectCfg.Components.Docker {
+		file.Registry = projectCfg.Registry.Kind
+		file.RegistryHost = projectCfg.Registry.Host
+	}
+	return file
+}
+
+// mappingNode returns the top-level mapping of a document node
+func mappingNode(root *yaml.Node) *yaml.Node {
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		return root.Content[0]
+	}
+	return root
+}
+
+// fieldNode returns the value node of a top-level key, or nil if it's missing
+func fieldNode(root *yaml.Node, key string) *yaml.Node {
+	mapping := mappingNode(root)
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// itemNode returns the i-th item of a top-level sequence, or nil if it's missing
+func itemNode(root *yaml.Node, key string, i int) *yaml.Node {
+	node := fieldNode(root, key)
+	if node != nil && node.Kind == yaml.SequenceNode && i < len(node.Content) {
+		return node.Content[i]
+	}
+	return nil
+}
+
+// fieldLine returns the line of a top-level key, falling back to the start of the document
+func fieldLine(root *yaml.Node, key string) int {
+	if node := fieldNode(root, key); node != nil {
+		return node.Line
+	}
+	if mapping := mappingNode(root); mapping.Line > 0 {
+		return mapping.Line
+	}
+	return 1
+}
+
+// itemLine returns the line of the i-th item of a top-level sequence
+func itemLine(root *yaml.Node, key string, i int) int {
+	if node := itemNode(root, key, i); node != nil {
+		return node.Line
+	}
+	return fieldLine(root, key)
+}
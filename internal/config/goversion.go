@@ -0,0 +1,77 @@
+// internal/config/goversion.go - Go version of the generated project
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+const (
+	// DefaultGoVersion is the Go version used when none is given
+	DefaultGoVersion = "1.23"
+	// MinGoVersion is the oldest Go version the generated code and its dependencies build with
+	MinGoVersion = "1.23"
+	// MaxGoVersion is the newest Go release the templates were checked against
+	MaxGoVersion = "1.27"
+)
+
+// goVersionPattern accepts a language version or a release, e.g. 1.23 or 1.23.4
+var goVersionPattern = regexp.MustCompile(`^1\.(0|[1-9][0-9]*)(\.(0|[1-9][0-9]*))?$`)
+
+// ValidateGoVersion checks that a Go version is a release such as 1.23 or
+// 1.23.4 between MinGoVersion and MaxGoVersion
+func ValidateGoVersion(version string) error {
+	m := goVersionPattern.FindStringSubmatch(version)
+	if m == nil {
+		return fmt.Errorf("invalid Go version %q: must be a release such as %s or %s.1", version, DefaultGoVersion, DefaultGoVersion)
+	}
+	minor, _ := strconv.Atoi(m[1])
+	if minor < goMinor(MinGoVersion) || minor > goMinor(MaxGoVersion) {
+		return fmt.Errorf("unsupported Go version %q: must be between %s and %s", version, MinGoVersion, MaxGoVersion)
+	}
+	return nil
+}
+
+// goMinor returns the minor version of a valid 1.N version
+func goMinor(version string) int {
+	minor, _ := strconv.Atoi(goVersionPattern.FindStringSubmatch(version)[1])
+	return minor
+}
+
+// Go returns the Go version of go.mod, the Docker build image and the CI
+// pipeline, DefaultGoVersion unless another was configured
+func (p ProjectConfig) Go() string {
+	if p.GoVersion == "" {
+		return DefaultGoVersion
+	}
+	return p.GoVersion
+}
+
+// Go returns the newest Go version of the services, which go.work and the
+// shared pkg module need to build all of them
+func (w WorkspaceConfig) Go() string {
+	version := DefaultGoVersion
+	for _, service := range w.Services {
+		if compareGoVersions(service.Go(), version) > 0 {
+			version = service.Go()
+		}
+	}
+	return version
+}
+
+// compareGoVersions compares two valid Go versions; a missing patch counts as zero
+func compareGoVersions(a, b string) int {
+	am, bm := goVersionPattern.FindStringSubmatch(a), goVersionPattern.FindStringSubmatch(b)
+	for i := 1; i <= 3; i += 2 {
+		x, _ := strconv.Atoi(am[i])
+		y, _ := strconv.Atoi(bm[i])
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,128 @@
+// internal/config/hooks.go - Post-generation hook commands
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HookEnv lists the variables set for the post-generation hooks, in addition to the
+// environment of the generator; only these are expanded in the hook commands
+var HookEnv = []string{"PROJECT_NAME", "MODULE_NAME", "OUTPUT_DIR"}
+
+// hookList collects the repeated --post-hook flags
+type hookList []string
+
+// String implements flag.Value
+func (h *hookList) String() string {
+	return strings.Join(*h, "; ")
+}
+
+// Set implements flag.Value, appending a hook
+func (h *hookList) Set(command string) error {
+	if _, err := ParseHook(command); err != nil {
+		return err
+	}
+	*h = append(*h, command)
+	return nil
+}
+
+// ParseHook splits a hook command into its program and arguments. Words are separated
+// by spaces; single quotes keep their content as-is, and double quotes and backslashes
+// work as in a POSIX shell. Hooks run without a shell, so pipes and && need sh -c.
+func ParseHook(command string) ([]string, error) {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\' && quote == '"':
+			escaped = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inWord = true, true
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("invalid hook %q: unterminated %c quote", command, quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("invalid hook %q: trailing backslash", command)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("invalid hook %q: empty command", command)
+	}
+	return words, nil
+}
+
+// ExpandHookEnv replaces $NAME and ${NAME} in a hook argument with the value in env of
+// one of the HookEnv variables; any other variable is kept as written
+func ExpandHookEnv(arg string, env map[string]string) string {
+	var b strings.Builder
+	for i := 0; i < len(arg); i++ {
+		if arg[i] == '$' {
+			if name, n := hookVariable(arg[i+1:]); name != "" {
+				if value, ok := env[name]; ok {
+					b.WriteString(value)
+					i += n
+					continue
+				}
+			}
+		}
+		b.WriteByte(arg[i])
+	}
+	return b.String()
+}
+
+// hookVariable returns the HookEnv variable s starts with, as NAME or {NAME}, and
+// the length of the reference
+func hookVariable(s string) (string, int) {
+	for _, name := range HookEnv {
+		if strings.HasPrefix(s, "{"+name+"}") {
+			return name, len(name) + 2
+		}
+		if strings.HasPrefix(s, name) && (len(s) == len(name) || !isNameByte(s[len(name)])) {
+			return name, len(name)
+		}
+	}
+	return "", 0
+}
+
+// isNameByte reports whether c may continue a variable name
+func isNameByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
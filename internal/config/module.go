@@ -0,0 +1,118 @@
+// internal/config/module.go - Module path of the generated project
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// DefaultModulePath returns the GitHub-style module path used when none is given
+func DefaultModulePath(username, projectName string) string {
+	return fmt.Sprintf("github.com/%s/%s", username, projectName)
+}
+
+// ValidateModulePath checks a module path with the rules of go mod init: it must
+// be a valid import path, and a major version suffix must be /vN with N >= 2
+// (or .vN for gopkg.in). Paths without a dot, e.g. "myservice", are accepted as
+// go mod init accepts them, though they cannot be fetched by other modules.
+func ValidateModulePath(path string) error {
+	if err := module.CheckImportPath(path); err != nil {
+		return fmt.Errorf("invalid module path %q: %w", path, err)
+	}
+	if _, _, ok := module.SplitPathVersion(path); !ok {
+		if strings.HasPrefix(path, "gopkg.in/") {
+			return fmt.Errorf("invalid module path %q: module paths beginning with gopkg.in/ must end with .vN", path)
+		}
+		return fmt.Errorf("invalid module path %q: major version suffixes must be in the form of /vN and are only allowed for v2 or later", path)
+	}
+	return nil
+}
+
+// HostCIProvider returns the CI provider built into the host of a module
+// path: github.com, GitLab, Bitbucket Cloud and Gitea hosts, recognized by
+// their names, e.g. gitlab.example.com or gitea.example.com. It returns ""
+// for other hosts.
+func HostCIProvider(modulePath string) string {
+	host, _, _ := strings.Cut(modulePath, "/")
+	switch {
+	case host == "github.com":
+		return CIProviderGitHub
+	case host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+		return CIProviderGitLab
+	case host == "bitbucket.org":
+		return CIProviderBitbucket
+	case host == "gitea.com" || host == "codeberg.org" || strings.HasPrefix(host, "gitea."):
+		return CIProviderGitea
+	}
+	return ""
+}
+
+// CompatibleCIProviders returns the CI providers offered for a module path:
+// the one built into its host and none, or all of them for other hosts
+func CompatibleCIProviders(modulePath string) []string {
+	if provider := HostCIProvider(modulePath); provider != "" {
+		return []string{provider, CIProviderNone}
+	}
+	return CIProviders
+}
+
+// repositoryOwner returns the owner of a <host>/<owner>/<repo> module path on
+// GitHub, Bitbucket or Gitea, or "" when the path is hosted elsewhere. GitLab
+// paths are left out since their groups nest, so the owner is ambiguous.
+func repositoryOwner(modulePath string) string {
+	parts := strings.Split(modulePath, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	switch HostCIProvider(modulePath) {
+	case CIProviderGitHub, CIProviderBitbucket, CIProviderGitea:
+		return parts[1]
+	}
+	return ""
+}
+
+// ImageOwner returns the namespace of the Docker image: the owner of a
+// GitHub, Bitbucket or Gitea module path, else none so that the image is named
+// after the project. GHCR and GitLab reject images outside a namespace, so
+// they fall back to the username.
+func (p ProjectConfig) ImageOwner() string {
+	if owner := repositoryOwner(p.ModuleName); owner != "" {
+		return owner
+	}
+	if p.Registry.Kind == RegistryGHCR || p.Registry.Kind == RegistryGitLab {
+		return p.Username
+	}
+	return ""
+}
+
+// Image returns the image repository of the project, without a tag
+func (p ProjectConfig) Image() string {
+	return p.Registry.Image(p.ImageOwner(), p.ProjectName)
+}
+
+// RepositoryPath returns the host and path of the repository of the project,
+// e.g. github.com/acme/billing, or "" when the module path names no host (its
+// first element has no dot)
+func (p ProjectConfig) RepositoryPath() string {
+	host, _, _ := strings.Cut(p.ModuleName, "/")
+	if !strings.Contains(host, ".") {
+		return ""
+	}
+	// Workspace services live in a subdirectory of the workspace repository, and
+	// a major version suffix is not part of the repository
+	repo, _, _ := strings.Cut(p.ModuleName, "/services/")
+	repo, _, _ = module.SplitPathVersion(repo)
+	return repo
+}
+
+// RepositoryURL returns the URL the README clones the project from, or "" when
+// the module path names no host (its first element has no dot)
+func (p ProjectConfig) RepositoryURL() string {
+	repo := p.RepositoryPath()
+	if repo == "" {
+		return ""
+	}
+	return "https://" + repo + ".git"
+}
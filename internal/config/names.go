@@ -0,0 +1,91 @@
+// internal/config/names.go - Validation of project names, usernames and branch names
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// maxProjectNameLength keeps the project name a valid Kubernetes resource name (DNS label)
+	maxProjectNameLength = 63
+	// maxUsernameLength is the longest GitHub username or organization
+	maxUsernameLength = 39
+)
+
+var (
+	// projectNamePattern accepts lowercase alphanumeric words joined by single dashes,
+	// valid as a directory, module path element, image name and Kubernetes name
+	projectNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	// usernamePattern follows GitHub: alphanumeric words joined by single dashes
+	usernamePattern = regexp.MustCompile(`^[A-Za-z0-9]+(-[A-Za-z0-9]+)*$`)
+	// branchNamePattern accepts the characters git allows in a branch name that
+	// need no quoting in YAML and shell scripts
+	branchNamePattern = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+	// nameSeparators are the runs of characters a sanitized name replaces with a dash
+	nameSeparators = regexp.MustCompile(`[^A-Za-z0-9]+`)
+)
+
+// ValidateProjectName checks that a project name is lowercase alphanumeric with
+// dashes; the error suggests a sanitized name when there is one
+func ValidateProjectName(name string) error {
+	if len(name) <= maxProjectNameLength && projectNamePattern.MatchString(name) {
+		return nil
+	}
+	return nameError("project name", name, fmt.Sprintf("must be at most %d lowercase letters, digits and dashes, starting and ending with a letter or digit", maxProjectNameLength), SanitizeProjectName(name))
+}
+
+// ValidateUsername checks that a username follows the GitHub username and
+// organization rules; the error suggests a sanitized name when there is one
+func ValidateUsername(name string) error {
+	if len(name) <= maxUsernameLength && usernamePattern.MatchString(name) {
+		return nil
+	}
+	return nameError("username", name, fmt.Sprintf("must be at most %d letters, digits and single dashes, starting and ending with a letter or digit", maxUsernameLength), SanitizeUsername(name))
+}
+
+// ValidateBranchName checks that a branch name is valid for git (see git
+// check-ref-format) and safe to write unquoted into the CI configuration
+func ValidateBranchName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("branch name must not be empty")
+	case !branchNamePattern.MatchString(name):
+		return fmt.Errorf("invalid branch name %q: must only contain letters, digits and . _ / -", name)
+	case strings.HasPrefix(name, "-"), strings.HasPrefix(name, "."), strings.HasPrefix(name, "/"), strings.HasSuffix(name, "/"),
+		strings.HasSuffix(name, "."), strings.HasSuffix(name, ".lock"),
+		strings.Contains(name, ".."), strings.Contains(name, "//"), strings.Contains(name, "/."):
+		return fmt.Errorf("invalid branch name %q: not a valid git branch name", name)
+	}
+	return nil
+}
+
+// SanitizeProjectName turns a name into a valid project name, e.g.
+// "My Cool Service!" -> "my-cool-service"; it returns "" when nothing is left
+func SanitizeProjectName(name string) string {
+	return sanitizeName(strings.ToLower(name), maxProjectNameLength)
+}
+
+// SanitizeUsername turns a name into a valid username, e.g. "acme_corp" ->
+// "acme-corp"; it returns "" when nothing is left
+func SanitizeUsername(name string) string {
+	return sanitizeName(name, maxUsernameLength)
+}
+
+// sanitizeName joins the alphanumeric runs of name with dashes, cut to maxLength
+func sanitizeName(name string, maxLength int) string {
+	name = strings.Trim(nameSeparators.ReplaceAllString(name, "-"), "-")
+	if len(name) > maxLength {
+		name = strings.TrimRight(name[:maxLength], "-")
+	}
+	return name
+}
+
+// nameError describes an invalid name, with a suggestion unless sanitizing left nothing
+func nameError(kind, name, rule, suggestion string) error {
+	if suggestion == "" {
+		return fmt.Errorf("invalid %s %q: %s", kind, name, rule)
+	}
+	return fmt.Errorf("invalid %s %q: %s (e.g. %q)", kind, name, rule, suggestion)
+}
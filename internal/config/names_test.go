@@ -0,0 +1,164 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateProjectName(t *testing.T) {
+	tests := []struct {
+		name string
+		// wantErr is a part of the error, "" when the name is valid
+		wantErr string
+	}{
+		{name: "demo"},
+		{name: "my-cool-service"},
+		{name: "service2"},
+		{name: "2fa"},
+		{name: strings.Repeat("a", maxProjectNameLength)},
+		{name: "", wantErr: `invalid project name ""`},
+		{name: "My Cool Service!", wantErr: `(e.g. "my-cool-service")`},
+		{name: "MyService", wantErr: `(e.g. "myservice")`},
+		{name: "my_service", wantErr: `(e.g. "my-service")`},
+		{name: "my.service", wantErr: `(e.g. "my-service")`},
+		{name: "-demo", wantErr: `(e.g. "demo")`},
+		{name: "demo-", wantErr: `(e.g. "demo")`},
+		{name: "my--service", wantErr: `(e.g. "my-service")`},
+		{name: "sérvice", wantErr: `(e.g. "s-rvice")`},
+		{name: "!!!", wantErr: "starting and ending with a letter or digit"},
+		{name: strings.Repeat("a", maxProjectNameLength+1), wantErr: `(e.g. "` + strings.Repeat("a", maxProjectNameLength) + `")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProjectName(tt.name)
+			checkNameError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+	}{
+		{name: "acme"},
+		{name: "Acme-Corp"},
+		{name: "user42"},
+		{name: strings.Repeat("a", maxUsernameLength)},
+		{name: "", wantErr: `invalid username ""`},
+		{name: "acme_corp", wantErr: `(e.g. "acme-corp")`},
+		{name: "acme--corp", wantErr: `(e.g. "acme-corp")`},
+		{name: "-acme", wantErr: `(e.g. "acme")`},
+		{name: "acme corp", wantErr: `(e.g. "acme-corp")`},
+		// Usernames keep their case, unlike project names
+		{name: "Acme Corp", wantErr: `(e.g. "Acme-Corp")`},
+		{name: strings.Repeat("a", maxUsernameLength+1), wantErr: "must be at most 39 letters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUsername(tt.name)
+			checkNameError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateModulePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr string
+	}{
+		{path: "github.com/acme/demo"},
+		{path: "gitlab.example.com/group/subgroup/demo"},
+		{path: "example.com/demo/v2"},
+		{path: "gopkg.in/demo.v2"},
+		// go mod init accepts paths without a dot
+		{path: "demo"},
+		{path: "", wantErr: "empty string"},
+		{path: "github.com/acme/My Service", wantErr: "invalid char ' '"},
+		{path: "github.com/acme//demo", wantErr: "double slash"},
+		{path: "github.com/acme/demo/", wantErr: "trailing slash"},
+		{path: "/github.com/acme/demo", wantErr: "empty path element"},
+		{path: "github.com/acme/../demo", wantErr: "invalid path element"},
+		// Windows reserves device names as file names
+		{path: "github.com/acme/con", wantErr: `"con" disallowed`},
+		{path: "github.com/acme/NUL.demo", wantErr: `"NUL" disallowed`},
+		{path: "example.com/demo/v1", wantErr: "only allowed for v2 or later"},
+		{path: "example.com/demo/v0", wantErr: "only allowed for v2 or later"},
+		{path: "gopkg.in/demo", wantErr: "must end with .vN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := ValidateModulePath(tt.path)
+			checkNameError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseArgsValidatesNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "uppercase project name",
+			args:    []string{"--project", "Demo", "--username", "acme"},
+			wantErr: `invalid project name "Demo"`,
+		},
+		{
+			name:    "invalid username",
+			args:    []string{"--project", "demo", "--username", "acme_corp"},
+			wantErr: `invalid username "acme_corp"`,
+		},
+		{
+			name:    "reserved module path element",
+			args:    []string{"--project", "demo", "--username", "acme", "--module", "example.com/aux"},
+			wantErr: `invalid module path "example.com/aux"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseArgs(append(tt.args, "--output", t.TempDir()))
+			checkNameError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSanitizeProjectName(t *testing.T) {
+	tests := map[string]string{
+		"My Cool Service!":        "my-cool-service",
+		"  spaced  out  ":         "spaced-out",
+		"API_Gateway.v2":          "api-gateway-v2",
+		"already-valid":           "already-valid",
+		"???":                     "",
+		strings.Repeat("ab-", 30): strings.TrimRight(strings.Repeat("ab-", 21), "-"),
+	}
+
+	for name, want := range tests {
+		if got := SanitizeProjectName(name); got != want {
+			t.Errorf("SanitizeProjectName(%q) = %q, want %q", name, got, want)
+		}
+		if want != "" {
+			if err := ValidateProjectName(want); err != nil {
+				t.Errorf("the suggestion for %q is invalid: %v", name, err)
+			}
+		}
+	}
+}
+
+// checkNameError checks that err contains wantErr, or is nil when wantErr is empty
+func checkNameError(t *testing.T, err error, wantErr string) {
+	t.Helper()
+	switch {
+	case wantErr == "" && err != nil:
+		t.Errorf("error = %v, want nil", err)
+	case wantErr != "" && err == nil:
+		t.Errorf("error = nil, want an error containing %q", wantErr)
+	case wantErr != "" && !strings.Contains(err.Error(), wantErr):
+		t.Errorf("error = %v, want it to contain %q", err, wantErr)
+	}
+}
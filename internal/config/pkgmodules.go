@@ -0,0 +1,65 @@
+// internal/config/pkgmodules.go - Nested modules published from pkg/
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// pkgModuleNamePattern matches the names of the nested modules, which are both
+// directories of pkg/ and the names of their packages
+var pkgModuleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// reservedPkgModules are the packages of pkg/ the generator writes for the main
+// module; a nested module there would take them out of it
+var reservedPkgModules = []string{
+	"breaker",
+	"clock",
+	"httpcache",
+	"httpclient",
+	"id",
+	"password",
+	"ratelimit",
+}
+
+// parsePkgModules parses a comma-separated list of nested module names
+func parsePkgModules(list string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return ParsePkgModules(names)
+}
+
+// ParsePkgModules validates the names of the pkg/ subdirectories published as
+// their own modules, e.g. client for pkg/client
+func ParsePkgModules(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	seen := map[string]bool{}
+	for _, name := range names {
+		if !pkgModuleNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid pkg module name %q: use lowercase letters and digits, starting with a letter", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate pkg module name %q", name)
+		}
+		if contains(reservedPkgModules, name) {
+			return nil, fmt.Errorf("pkg module name %q clashes with pkg/%s of the main module", name, name)
+		}
+		seen[name] = true
+	}
+	return names, nil
+}
+
+// PkgModulePath returns the module path of the nested module published from pkg/<name>
+func (p ProjectConfig) PkgModulePath(name string) string {
+	return p.ModuleName + "/pkg/" + name
+}
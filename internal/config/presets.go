@@ -0,0 +1,63 @@
+// internal/config/presets.go - Named component sets selectable with --preset
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Preset names accepted on the command line
+const (
+	PresetMinimal = "minimal"
+	PresetAPI     = "api"
+	PresetFull    = "full"
+)
+
+// Preset is a named set of components
+type Preset struct {
+	// Name accepted by --preset
+	Name string
+	// Description shown in the wizard
+	Description string
+	// Components included by the preset
+	Components []string
+}
+
+// Presets lists all presets in display order
+var Presets = []Preset{
+	{
+		Name:        PresetMinimal,
+		Description: "module, main.go, logger, config and Makefile only",
+		Components:  []string{},
+	},
+	{
+		Name:        PresetAPI,
+		Description: "HTTP API with a database and Docker",
+		Components:  []string{ComponentHTTP, ComponentPostgres, ComponentDocker},
+	},
+	{
+		Name:        PresetFull,
+		Description: "every component",
+		Components:  []string{ComponentHTTP, ComponentGRPC, ComponentPostgres, ComponentRedis, ComponentDocker, ComponentCICD, ComponentMetrics, ComponentTracing, ComponentAuth},
+	},
+}
+
+// PresetNames returns the names of all presets in display order
+func PresetNames() []string {
+	names := make([]string, 0, len(Presets))
+	for _, preset := range Presets {
+		names = append(names, preset.Name)
+	}
+	return names
+}
+
+// ParsePreset looks up a preset by name
+func ParsePreset(name string) (Preset, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, preset := range Presets {
+		if preset.Name == name {
+			return preset, nil
+		}
+	}
+	return Preset{}, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(PresetNames(), ", "))
+}
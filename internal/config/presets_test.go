@@ -0,0 +1,100 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPresetNames(t *testing.T) {
+	want := []string{PresetMinimal, PresetAPI, PresetFull}
+	if got := PresetNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PresetNames() = %v, want %v", got, want)
+	}
+}
+
+func TestParsePreset(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "minimal", input: "minimal", want: PresetMinimal},
+		{name: "api", input: "api", want: PresetAPI},
+		{name: "case and spaces", input: " Full ", want: PresetFull},
+		{name: "unknown", input: "everything", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preset, err := ParsePreset(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParsePreset(%q) = %v, want an error", tt.input, preset.Name)
+				}
+				// The error lists the presets to choose from
+				if !strings.Contains(err.Error(), strings.Join(PresetNames(), ", ")) {
+					t.Errorf("ParsePreset(%q) error = %v, want it to list the presets", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePreset(%q) error = %v", tt.input, err)
+			}
+			if preset.Name != tt.want {
+				t.Errorf("ParsePreset(%q) = %q, want %q", tt.input, preset.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestPresetComponentsAreValid(t *testing.T) {
+	for _, preset := range Presets {
+		t.Run(preset.Name, func(t *testing.T) {
+			components, err := ParseComponents(preset.Components)
+			if err != nil {
+				t.Fatalf("ParseComponents(%v) error = %v", preset.Components, err)
+			}
+			// Names lists the components in registry order, which the presets follow
+			if got, want := strings.Join(components.Names(), ","), strings.Join(preset.Components, ","); got != want {
+				t.Errorf("components of %s = %s, want %s", preset.Name, got, want)
+			}
+		})
+	}
+}
+
+func TestParseArgsPreset(t *testing.T) {
+	base := []string{"--project", "demo", "--username", "acme", "--output", t.TempDir()}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		{name: "minimal", args: []string{"--preset", "minimal"}, want: nil},
+		{name: "api", args: []string{"--preset", "api"}, want: []string{ComponentHTTP, ComponentPostgres, ComponentDocker}},
+		{name: "unknown", args: []string{"--preset", "everything"}, wantErr: "unknown preset"},
+		{name: "with components", args: []string{"--preset", "api", "--components", "http"}, wantErr: "cannot be combined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseArgs(append(append([]string{}, base...), tt.args...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseArgs() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseArgs() error = %v", err)
+			}
+			if got := cfg.ProjectConfig.Components.Names(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("components = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
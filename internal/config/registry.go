@@ -0,0 +1,141 @@
+// internal/config/registry.go - Container registry the Docker image is pushed to
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Registry kinds accepted on the command line
+const (
+	RegistryDockerHub = "dockerhub"
+	RegistryGHCR      = "ghcr"
+	RegistryGitLab    = "gitlab"
+	RegistryECR       = "ecr"
+	RegistryGAR       = "gar"
+	RegistryCustom    = "custom"
+)
+
+// Registries lists all registry kinds in display order
+var Registries = []string{
+	RegistryDockerHub,
+	RegistryGHCR,
+	RegistryGitLab,
+	RegistryECR,
+	RegistryGAR,
+	RegistryCustom,
+}
+
+// DefaultRegistry is the registry used when none is selected
+const DefaultRegistry = RegistryDockerHub
+
+// Registry is the container registry the project image is pushed to
+type Registry struct {
+	// Kind of registry (dockerhub, ghcr, gitlab, ecr, gar or custom)
+	Kind string
+	// Host of the registry; required for ecr, gar and custom
+	// (e.g., 123456789012.dkr.ecr.us-east-1.amazonaws.com,
+	// us-central1-docker.pkg.dev/my-project/my-repo or registry.example.com)
+	Host string
+}
+
+var (
+	ecrHostPattern = regexp.MustCompile(`^\d{12}\.dkr\.ecr\.([a-z0-9-]+)\.amazonaws\.com$`)
+	garHostPattern = regexp.MustCompile(`^([a-z0-9-]+)-docker\.pkg\.dev/[a-z][a-z0-9-]*/[a-z0-9._-]+$`)
+)
+
+// RegistryNeedsHost reports whether a registry kind requires a host
+func RegistryNeedsHost(kind string) bool {
+	return kind == RegistryECR || kind == RegistryGAR || kind == RegistryCustom
+}
+
+// ParseRegistry validates a registry kind and its host
+func ParseRegistry(kind, host string) (Registry, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	host = strings.TrimSuffix(strings.TrimSpace(host), "/")
+	if kind == "" {
+		kind = DefaultRegistry
+	}
+	if !contains(Registries, kind) {
+		return Registry{}, fmt.Errorf("unknown registry %q (available: %s)", kind, strings.Join(Registries, ", "))
+	}
+
+	if !RegistryNeedsHost(kind) {
+		if host != "" {
+			return Registry{}, fmt.Errorf("registry %s does not take a host", kind)
+		}
+		return Registry{Kind: kind}, nil
+	}
+
+	switch {
+	case host == "":
+		return Registry{}, fmt.Errorf("registry %s requires a host", kind)
+	case strings.Contains(host, "://"):
+		return Registry{}, fmt.Errorf("registry host %q must not include a scheme", host)
+	case kind == RegistryECR && !ecrHostPattern.MatchString(host):
+		return Registry{}, fmt.Errorf("invalid ECR host %q: must look like <account>.dkr.ecr.<region>.amazonaws.com", host)
+	case kind == RegistryGAR && !garHostPattern.MatchString(host):
+		return Registry{}, fmt.Errorf("invalid Artifact Registry host %q: must look like <region>-docker.pkg.dev/<project>/<repository>", host)
+	}
+
+	return Registry{Kind: kind, Host: host}, nil
+}
+
+// Region returns the cloud region of an ECR or Artifact Registry host
+func (r Registry) Region() string {
+	switch r.Kind {
+	case RegistryECR:
+		if m := ecrHostPattern.FindStringSubmatch(r.Host); m != nil {
+			return m[1]
+		}
+	case RegistryGAR:
+		if m := garHostPattern.FindStringSubmatch(r.Host); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// LoginHost returns the host docker logs in to
+func (r Registry) LoginHost() string {
+	switch r.Kind {
+	case RegistryGHCR:
+		return "ghcr.io"
+	case RegistryGitLab:
+		return "registry.gitlab.com"
+	case RegistryECR, RegistryCustom:
+		return r.Host
+	case RegistryGAR:
+		return r.Region() + "-docker.pkg.dev"
+	}
+	return "docker.io"
+}
+
+// ImagePrefix returns the part of the image name before the project name; it
+// is empty for a Docker Hub image without an owner
+func (r Registry) ImagePrefix(username string) string {
+	switch r.Kind {
+	case RegistryGHCR:
+		return "ghcr.io/" + strings.ToLower(username)
+	case RegistryGitLab:
+		return "registry.gitlab.com/" + strings.ToLower(username)
+	case RegistryECR, RegistryGAR:
+		return r.Host
+	case RegistryCustom:
+		if username == "" {
+			return r.Host
+		}
+		return r.Host + "/" + strings.ToLower(username)
+	}
+	// Image names are lowercase, unlike GitHub usernames
+	return strings.ToLower(username)
+}
+
+// Image returns the image repository of a project, without a tag
+func (r Registry) Image(username, projectName string) string {
+	if prefix := r.ImagePrefix(username); prefix != "" {
+		return prefix + "/" + projectName
+	}
+	return projectName
+}
@@ -0,0 +1,286 @@
+// internal/config/schema.go - JSON Schema of the project configuration files
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaDialect is the JSON Schema version of ProjectFileSchema
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema, limited to the keywords that describe project
+// configuration files
+type Schema struct {
+	Dialect              string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MaxLength            int                `json:"maxLength,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// serviceDef is the name of the definition of the services of a workspace
+const serviceDef = "service"
+
+// requiredFields are the fields of ProjectFile every file must set; services
+// inherit the username of the workspace
+var requiredFields = []string{"username", "projectName"}
+
+// fieldSchemas describe the fields of ProjectFile and Companion, keyed by their
+// YAML names, with the values the validation accepts; the types and the items of
+// lists come from the Go fields. Every field needs an entry, so a new field
+// can't be left out of the schema.
+func fieldSchemas() map[string]*Schema {
+	presets := make([]string, len(Presets))
+	for i, preset := range Presets {
+		presets[i] = preset.Name + " (" + preset.Description + ")"
+	}
+
+	return map[string]*Schema{
+		"username":            {Description: "GitHub username or organization of the module path", Pattern: usernamePattern.String(), MaxLength: maxUsernameLength},
+		"projectName":         {Description: "Name of the project directory, binary and image", Pattern: projectNamePattern.String(), MaxLength: maxProjectNameLength},
+		"moduleName":          {Description: "Module path, defaults to github.com/<username>/<projectName>"},
+		"preset":              {Description: "Named component set replacing components: " + strings.Join(presets, ", "), Enum: PresetNames()},
+		"components":          {Description: "Components to include", Items: &Schema{Enum: ComponentNames}},
+		"httpFramework":       {Description: "Framework of the http component", Enum: HTTPFrameworks},
+		"ciProvider":          {Description: "CI provider of the cicd component", Enum: CIProviders},
+		"tokenFormat":         {Description: "Format of the tokens issued by the auth component", Enum: TokenFormats},
+		"jsonEngine":          {Description: "JSON engine of the gin framework", Enum: JSONEngines},
+		"postgresDriver":      {Description: "Driver of the postgres component", Enum: PostgresDrivers},
+		"dataLayer":           {Description: "Data layer of the postgres component", Enum: DataLayers},
+		"buildTargets":        {Description: "GOOS/GOARCH pairs to cross-compile for", Items: &Schema{Enum: SupportedBuildTargets}},
+		"databases":           {Description: "Names of the database connections when there are several, main one first", Items: &Schema{Pattern: databaseNamePattern.String()}},
+		"registry":            {Description: "Container registry the Docker image is pushed to", Enum: Registries},
+		"registryHost":        {Description: "Host of the ecr, gar and custom registries"},
+		"companions":          {Description: "Modules developed alongside the project, added to go.work"},
+		"module":              {Description: "Module path of the companion, read from its go.mod when omitted"},
+		"path":                {Description: "Directory of the companion, relative to the project or absolute"},
+		"companionReplaces":   {Description: "Also add replace directives for the companions to go.mod"},
+		"pkgModules":          {Description: "pkg/ subdirectories published as their own modules", Items: &Schema{Pattern: pkgModuleNamePattern.String()}},
+		"vendor":              {Description: "Commit vendor/ and build the Docker image from it"},
+		"noTests":             {Description: "Omit the generated unit tests"},
+		"coalescingExample":   {Description: "Generate the request coalescing example endpoint"},
+		"responseCache":       {Description: "Cache the responses of selected GET routes"},
+		"description":         {Description: "One-line summary of the service reported by its service descriptor", MaxLength: maxDescriptionLength},
+		"owners":              {Description: "Teams or people owning the service, reported by its service descriptor"},
+		"defaultBranch":       {Description: "Branch the CI pipeline builds and deploys", Pattern: branchNamePattern.String()},
+		"conventionalCommits": {Description: "Enforce conventional commit messages"},
+		"release":             {Description: "Automate releases and CHANGELOG.md from the conventional commits; implies conventionalCommits"},
+		"tls":                 {Description: "Serve HTTPS and generate local development certificates"},
+		"goVersion":           {Description: "Go version of go.mod, the Docker build image and the CI pipeline", Pattern: goVersionPattern.String()},
+		"hooks":               {Description: "Commands run in the generated project after go mod tidy"},
+		"services":            {Description: "Services of a workspace, each generated into services/<projectName>"},
+	}
+}
+
+// ProjectFileSchema returns the JSON Schema of the project configuration files,
+// generated from ProjectFile; it fails when a field has no description in
+// fieldSchemas or a type the schema can't describe
+func ProjectFileSchema() (*Schema, error) {
+	fields := fieldSchemas()
+
+	root, err := objectSchema(reflect.TypeOf(ProjectFile{}), fields)
+	if err != nil {
+		return nil, err
+	}
+	root.Dialect = SchemaDialect
+	root.Title = "go-project-gen project configuration"
+	root.Required = requiredFields
+
+	// A service is a project without services of its own
+	service, err := objectSchema(reflect.TypeOf(ProjectFile{}), fields)
+	if err != nil {
+		return nil, err
+	}
+	delete(service.Properties, "services")
+	service.Required = []string{"projectName"}
+	root.Defs = map[string]*Schema{serviceDef: service}
+
+	return root, nil
+}
+
+// objectSchema returns the schema of a struct from the YAML names of its fields
+func objectSchema(t reflect.Type, fields map[string]*Schema) (*Schema, error) {
+	closed := false
+	schema := &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: &closed,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		described, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("no schema for the field %s of %s", name, t.Name())
+		}
+		property, err := typeSchema(field.Type, fields)
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", name, t.Name(), err)
+		}
+		property.Description = described.Description
+		property.Enum = described.Enum
+		property.Pattern = described.Pattern
+		property.MaxLength = described.MaxLength
+		if described.Items != nil {
+			property.Items.Enum = described.Items.Enum
+			property.Items.Pattern = described.Items.Pattern
+		}
+		schema.Properties[name] = property
+
+		// The fields of a companion without omitempty are required;
+		// ProjectFileSchema sets the required fields of a project
+		if t != reflect.TypeOf(ProjectFile{}) && !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema, nil
+}
+
+// typeSchema returns the schema of a Go type of ProjectFile
+func typeSchema(t reflect.Type, fields map[string]*Schema) (*Schema, error) {
+	switch {
+	case t == reflect.TypeOf(ProjectFile{}):
+		return &Schema{Ref: "#/$defs/" + serviceDef}, nil
+	case t.Kind() == reflect.Struct:
+		return objectSchema(t, fields)
+	case t.Kind() == reflect.Slice:
+		items, err := typeSchema(t.Elem(), fields)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case t.Kind() == reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case t.Kind() == reflect.String:
+		return &Schema{Type: "string"}, nil
+	}
+	return nil, fmt.Errorf("no schema for the type %s", t)
+}
+
+// validateSchema checks a parsed configuration file against the schema; the
+// error names the offending field by its path, such as services[0].components[1]
+func validateSchema(path string, root *yaml.Node, schema *Schema) error {
+	document := mappingNode(root)
+	if document.Kind == 0 {
+		// An empty file is an empty mapping
+		document = &yaml.Node{Kind: yaml.MappingNode, Line: 1}
+	}
+
+	v := schemaValidator{path: path, defs: schema.Defs}
+	return v.validate(document, schema, "")
+}
+
+// schemaValidator validates the nodes of a configuration file
+type schemaValidator struct {
+	path string
+	defs map[string]*Schema
+}
+
+// validate checks a node against a schema; field is the path of the node
+func (v schemaValidator) validate(node *yaml.Node, schema *Schema, field string) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if schema.Ref != "" {
+		schema = v.defs[strings.TrimPrefix(schema.Ref, "#/$defs/")]
+	}
+
+	switch schema.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			return v.fail(node, field, "must be an object")
+		}
+		present := map[string]bool{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			present[key.Value] = true
+
+			property, ok := schema.Properties[key.Value]
+			if !ok {
+				return v.fail(key, fieldPath(field, key.Value), "is not a known field")
+			}
+			if err := v.validate(value, property, fieldPath(field, key.Value)); err != nil {
+				return err
+			}
+		}
+		for _, name := range schema.Required {
+			if !present[name] {
+				return v.fail(node, fieldPath(field, name), "is required")
+			}
+		}
+
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			return v.fail(node, field, "must be an array")
+		}
+		for i, item := range node.Content {
+			if err := v.validate(item, schema.Items, fmt.Sprintf("%s[%d]", field, i)); err != nil {
+				return err
+			}
+		}
+
+	case "boolean":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			return v.fail(node, field, "must be a boolean")
+		}
+
+	case "string":
+		// Unquoted numbers such as goVersion: 1.20 are not strings
+		if node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float") {
+			return v.fail(node, field, fmt.Sprintf("must be a string, quote it: %q", node.Value))
+		}
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+			return v.fail(node, field, "must be a string")
+		}
+		if len(schema.Enum) > 0 && !contains(schema.Enum, node.Value) {
+			return v.fail(node, field, "must be one of "+strings.Join(schema.Enum, ", "))
+		}
+		if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(node.Value) {
+			return v.fail(node, field, "must match "+schema.Pattern)
+		}
+		if schema.MaxLength > 0 && len(node.Value) > schema.MaxLength {
+			return v.fail(node, field, fmt.Sprintf("must be at most %d characters", schema.MaxLength))
+		}
+	}
+
+	return nil
+}
+
+// fail returns the error of an invalid node
+func (v schemaValidator) fail(node *yaml.Node, field, msg string) error {
+	line := node.Line
+	if line == 0 {
+		line = 1
+	}
+	if field == "" {
+		field = "(root)"
+	}
+	return &FileError{Path: v.path, Line: line, Field: field, Msg: msg}
+}
+
+// fieldPath appends the name of a field to the path of its parent
+func fieldPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
@@ -0,0 +1,269 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/project.schema.json")
+
+// schemaGolden is the schema exported by go-project-gen schema
+const schemaGolden = "testdata/project.schema.json"
+
+func TestProjectFileSchemaGolden(t *testing.T) {
+	schema, err := ProjectFileSchema()
+	if err != nil {
+		t.Fatalf("ProjectFileSchema() error = %v", err)
+	}
+	content, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal the schema: %v", err)
+	}
+	content = append(content, '\n')
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(schemaGolden), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(schemaGolden, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(schemaGolden)
+	if err != nil {
+		t.Fatalf("failed to read %s, run go test ./internal/config -update: %v", schemaGolden, err)
+	}
+	if !bytes.Equal(content, want) {
+		t.Errorf("the schema differs from %s; if the change is intended, run go test ./internal/config -update", schemaGolden)
+	}
+}
+
+func TestProjectFileSchemaCoversFields(t *testing.T) {
+	schema, err := ProjectFileSchema()
+	if err != nil {
+		t.Fatalf("ProjectFileSchema() error = %v", err)
+	}
+
+	checkFields(t, "(root)", reflect.TypeOf(ProjectFile{}), schema)
+	checkFields(t, "$defs.service", reflect.TypeOf(ProjectFile{}), schema.Defs[serviceDef])
+
+	services := schema.Properties["services"]
+	if services.Items == nil || services.Items.Ref != "#/$defs/"+serviceDef {
+		t.Errorf("services items = %+v, want a reference to the service definition", services.Items)
+	}
+	if _, ok := schema.Defs[serviceDef].Properties["services"]; ok {
+		t.Error("services may have services of their own")
+	}
+	if got := schema.Defs[serviceDef].Required; !reflect.DeepEqual(got, []string{"projectName"}) {
+		t.Errorf("service required = %v, want [projectName]", got)
+	}
+}
+
+// checkFields checks that the properties of an object schema are the YAML
+// fields of t, with the type of each field
+func checkFields(t *testing.T, path string, typ reflect.Type, schema *Schema) {
+	t.Helper()
+
+	fields := map[string]bool{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = true
+		// A service has no services of its own
+		if path != "(root)" && name == "services" {
+			continue
+		}
+
+		property, ok := schema.Properties[name]
+		if !ok {
+			t.Errorf("%s: the schema lacks the field %s", path, name)
+			continue
+		}
+		if property.Description == "" {
+			t.Errorf("%s.%s has no description", path, name)
+		}
+		checkType(t, path+"."+name, field.Type, property)
+	}
+
+	for name := range schema.Properties {
+		if !fields[name] {
+			t.Errorf("%s: the schema has the property %s, which %s has no field for", path, name, typ.Name())
+		}
+	}
+}
+
+// checkType checks that a property schema describes values of typ
+func checkType(t *testing.T, path string, typ reflect.Type, property *Schema) {
+	t.Helper()
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		if property.Type != "boolean" {
+			t.Errorf("%s type = %q, want boolean", path, property.Type)
+		}
+	case reflect.String:
+		if property.Type != "string" {
+			t.Errorf("%s type = %q, want string", path, property.Type)
+		}
+	case reflect.Slice:
+		if property.Type != "array" || property.Items == nil {
+			t.Errorf("%s type = %q, want an array", path, property.Type)
+			return
+		}
+		if typ.Elem() != reflect.TypeOf(ProjectFile{}) {
+			checkType(t, path+"[]", typ.Elem(), property.Items)
+		}
+	case reflect.Struct:
+		if property.Type != "object" {
+			t.Errorf("%s type = %q, want object", path, property.Type)
+			return
+		}
+		checkFields(t, path, typ, property)
+	default:
+		t.Errorf("%s has the type %s, which the schema can't describe", path, typ)
+	}
+}
+
+func TestObjectSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		wantErr string
+	}{
+		{
+			name: "undescribed field",
+			value: struct {
+				Undescribed string `yaml:"undescribed"`
+			}{},
+			wantErr: "no schema for the field undescribed",
+		},
+		{
+			name: "unsupported type",
+			value: struct {
+				Port int `yaml:"vendor"`
+			}{},
+			wantErr: "no schema for the type int",
+		},
+		{
+			name: "unsupported item type",
+			value: struct {
+				Ports []int `yaml:"hooks"`
+			}{},
+			wantErr: "no schema for the type int",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := objectSchema(reflect.TypeOf(tt.value), fieldSchemas())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("objectSchema() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadProjectFileRejectsInvalidFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantLine  int
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name:      "unknown key",
+			content:   "username: acme\nprojectName: demo\ncomponets: [http]\n",
+			wantLine:  3,
+			wantField: "componets",
+			wantMsg:   "is not a known field",
+		},
+		{
+			name:      "unknown key of a companion",
+			content:   "username: acme\nprojectName: demo\ncompanions:\n  - path: ../shared\n    version: v1\n",
+			wantLine:  5,
+			wantField: "companions[0].version",
+			wantMsg:   "is not a known field",
+		},
+		{
+			name:      "unknown key of a service",
+			content:   "username: acme\nprojectName: shop\nservices:\n  - projectName: orders\n    port: 8080\n",
+			wantLine:  5,
+			wantField: "services[0].port",
+			wantMsg:   "is not a known field",
+		},
+		{
+			name:      "string instead of a list",
+			content:   "username: acme\nprojectName: demo\ncomponents: http\n",
+			wantLine:  3,
+			wantField: "components",
+			wantMsg:   "must be an array",
+		},
+		{
+			name:      "string instead of a boolean",
+			content:   "username: acme\nprojectName: demo\nvendor: \"true\"\n",
+			wantLine:  3,
+			wantField: "vendor",
+			wantMsg:   "must be a boolean",
+		},
+		{
+			name:      "number instead of a string",
+			content:   "username: acme\nprojectName: demo\ngoVersion: 1.24\n",
+			wantLine:  3,
+			wantField: "goVersion",
+			wantMsg:   "must be a string",
+		},
+		{
+			name:      "list instead of an object",
+			content:   "username: acme\nprojectName: demo\ncompanions:\n  - ../shared\n",
+			wantLine:  4,
+			wantField: "companions[0]",
+			wantMsg:   "must be an object",
+		},
+		{
+			name:      "wrong item of a service",
+			content:   "username: acme\nprojectName: shop\nservices:\n  - projectName: orders\n    components: [http, mongo]\n",
+			wantLine:  5,
+			wantField: "services[0].components[1]",
+			wantMsg:   "must be one of",
+		},
+		{
+			name:      "missing required field",
+			content:   "username: acme\n",
+			wantLine:  1,
+			wantField: "projectName",
+			wantMsg:   "is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "project.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadProjectFile(path)
+			var fileErr *FileError
+			if !errors.As(err, &fileErr) {
+				t.Fatalf("LoadProjectFile() error = %v, want a *FileError", err)
+			}
+			if fileErr.Path != path || fileErr.Line != tt.wantLine || fileErr.Field != tt.wantField {
+				t.Errorf("error at %s:%d: %s, want %s:%d: %s", fileErr.Path, fileErr.Line, fileErr.Field, path, tt.wantLine, tt.wantField)
+			}
+			if !strings.HasPrefix(fileErr.Msg, tt.wantMsg) {
+				t.Errorf("Msg = %q, want it to start with %q", fileErr.Msg, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+// internal/doctor/doctor.go - Environment checks run before generating a project
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// MinGoVersion is the oldest Go toolchain able to build the generated projects
+const MinGoVersion = config.MinGoVersion
+
+// commandTimeout bounds each external command run by a check
+const commandTimeout = 10 * time.Second
+
+// Status is the outcome of a check
+type Status int
+
+// Check outcomes; only Fail makes the report fail
+const (
+	Pass Status = iota
+	Warn
+	Fail
+)
+
+// String returns the label printed for a status
+func (s Status) String() string {
+	switch s {
+	case Pass:
+		return "PASS"
+	case Warn:
+		return "WARN"
+	}
+	return "FAIL"
+}
+
+// Runner runs an external command and returns its combined output
+type Runner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+// ExecRunner runs commands with os/exec
+func ExecRunner(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Name   string
+	Status Status
+	Detail string
+	// Hint tells the user how to fix a warning or failure
+	Hint string
+}
+
+// Check is a single environment check
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) Result
+}
+
+// Options selects the checks relevant to a generation
+type Options struct {
+	// Directory the project is generated in
+	OutputDir string
+	// Check the Docker daemon, for projects with the Docker component
+	Docker bool
+	// Go version of the generated project; the toolchain must be at least this
+	// version, or MinGoVersion when empty
+	GoVersion string
+}
+
+// Checks returns the checks for the given options
+func Checks(run Runner, opts Options) []Check {
+	checks := []Check{
+		GoToolchain(run, opts.GoVersion),
+		OutputWritable(opts.OutputDir),
+	}
+	if opts.Docker {
+		checks = append(checks, DockerDaemon(run))
+	}
+	return checks
+}
+
+// GoToolchain checks that go is installed and at least the required version,
+// MinGoVersion when required is empty
+func GoToolchain(run Runner, required string) Check {
+	if required == "" {
+		required = MinGoVersion
+	}
+	return Check{
+		Name: "Go toolchain",
+		Run: func(ctx context.Context) Result {
+			result := Result{Name: "Go toolchain"}
+
+			output, err := run(ctx, "go", "version")
+			if err != nil {
+				result.Status = Fail
+				result.Detail = commandError("go version", output, err)
+				result.Hint = "Install Go " + required + " or newer from https://go.dev/dl/ and make sure go is on your PATH"
+				return result
+			}
+
+			version, ok := parseGoVersion(string(output))
+			if !ok {
+				result.Status = Warn
+				result.Detail = "could not determine the version from " + strings.TrimSpace(string(output))
+				result.Hint = "Make sure the toolchain is Go " + required + " or newer"
+				return result
+			}
+
+			if compareVersions(version, required) < 0 {
+				result.Status = Fail
+				result.Detail = fmt.Sprintf("go%s is older than the required go%s", version, required)
+				result.Hint = "Upgrade Go from https://go.dev/dl/ or set GOTOOLCHAIN=go" + toolchainRelease(required) + " to download a newer toolchain"
+				return result
+			}
+
+			result.Detail = "go" + version
+			return result
+		},
+	}
+}
+
+// DockerDaemon checks that the Docker daemon is reachable; failures are warnings
+// because Docker is only needed to build and run the generated image
+func DockerDaemon(run Runner) Check {
+	return Check{
+		Name: "Docker daemon",
+		Run: func(ctx context.Context) Result {
+			result := Result{Name: "Docker daemon"}
+
+			output, err := run(ctx, "docker", "version", "--format", "{{.Server.Version}}")
+			if err != nil {
+				result.Status = Warn
+				if errors.Is(err, exec.ErrNotFound) {
+					result.Detail = "docker is not installed"
+					result.Hint = "Install Docker from https://docs.docker.com/get-docker/ to build the generated image"
+				} else {
+					result.Detail = commandError("docker version", output, err)
+					result.Hint = "Start Docker Desktop or the docker service, and check that your user may access the Docker socket"
+				}
+				return result
+			}
+
+			result.Detail = "server " + strings.TrimSpace(string(output))
+			return result
+		},
+	}
+}
+
+// OutputWritable checks that files can be created in the output directory,
+// or in its closest existing parent when it does not exist yet
+func OutputWritable(dir string) Check {
+	return Check{
+		Name: "Output directory",
+		Run: func(ctx context.Context) Result {
+			result := Result{Name: "Output directory"}
+
+			existing, err := closestExistingDir(dir)
+			if err != nil {
+				result.Status = Fail
+				result.Detail = err.Error()
+				result.Hint = "Choose another directory with --output"
+				return result
+			}
+
+			file, err := os.CreateTemp(existing, ".doctor-*")
+			if err != nil {
+				result.Status = Fail
+				result.Detail = fmt.Sprintf("%s is not writable: %v", existing, err)
+				result.Hint = "Check the directory permissions, or whether it is on a read-only mount, or choose another directory with --output"
+				return result
+			}
+			file.Close()
+			os.Remove(file.Name())
+
+			result.Detail = existing + " is writable"
+			if existing != filepath.Clean(dir) {
+				result.Detail = fmt.Sprintf("%s will be created in %s, which is writable", dir, existing)
+			}
+			return result
+		},
+	}
+}
+
+// Report holds the results of a doctor run
+type Report struct {
+	Results []Result
+}
+
+// Run runs the checks in order
+func Run(ctx context.Context, checks []Check) *Report {
+	report := &Report{}
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, commandTimeout)
+		report.Results = append(report.Results, check.Run(checkCtx))
+		cancel()
+	}
+	return report
+}
+
+// Failed reports whether any check failed
+func (r *Report) Failed() bool {
+	return r.count(Fail) > 0
+}
+
+// Warned reports whether any check produced a warning
+func (r *Report) Warned() bool {
+	return r.count(Warn) > 0
+}
+
+// Print writes the results with remediation hints for warnings and failures
+func (r *Report) Print(w io.Writer) {
+	for _, result := range r.Results {
+		fmt.Fprintf(w, "[%s] %s: %s\n", result.Status, result.Name, result.Detail)
+		if result.Status != Pass && result.Hint != "" {
+			fmt.Fprintf(w, "       → %s\n", result.Hint)
+		}
+	}
+	fmt.Fprintf(w, "\n%d passed, %d warnings, %d failed\n", r.count(Pass), r.count(Warn), r.count(Fail))
+}
+
+func (r *Report) count(status Status) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// goVersionPattern matches the version in the output of go version, e.g. "go version go1.23.4 linux/amd64"
+var goVersionPattern = regexp.MustCompile(`\bgo(\d+\.\d+(?:\.\d+)?)`)
+
+// parseGoVersion extracts the version from the output of go version
+func parseGoVersion(output string) (string, bool) {
+	m := goVersionPattern.FindStringSubmatch(output)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// toolchainRelease returns the first release of a Go version, e.g. 1.23.0 for 1.23;
+// GOTOOLCHAIN names releases, not language versions
+func toolchainRelease(version string) string {
+	if strings.Count(version, ".") == 1 {
+		return version + ".0"
+	}
+	return version
+}
+
+// compareVersions compares dotted numeric versions, treating missing parts as zero
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// closestExistingDir returns dir, or its closest existing parent
+func closestExistingDir(dir string) (string, error) {
+	dir = filepath.Clean(dir)
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return "", fmt.Errorf("%s is not a directory", dir)
+			}
+			return dir, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("failed to inspect %s: %w", dir, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no existing parent directory for %s", dir)
+		}
+		dir = parent
+	}
+}
+
+// commandError describes a failed command with the first line of its output
+func commandError(command string, output []byte, err error) string {
+	if line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n"); line != "" {
+		return fmt.Sprintf("%s failed: %s", command, line)
+	}
+	return fmt.Sprintf("%s failed: %v", command, err)
+}
@@ -0,0 +1,164 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeRunner returns a Runner answering each command, keyed by its name, with
+// the given output and error; commands without an answer are not installed
+func fakeRunner(answers map[string]fakeAnswer) Runner {
+	return func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		answer, ok := answers[name]
+		if !ok {
+			return nil, &exec.Error{Name: name, Err: exec.ErrNotFound}
+		}
+		return []byte(answer.output), answer.err
+	}
+}
+
+// fakeAnswer is the result of a faked command
+type fakeAnswer struct {
+	output string
+	err    error
+}
+
+func TestGoToolchain(t *testing.T) {
+	tests := []struct {
+		name       string
+		answers    map[string]fakeAnswer
+		required   string
+		wantStatus Status
+		wantDetail string
+	}{
+		{
+			name:       "missing",
+			answers:    map[string]fakeAnswer{},
+			wantStatus: Fail,
+			wantDetail: "go version failed",
+		},
+		{
+			name:       "older than the minimum",
+			answers:    map[string]fakeAnswer{"go": {output: "go version go1.19.13 linux/amd64\n"}},
+			wantStatus: Fail,
+			wantDetail: "go1.19.13 is older than the required go" + MinGoVersion,
+		},
+		{
+			name:       "older than the project",
+			answers:    map[string]fakeAnswer{"go": {output: "go version go1.23.4 linux/amd64\n"}},
+			required:   "1.24",
+			wantStatus: Fail,
+			wantDetail: "go1.23.4 is older than the required go1.24",
+		},
+		{
+			name:       "unknown version",
+			answers:    map[string]fakeAnswer{"go": {output: "go version devel linux/amd64\n"}},
+			wantStatus: Warn,
+			wantDetail: "could not determine the version",
+		},
+		{
+			name:       "ok",
+			answers:    map[string]fakeAnswer{"go": {output: "go version go1.24.2 darwin/arm64\n"}},
+			wantStatus: Pass,
+			wantDetail: "go1.24.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GoToolchain(fakeRunner(tt.answers), tt.required).Run(context.Background())
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s (%s)", result.Status, tt.wantStatus, result.Detail)
+			}
+			if !strings.Contains(result.Detail, tt.wantDetail) {
+				t.Errorf("Detail = %q, want it to contain %q", result.Detail, tt.wantDetail)
+			}
+			if result.Status != Pass && result.Hint == "" {
+				t.Error("Hint is empty")
+			}
+		})
+	}
+}
+
+func TestDockerDaemon(t *testing.T) {
+	tests := []struct {
+		name       string
+		answers    map[string]fakeAnswer
+		wantStatus Status
+		wantDetail string
+	}{
+		{
+			name:       "missing",
+			answers:    map[string]fakeAnswer{},
+			wantStatus: Warn,
+			wantDetail: "docker is not installed",
+		},
+		{
+			name: "daemon not running",
+			answers: map[string]fakeAnswer{"docker": {
+				output: "Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?\n",
+				err:    errors.New("exit status 1"),
+			}},
+			wantStatus: Warn,
+			wantDetail: "docker version failed: Cannot connect to the Docker daemon",
+		},
+		{
+			name:       "ok",
+			answers:    map[string]fakeAnswer{"docker": {output: "27.3.1\n"}},
+			wantStatus: Pass,
+			wantDetail: "server 27.3.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DockerDaemon(fakeRunner(tt.answers)).Run(context.Background())
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s (%s)", result.Status, tt.wantStatus, result.Detail)
+			}
+			if !strings.Contains(result.Detail, tt.wantDetail) {
+				t.Errorf("Detail = %q, want it to contain %q", result.Detail, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestOutputWritable(t *testing.T) {
+	dir := t.TempDir()
+
+	if result := OutputWritable(dir).Run(context.Background()); result.Status != Pass {
+		t.Errorf("existing directory: Status = %s, want PASS (%s)", result.Status, result.Detail)
+	}
+
+	missing := filepath.Join(dir, "new", "project")
+	result := OutputWritable(missing).Run(context.Background())
+	if result.Status != Pass {
+		t.Errorf("missing directory: Status = %s, want PASS (%s)", result.Status, result.Detail)
+	}
+	if want := fmt.Sprintf("%s will be created in %s", missing, dir); !strings.HasPrefix(result.Detail, want) {
+		t.Errorf("missing directory: Detail = %q, want it to start with %q", result.Detail, want)
+	}
+}
+
+func TestReport(t *testing.T) {
+	run := fakeRunner(map[string]fakeAnswer{"go": {output: "go version go1.18 linux/amd64\n"}})
+	report := Run(context.Background(), Checks(run, Options{OutputDir: t.TempDir(), Docker: true}))
+
+	if len(report.Results) != 3 {
+		t.Fatalf("got %d results, want 3", len(report.Results))
+	}
+	if !report.Failed() || !report.Warned() {
+		t.Errorf("Failed(), Warned() = %t, %t, want true, true", report.Failed(), report.Warned())
+	}
+
+	var out strings.Builder
+	report.Print(&out)
+	if !strings.HasSuffix(out.String(), "1 passed, 1 warnings, 1 failed\n") {
+		t.Errorf("Print() =\n%s\nwant the summary 1 passed, 1 warnings, 1 failed", out.String())
+	}
+}
@@ -0,0 +1,279 @@
+// internal/generator/add.go - Addition of a component to an existing project
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+// AdditionReport lists the changes made by adding a component; paths are
+// relative to the project directory
+type AdditionReport struct {
+	// Created are the new files of the component
+	Created []string
+	// Updated are the files regenerated with the component
+	Updated []string
+	// Merged are the files the user edited which the changes were merged into
+	Merged []string
+	// Kept are the files other than Go sources the changes could not be merged
+	// into, and the conflicts resolved by keeping them; they were left alone
+	Kept []string
+	// Overwritten are the edited files replaced with the generated content to
+	// resolve their conflicts
+	Overwritten []string
+	// Marked are the edited files written with conflict markers
+	Marked []string
+}
+
+// AddComponent adds a component to the project generated with cfg. The new
+// files are written, and so are the changed files that still match the
+// manifest. The changes to the files the user edited are merged into them,
+// with the file as generated before as the base. The files the changes can't
+// be merged into are resolved by resolve, asked before anything is written;
+// without it, nothing is written when a Go file, such as internal/app/app.go,
+// has diverged too far to merge the changes safely. go.mod gets the pinned
+// requirements of a new project with the component, then go mod tidy.
+func AddComponent(log logger.Logger, cfg *config.Config, component string, resolve ConflictResolver) (*AdditionReport, error) {
+	projectDir := filepath.Join(cfg.OutputDir, cfg.ProjectConfig.ProjectName)
+	manifest, err := LoadManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Project == nil {
+		return nil, fmt.Errorf("%s has no %s with the configuration of the project", projectDir, ManifestFile)
+	}
+
+	with, err := cfg.ProjectConfig.Components.With(component)
+	if err != nil {
+		return nil, err
+	}
+	target := cfg.ProjectConfig
+	target.Components = with
+
+	// Render the project without and with the component; the first render is
+	// the base the edited files are merged from
+	before, err := renderPlan(log, cfg, cfg.ProjectConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the project without the %s component: %w", component, err)
+	}
+	after, err := renderPlan(log, cfg, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the project with the %s component: %w", component, err)
+	}
+
+	// Work out every file before writing any, so a conflict leaves the project as it was
+	report := &AdditionReport{}
+	writes := map[string]*plannedFile{}
+	contents := map[string][]byte{}
+	var conflicts []string
+	var unmerged []*Conflict
+	for _, rel := range after.paths(func(file *plannedFile) bool { return file.status != FileUnchanged }) {
+		file := after.files[rel]
+		path := filepath.Join(after.root, rel)
+		name, err := filepath.Rel(projectDir, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+
+		writes[name] = file
+		switch {
+		case !file.exists:
+			report.Created = append(report.Created, name)
+		case !file.edited:
+			report.Updated = append(report.Updated, name)
+		default:
+			merged, ok := mergeEdited(manifest, name, before.files[rel], file)
+			switch {
+			case ok:
+				report.Merged = append(report.Merged, name)
+				contents[name] = merged
+			case resolve != nil:
+				unmerged = append(unmerged, newConflict(manifest, name, nil, file))
+			case strings.HasSuffix(name, ".go"):
+				conflicts = append(conflicts, name)
+				delete(writes, name)
+			default:
+				report.Kept = append(report.Kept, name)
+				delete(writes, name)
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		components := strings.Join(with.Names(), ",")
+		err := fmt.Errorf("%s diverged too far from the generated code to add the %s component safely; run go-project-gen update --components %s --plan to see the changes, then update --components %s to write the other files and merge the changes into these by hand",
+			strings.Join(conflicts, ", "), component, components, components)
+		if manifest.GeneratorVersion != ToolVersion() {
+			err = fmt.Errorf("%w (the project was generated by go-project-gen %s, whose files this version can't reproduce to merge from)", err, manifest.GeneratorVersion)
+		}
+		return nil, err
+	}
+
+	// The resolutions are recorded in the manifest, written with the files
+	for _, c := range unmerged {
+		resolution, err := resolve(c)
+		if err != nil {
+			return nil, err
+		}
+		content, err := c.resolve(manifest, resolution)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case content == nil:
+			report.Kept = append(report.Kept, c.Path)
+			delete(writes, c.Path)
+			continue
+		case resolution == ResolveMarkers:
+			report.Marked = append(report.Marked, c.Path)
+		default:
+			report.Overwritten = append(report.Overwritten, c.Path)
+		}
+		contents[c.Path] = content
+	}
+
+	names := make([]string, 0, len(writes))
+	for name := range writes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path, file := filepath.Join(projectDir, name), writes[name]
+		content, ok := contents[name]
+		if !ok {
+			content = file.content
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+		if err := os.WriteFile(path, content, file.perm); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if err := os.Chmod(path, file.perm); err != nil {
+			return nil, fmt.Errorf("failed to set the mode of %s: %w", name, err)
+		}
+		// Merged files get the checksum of the generated content, so they stay flagged as edited
+		manifest.Files[filepath.ToSlash(name)] = checksum(file.content)
+	}
+
+	// Pin the requirements of the component the way a new project gets them,
+	// and record the files as written before go mod tidy, which can fail; the
+	// manifest then still tells them from edits when tidy is run again by hand
+	g := NewGenerator(log, &config.Config{OutputDir: cfg.OutputDir, ProjectConfig: target, NoHeaders: cfg.NoHeaders, Offline: cfg.Offline})
+	goModFile := filepath.Join(projectDir, "go.mod")
+	goMod, err := os.ReadFile(goModFile)
+	if err != nil {
+		return report, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	goModGenerated := manifest.Matches("go.mod", goMod)
+	pinned, err := pinRequirements(g, goModFile, goMod, goModGenerated)
+	if err != nil {
+		return report, err
+	}
+	if !bytes.Equal(pinned, goMod) {
+		if err := os.WriteFile(goModFile, pinned, 0644); err != nil {
+			return report, fmt.Errorf("failed to write go.mod: %w", err)
+		}
+		report.Updated = append(report.Updated, "go.mod")
+	}
+	if goModGenerated {
+		manifest.Files["go.mod"] = checksum(pinned)
+	}
+	if err := manifest.write(projectDir, target); err != nil {
+		return report, err
+	}
+
+	// go mod tidy adds the indirect requirements of the component; a go.mod
+	// that was unedited stays tracked as generated
+	if err := g.runGoModTidy(projectDir); err != nil {
+		return report, err
+	}
+	if target.Vendor {
+		if err := g.runGoModVendor(projectDir); err != nil {
+			return report, err
+		}
+	}
+	if goModGenerated {
+		if goMod, err = os.ReadFile(goModFile); err == nil {
+			manifest.Files["go.mod"] = checksum(goMod)
+			if err := manifest.write(projectDir, target); err != nil {
+				return report, err
+			}
+		}
+	}
+	return report, nil
+}
+
+// mergeEdited merges the changes between the file as generated before and
+// after into the edited file on disk. The earlier render is only a valid base
+// when it matches the manifest, i.e. the file was generated by the same
+// templates.
+func mergeEdited(manifest *Manifest, name string, before, after *plannedFile) ([]byte, bool) {
+	if before == nil || !manifest.Matches(name, before.content) {
+		return nil, false
+	}
+	return mergeLines(before.content, after.previous, after.content)
+}
+
+// pinRequirements returns go.mod with the requirements of the project
+// generated by g, so the component gets the versions a new project does rather
+// than the latest ones go mod tidy would pick. An unedited go.mod is replaced
+// with the one Generate writes; an edited one keeps the edits and gets the
+// missing requirements and the raised versions.
+func pinRequirements(g *Generator, path string, current []byte, generated bool) ([]byte, error) {
+	content, err := g.goModContent()
+	if err != nil {
+		return nil, err
+	}
+	rendered := []byte(content)
+	if generated {
+		return g.generatedContent(path, rendered), nil
+	}
+	return mergeRequirements(path, current, rendered)
+}
+
+// mergeRequirements adds the requirements of the rendered go.mod missing from
+// current and raises the lower versions, like the minimal version selection
+// of go; the other lines of current are kept as they are
+func mergeRequirements(path string, current, rendered []byte) ([]byte, error) {
+	file, err := modfile.Parse(path, current, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	want, err := modfile.Parse(path, rendered, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the generated go.mod: %w", err)
+	}
+
+	have := map[string]string{}
+	for _, require := range file.Require {
+		have[require.Mod.Path] = require.Mod.Version
+	}
+	for _, require := range want.Require {
+		version, ok := have[require.Mod.Path]
+		switch {
+		case !ok:
+			file.AddNewRequire(require.Mod.Path, require.Mod.Version, require.Indirect)
+		case semver.Compare(version, require.Mod.Version) < 0:
+			if err := file.AddRequire(require.Mod.Path, require.Mod.Version); err != nil {
+				return nil, fmt.Errorf("failed to require %s: %w", require.Mod, err)
+			}
+		}
+	}
+	file.Cleanup()
+
+	content, err := file.Format()
+	if err != nil {
+		return nil, fmt.Errorf("failed to format go.mod: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,249 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+func TestMergeRequirements(t *testing.T) {
+	current := `module example.com/demo
+
+go 1.23
+
+// pinned by hand
+require (
+	github.com/joho/godotenv v1.5.1
+	go.uber.org/zap v1.27.0
+)
+`
+	rendered := `module example.com/demo
+
+go 1.23
+
+require (
+	github.com/joho/godotenv v1.5.1
+	github.com/redis/go-redis/v9 v9.7.3
+	go.uber.org/zap v1.26.0
+	golang.org/x/sync v0.7.0
+)
+
+require github.com/cespare/xxhash/v2 v2.2.0 // indirect
+`
+
+	merged, err := mergeRequirements("go.mod", []byte(current), []byte(rendered))
+	if err != nil {
+		t.Fatalf("mergeRequirements() error = %v", err)
+	}
+	got := string(merged)
+
+	for _, want := range []string{
+		"// pinned by hand",
+		"github.com/redis/go-redis/v9 v9.7.3",
+		"golang.org/x/sync v0.7.0",
+		"github.com/cespare/xxhash/v2 v2.2.0 // indirect",
+		// A version raised by hand is kept
+		"go.uber.org/zap v1.27.0",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("merged go.mod lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "go.uber.org/zap v1.26.0") {
+		t.Errorf("merged go.mod lowered go.uber.org/zap:\n%s", got)
+	}
+}
+
+func TestMergeRequirementsRaisesVersions(t *testing.T) {
+	current := "module example.com/demo\n\ngo 1.23\n\nrequire golang.org/x/sync v0.6.0\n"
+	rendered := "module example.com/demo\n\ngo 1.23\n\nrequire golang.org/x/sync v0.7.0\n"
+
+	merged, err := mergeRequirements("go.mod", []byte(current), []byte(rendered))
+	if err != nil {
+		t.Fatalf("mergeRequirements() error = %v", err)
+	}
+	if got := string(merged); !strings.Contains(got, "golang.org/x/sync v0.7.0") || strings.Contains(got, "v0.6.0") {
+		t.Errorf("merged go.mod = %s, want golang.org/x/sync raised to v0.7.0", got)
+	}
+}
+
+func TestMergeRequirementsInvalidGoMod(t *testing.T) {
+	if _, err := mergeRequirements("go.mod", []byte("module\nrequire ("), []byte("module example.com/demo\n")); err == nil {
+		t.Error("mergeRequirements() error = nil, want an error for an unparsable go.mod")
+	}
+}
+
+// addComponent adds a component to the project in dir with the configuration
+// recorded in its manifest, offline
+func addComponent(t *testing.T, dir, component string, resolve ConflictResolver) (*AdditionReport, error) {
+	t.Helper()
+
+	m, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	cfg, err := config.ParseUpdateArgs([]string{"--offline", "--no-doctor"}, m.Project)
+	if err != nil {
+		t.Fatalf("ParseUpdateArgs() error = %v", err)
+	}
+	cfg.OutputDir = filepath.Dir(dir)
+	return AddComponent(logger.NewLogger(), cfg, component, resolve)
+}
+
+// editConflicting generates a project with the http component and rewrites
+// README.md and .env.example, which adding redis changes, so that the changes
+// can't be merged into them
+func editConflicting(t *testing.T) (dir string, edited map[string]string) {
+	t.Helper()
+
+	dir = generateProject(t, "--components", "http")
+	edited = map[string]string{
+		"README.md":    "# Our service\n",
+		".env.example": "PORT=9000\n",
+	}
+	for name, content := range edited {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir, edited
+}
+
+func TestAddComponentResolutions(t *testing.T) {
+	tests := []struct {
+		name       string
+		resolution Resolution
+		// wantKept is set when the edited files are left alone
+		wantKept bool
+	}{
+		// --accept-all overwrites every conflict
+		{name: "accept all", resolution: ResolveOverwrite},
+		// --keep-all keeps them, recording the decision
+		{name: "keep all", resolution: ResolveKeep, wantKept: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, edited := editConflicting(t)
+
+			var asked []string
+			report, err := addComponent(t, dir, "redis", func(c *Conflict) (Resolution, error) {
+				asked = append(asked, filepath.ToSlash(c.Path))
+				return tt.resolution, nil
+			})
+			if err != nil {
+				t.Fatalf("AddComponent() error = %v", err)
+			}
+			if got := strings.Join(asked, " "); got != ".env.example README.md" {
+				t.Errorf("asked for %s, want .env.example README.md", got)
+			}
+
+			resolved, unresolved := report.Overwritten, report.Kept
+			if tt.wantKept {
+				resolved, unresolved = report.Kept, report.Overwritten
+			}
+			if len(resolved) != len(edited) || len(unresolved) != 0 {
+				t.Errorf("Overwritten, Kept = %v, %v, want the edited files in one of them", report.Overwritten, report.Kept)
+			}
+
+			m, err := LoadManifest(dir)
+			if err != nil {
+				t.Fatalf("LoadManifest() error = %v", err)
+			}
+			for name, content := range edited {
+				data, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if kept := string(data) == content; kept != tt.wantKept {
+					t.Errorf("%s = %q, kept = %t, want %t", name, data, kept, tt.wantKept)
+				}
+				// A kept file is recorded as resolved; an overwritten one is generated again
+				if _, ok := m.Resolved[name]; ok != tt.wantKept {
+					t.Errorf("%s recorded as resolved = %t, want %t", name, ok, tt.wantKept)
+				}
+				if matches := m.Matches(name, data); matches == tt.wantKept {
+					t.Errorf("%s matches the manifest = %t, want %t", name, matches, !tt.wantKept)
+				}
+			}
+		})
+	}
+}
+
+func TestAddComponentWithoutResolver(t *testing.T) {
+	dir, edited := editConflicting(t)
+
+	// Without a resolver, edited files other than Go sources are kept unrecorded
+	report, err := addComponent(t, dir, "redis", nil)
+	if err != nil {
+		t.Fatalf("AddComponent() error = %v", err)
+	}
+	if got := strings.Join(report.Kept, " "); got != ".env.example README.md" {
+		t.Errorf("Kept = %s, want .env.example README.md", got)
+	}
+	m, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	for name := range edited {
+		if _, ok := m.Resolved[name]; ok {
+			t.Errorf("%s is recorded as resolved", name)
+		}
+	}
+}
+
+func TestAddComponentKeepsRecordedResolutions(t *testing.T) {
+	dir, edited := editConflicting(t)
+
+	asked := 0
+	resolve := KeepResolved(func(c *Conflict) (Resolution, error) {
+		asked++
+		return ResolveKeep, nil
+	})
+	if _, err := addComponent(t, dir, "redis", resolve); err != nil {
+		t.Fatalf("AddComponent() error = %v", err)
+	}
+	if asked != len(edited) {
+		t.Fatalf("asked %d times, want %d", asked, len(edited))
+	}
+
+	// Adding the component again after removing it generates the same content,
+	// so the recorded decisions apply without asking
+	m, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	cfg, err := config.ParseUpdateArgs([]string{"--offline", "--no-doctor"}, m.Project)
+	if err != nil {
+		t.Fatalf("ParseUpdateArgs() error = %v", err)
+	}
+	cfg.OutputDir = filepath.Dir(dir)
+	if _, err := RemoveComponent(logger.NewLogger(), cfg, "redis"); err != nil {
+		t.Fatalf("RemoveComponent() error = %v", err)
+	}
+
+	asked = 0
+	report, err := addComponent(t, dir, "redis", resolve)
+	if err != nil {
+		t.Fatalf("AddComponent() error = %v", err)
+	}
+	if asked != 0 {
+		t.Errorf("asked %d times again for the resolved files", asked)
+	}
+	if got := strings.Join(report.Kept, " "); got != ".env.example README.md" {
+		t.Errorf("Kept = %s, want .env.example README.md", got)
+	}
+	for name, content := range edited {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Errorf("%s = %q, want the edited content", name, data)
+		}
+	}
+}
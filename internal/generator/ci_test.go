@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// ciPipelines are the pipeline files of each CI provider
+var ciPipelines = map[string]string{
+	config.CIProviderGitHub:    ".github/workflows/main.yml",
+	config.CIProviderGitLab:    ".gitlab-ci.yml",
+	config.CIProviderBitbucket: "bitbucket-pipelines.yml",
+	config.CIProviderGitea:     ".gitea/workflows/main.yml",
+}
+
+// readProjectFile returns the content of a file of the project in dir
+func readProjectFile(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return string(content)
+}
+
+func TestCIPipelineGolden(t *testing.T) {
+	for provider, pipeline := range ciPipelines {
+		t.Run(provider, func(t *testing.T) {
+			projectDir := generateProject(t, "--components", "http,postgres,docker,cicd", "--ci-provider", provider, "--no-headers")
+
+			assertGolden(t, filepath.Join("testdata", "ci", provider+".golden"), readProjectFile(t, projectDir, pipeline))
+
+			// Only the pipeline of the selected provider is generated
+			for other, otherPipeline := range ciPipelines {
+				if other == provider {
+					continue
+				}
+				if _, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(otherPipeline))); err == nil {
+					t.Errorf("the %s pipeline %s was generated for %s", other, otherPipeline, provider)
+				}
+			}
+		})
+	}
+}
+
+// registryHosts are the hosts of the registries that need one
+var registryHosts = map[string]string{
+	config.RegistryECR:    "123456789012.dkr.ecr.us-east-1.amazonaws.com",
+	config.RegistryGAR:    "us-central1-docker.pkg.dev/acme-project/images",
+	config.RegistryCustom: "registry.example.com",
+}
+
+func TestRegistryPipelineGolden(t *testing.T) {
+	for _, registry := range config.Registries {
+		for provider, pipeline := range ciPipelines {
+			t.Run(registry+"/"+provider, func(t *testing.T) {
+				args := []string{"--components", "http,docker,cicd", "--ci-provider", provider, "--registry", registry, "--no-headers"}
+				if host, ok := registryHosts[registry]; ok {
+					args = append(args, "--registry-host", host)
+				}
+				projectDir := generateProject(t, args...)
+
+				golden := filepath.Join("testdata", "ci", "registries", registry+"-"+provider+".golden")
+				assertGolden(t, golden, readProjectFile(t, projectDir, pipeline))
+			})
+		}
+	}
+}
+
+// branchFiles tells the files naming the default branch: the pipelines, the
+// release configuration and the documentation
+func branchFiles(name string) bool {
+	switch name {
+	case "README.md", "CONTRIBUTING.md", ".gitlab-ci.yml", "bitbucket-pipelines.yml", ".releaserc.yml", "release-please-config.json":
+		return true
+	}
+	return strings.HasPrefix(name, ".github/workflows/") || strings.HasPrefix(name, ".gitea/workflows/")
+}
+
+// notBranches removes the uses of main that are not branches, such as main.go
+var notBranches = strings.NewReplacer("main.yml", "", "main.go", "", "package main", "", "release-please/main/", "")
+
+// mainBranchPattern matches main as a word
+var mainBranchPattern = regexp.MustCompile(`\bmain\b`)
+
+func TestDefaultBranch(t *testing.T) {
+	for provider := range ciPipelines {
+		t.Run(provider, func(t *testing.T) {
+			args := []string{"--preset", "full", "--ci-provider", provider, "--conventional-commits", "--release", "--no-headers"}
+
+			// The default branch is named in every provider's files, so a
+			// hard-coded main would show up below
+			if refs := mainBranchReferences(t, generateProject(t, args...)); len(refs) == 0 {
+				t.Fatal("no reference to the default branch main found, the check below would miss them")
+			}
+
+			projectDir := generateProject(t, append(args, "--default-branch=master")...)
+			for _, ref := range mainBranchReferences(t, projectDir) {
+				t.Errorf("reference to main with --default-branch=master: %s", ref)
+			}
+			for _, name := range []string{"README.md", "CONTRIBUTING.md"} {
+				if !strings.Contains(readProjectFile(t, projectDir, name), "`master`") {
+					t.Errorf("%s doesn't name the default branch master", name)
+				}
+			}
+		})
+	}
+}
+
+// mainBranchReferences returns the lines of the files naming the default
+// branch that refer to a main branch, as file:line: text
+func mainBranchReferences(t *testing.T, projectDir string) []string {
+	t.Helper()
+
+	var refs []string
+	for _, name := range projectFiles(t, projectDir) {
+		if !branchFiles(name) {
+			continue
+		}
+		for i, line := range strings.Split(readProjectFile(t, projectDir, name), "\n") {
+			if mainBranchPattern.MatchString(notBranches.Replace(line)) {
+				refs = append(refs, fmt.Sprintf("%s:%d: %s", name, i+1, strings.TrimSpace(line)))
+			}
+		}
+	}
+	return refs
+}
@@ -0,0 +1,273 @@
+// internal/generator/conflicts.go - Resolution of the files edited since they were generated
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// Resolution is how a file edited since it was generated is reconciled with
+// its regenerated content
+type Resolution string
+
+const (
+	// ResolveKeep leaves the edited file alone
+	ResolveKeep Resolution = "keep"
+	// ResolveOverwrite replaces the edited file with the generated content
+	ResolveOverwrite Resolution = "overwrite"
+	// ResolveMerge applies the changes of the templates to the edited file;
+	// it is only offered when the three-way merge succeeds
+	ResolveMerge Resolution = "merge"
+	// ResolveMarkers writes the lines that differ from both versions between
+	// conflict markers, to be resolved by hand
+	ResolveMarkers Resolution = "markers"
+)
+
+// Conflict markers around the lines of the edited and the generated content
+const (
+	markerEdited    = "<<<<<<< yours"
+	markerSeparator = "======="
+	markerGenerated = ">>>>>>> generated"
+)
+
+// Conflict is a file edited since it was generated whose regenerated content differs
+type Conflict struct {
+	// Path is relative to the project directory
+	Path string
+	// Edited is the content on disk, Generated the regenerated content
+	Edited, Generated []byte
+	// Merged is the edited content with the changes of the templates applied,
+	// from the file as last generated; nil when that file can't be reproduced
+	// or the changes touch lines that were edited
+	Merged []byte
+	// Resolved is set when an earlier run resolved the conflict with the same
+	// generated content, so the file can be kept without asking again
+	Resolved bool
+
+	perm os.FileMode
+}
+
+// ConflictResolver chooses the resolution of a conflict
+type ConflictResolver func(*Conflict) (Resolution, error)
+
+// KeepResolved returns a resolver keeping the conflicts an earlier run resolved
+// for the same generated content, and asking ask for the others
+func KeepResolved(ask ConflictResolver) ConflictResolver {
+	return func(c *Conflict) (Resolution, error) {
+		if c.Resolved {
+			return ResolveKeep, nil
+		}
+		return ask(c)
+	}
+}
+
+// ConflictReport lists the resolved conflicts; paths are relative to the
+// project directory
+type ConflictReport struct {
+	// Overwritten are the edited files replaced with the generated content
+	Overwritten []string
+	// Merged are the edited files the changes of the templates were merged into
+	Merged []string
+	// Marked are the files written with conflict markers
+	Marked []string
+	// Kept are the edited files left alone
+	Kept []string
+}
+
+// Diff renders a unified diff from the edited to the generated content
+func (c *Conflict) Diff() string {
+	name := filepath.ToSlash(c.Path)
+	return unifiedDiff("yours/"+name, "generated/"+name, c.Edited, c.Generated)
+}
+
+// Markers returns the edited content with every run of differing lines
+// replaced by both versions between conflict markers
+func (c *Conflict) Markers() []byte {
+	var b strings.Builder
+	lines := diffLines(splitLines(c.Edited), splitLines(c.Generated))
+	for i := 0; i < len(lines); {
+		if lines[i].op == ' ' {
+			b.WriteString(lines[i].text + "\n")
+			i++
+			continue
+		}
+
+		var edited, generated []string
+		for ; i < len(lines) && lines[i].op != ' '; i++ {
+			if lines[i].op == '-' {
+				edited = append(edited, lines[i].text)
+			} else {
+				generated = append(generated, lines[i].text)
+			}
+		}
+		b.WriteString(markerEdited + "\n")
+		for _, line := range edited {
+			b.WriteString(line + "\n")
+		}
+		b.WriteString(markerSeparator + "\n")
+		for _, line := range generated {
+			b.WriteString(line + "\n")
+		}
+		b.WriteString(markerGenerated + "\n")
+	}
+	return []byte(b.String())
+}
+
+// newConflict returns the conflict of an edited file with its regenerated
+// content; base is the file as rendered from the configuration it was last
+// generated with, nil when there is none
+func newConflict(m *Manifest, name string, base, file *plannedFile) *Conflict {
+	c := &Conflict{Path: name, Edited: file.previous, Generated: file.content, perm: file.perm}
+	if merged, ok := mergeEdited(m, name, base, file); ok {
+		c.Merged = merged
+	}
+	sum, ok := m.Resolved[filepath.ToSlash(name)]
+	c.Resolved = ok && sum == checksum(file.content)
+	return c
+}
+
+// resolve records the resolution of the conflict in the manifest and returns
+// the content to write, nil to leave the file alone. Files written with the
+// changes of the templates get the checksum of the generated content, so they
+// stay flagged as edited; the others keep the checksum they were generated
+// with, so later runs can still merge into them.
+func (c *Conflict) resolve(m *Manifest, resolution Resolution) ([]byte, error) {
+	name, sum := filepath.ToSlash(c.Path), checksum(c.Generated)
+
+	var content []byte
+	switch resolution {
+	case ResolveKeep:
+		m.Resolved[name] = sum
+		return nil, nil
+	case ResolveOverwrite:
+		delete(m.Resolved, name)
+		m.Files[name] = sum
+		return c.Generated, nil
+	case ResolveMerge:
+		if c.Merged == nil {
+			return nil, fmt.Errorf("the changes to %s cannot be merged", c.Path)
+		}
+		content = c.Merged
+	case ResolveMarkers:
+		content = c.Markers()
+	default:
+		return nil, fmt.Errorf("unknown resolution %q for %s", resolution, c.Path)
+	}
+	m.Resolved[name] = sum
+	m.Files[name] = sum
+	return content, nil
+}
+
+// record adds the path of a resolved conflict to the list of its resolution
+func (r *ConflictReport) record(resolution Resolution, path string) {
+	switch resolution {
+	case ResolveOverwrite:
+		r.Overwritten = append(r.Overwritten, path)
+	case ResolveMerge:
+		r.Merged = append(r.Merged, path)
+	case ResolveMarkers:
+		r.Marked = append(r.Marked, path)
+	default:
+		r.Kept = append(r.Kept, path)
+	}
+}
+
+// Conflicts returns the edited files the applied plan left alone, in path
+// order, with the three-way merge of the changes of the templates when the
+// configuration recorded before the run reproduces the files as they were
+// last generated. Workspaces are updated one service at a time, so they have
+// none.
+func (g *Generator) Conflicts() ([]*Conflict, error) {
+	if g.plan == nil || g.recordOnly() || g.config.Workspace != nil {
+		return nil, nil
+	}
+	skipped := g.plan.Skipped()
+	if len(skipped) == 0 {
+		return nil, nil
+	}
+
+	projectDir := filepath.Join(g.config.OutputDir, g.config.ProjectConfig.ProjectName)
+	m, err := LoadManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// The base of the merges; without it, the conflicts can still be kept,
+	// overwritten or marked
+	var base *PlanWriter
+	if g.recorded != nil {
+		if base, err = g.renderRecorded(); err != nil {
+			g.log.Warn("Failed to render the files as last generated, the changes cannot be merged", "error", err)
+			base = nil
+		}
+	}
+
+	conflicts := make([]*Conflict, 0, len(skipped))
+	for _, rel := range skipped {
+		name, err := filepath.Rel(projectDir, filepath.Join(g.plan.root, rel))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", rel, err)
+		}
+		var before *plannedFile
+		if base != nil {
+			before = base.files[rel]
+		}
+		conflicts = append(conflicts, newConflict(m, name, before, g.plan.files[rel]))
+	}
+	return conflicts, nil
+}
+
+// renderRecorded renders the project from the configuration recorded before
+// the run, with the defaults the update command fills in
+func (g *Generator) renderRecorded() (*PlanWriter, error) {
+	recorded, err := config.ParseUpdateArgs(nil, g.recorded)
+	if err != nil {
+		return nil, err
+	}
+	return renderPlan(g.log, g.config, recorded.ProjectConfig)
+}
+
+// ResolveConflicts resolves the conflicts with resolve and records the
+// resolutions in the manifest
+func (g *Generator) ResolveConflicts(conflicts []*Conflict, resolve ConflictResolver) (*ConflictReport, error) {
+	if g.recordOnly() {
+		return nil, fmt.Errorf("conflicts are only resolved when applying the plan")
+	}
+
+	projectDir := filepath.Join(g.config.OutputDir, g.config.ProjectConfig.ProjectName)
+	m, err := LoadManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ConflictReport{}
+	for _, c := range conflicts {
+		resolution, err := resolve(c)
+		if err != nil {
+			return report, err
+		}
+		content, err := c.resolve(m, resolution)
+		if err != nil {
+			return report, err
+		}
+		if content != nil {
+			if err := os.WriteFile(filepath.Join(projectDir, c.Path), content, c.perm); err != nil {
+				return report, fmt.Errorf("failed to write %s: %w", c.Path, err)
+			}
+		}
+		report.record(resolution, c.Path)
+	}
+
+	data, err := m.Marshal()
+	if err != nil {
+		return report, err
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, ManifestFile), data, 0644); err != nil {
+		return report, fmt.Errorf("failed to write %s: %w", ManifestFile, err)
+	}
+	return report, nil
+}
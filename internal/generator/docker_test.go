@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDockerfileCopiesExistingFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "http only", args: []string{"--components", "http,docker"}},
+		{name: "api preset", args: []string{"--preset", "api"}},
+		{name: "full preset", args: []string{"--preset", "full"}},
+		{name: "sqlite", args: []string{"--components", "grpc,sqlite,docker"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := generateProject(t, tt.args...)
+
+			dockerfile, err := os.ReadFile(filepath.Join(projectDir, "Dockerfile"))
+			if err != nil {
+				t.Fatalf("failed to read the Dockerfile: %v", err)
+			}
+
+			copies := 0
+			for _, line := range strings.Split(string(dockerfile), "\n") {
+				fields := strings.Fields(line)
+				if len(fields) < 3 || fields[0] != "COPY" {
+					continue
+				}
+				copies++
+
+				sources := fields[1 : len(fields)-1]
+				fromBuilder := strings.HasPrefix(sources[0], "--from=")
+				if fromBuilder {
+					sources = sources[1:]
+				}
+				for _, source := range sources {
+					if fromBuilder {
+						// The builder copies the project to /app and builds the binaries into /app/bin
+						if strings.HasPrefix(source, "/app/bin/") {
+							continue
+						}
+						source = strings.TrimPrefix(source, "/app/")
+					}
+					// go mod tidy writes go.sum, and offline generation skips it
+					if source == "." || source == "go.sum" {
+						continue
+					}
+					if _, err := os.Stat(filepath.Join(projectDir, source)); err != nil {
+						t.Errorf("%q copies %s, which the project doesn't have", line, source)
+					}
+				}
+			}
+			if copies == 0 {
+				t.Fatal("the Dockerfile copies nothing from the project")
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+// internal/generator/errors.go - Structured errors for template rendering
+package generator
+
+import "github.com/neor-it/go-project-gen/internal/generator/templates"
+
+// TemplateError describes a template that failed to parse or execute, or
+// rendered Go code that failed to format
+type TemplateError = templates.TemplateError
+
+// newTemplateError builds a TemplateError with the offending line and surrounding source;
+// for a format error, source is the rendered code
+func newTemplateError(name, path, phase, source string, err error) *TemplateError {
+	return templates.NewTemplateError(name, path, phase, source, err)
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+func TestWriteTemplateFileErrors(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: t.TempDir(),
+		DryRun:    true,
+		ProjectConfig: config.ProjectConfig{
+			ProjectName: "demo",
+			ModuleName:  "github.com/acme/demo",
+		},
+	}
+	path := filepath.Join(cfg.OutputDir, "demo", "internal", "app", "app.go")
+
+	tests := []struct {
+		name        string
+		content     string
+		wantPhase   string
+		wantLine    int
+		wantSnippet string
+	}{
+		{
+			name:        "unclosed action",
+			content:     "package app\n\nimport \"{{ .ModuleName }/internal/config\"\n",
+			wantPhase:   "parse",
+			wantLine:    3,
+			wantSnippet: `>    3 | import "{{ .ModuleName }/internal/config"`,
+		},
+		{
+			name:        "unknown placeholder",
+			content:     "package app\n\n// {{ .ProjectName }} runs on {{ .Port }}\n",
+			wantPhase:   "execute",
+			wantLine:    3,
+			wantSnippet: ">    3 | // {{ .ProjectName }} runs on {{ .Port }}",
+		},
+		{
+			name:        "rendered code that doesn't format",
+			content:     "package app\n\nfunc Start() {\n\tname := {{ .ProjectName }}:\n}\n",
+			wantPhase:   "format",
+			wantLine:    4,
+			wantSnippet: ">    4 | \tname := demo:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewGenerator(logger.NewLogger(), cfg).writeTemplateFile(path, tt.content)
+
+			var tmplErr *TemplateError
+			if !errors.As(err, &tmplErr) {
+				t.Fatalf("writeTemplateFile() error = %v, want a *TemplateError", err)
+			}
+			if tmplErr.Template != "internal/app/app.go" || tmplErr.Path != path {
+				t.Errorf("Template, Path = %q, %q, want internal/app/app.go, %s", tmplErr.Template, tmplErr.Path, path)
+			}
+			if tmplErr.Phase != tt.wantPhase {
+				t.Errorf("Phase = %q, want %q", tmplErr.Phase, tt.wantPhase)
+			}
+			if tmplErr.Line != tt.wantLine {
+				t.Errorf("Line = %d, want %d", tmplErr.Line, tt.wantLine)
+			}
+			if !strings.Contains(tmplErr.Snippet, tt.wantSnippet) {
+				t.Errorf("Snippet = %q, want it to mark %q", tmplErr.Snippet, tt.wantSnippet)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// internal/generator/format.go - Formatting of the generated Go files
+package generator
+
+import (
+	"golang.org/x/tools/imports"
+)
+
+// formatOptions sort and group the imports like goimports without adding or
+// removing any, which would need the dependencies of the project
+var formatOptions = &imports.Options{
+	Comments:   true,
+	TabIndent:  true,
+	TabWidth:   8,
+	FormatOnly: true,
+}
+
+// formatGo formats Go source like gofmt and organizes its imports like
+// goimports, so templates assembling code from conditional parts don't have to
+// get every blank line and import group right
+func formatGo(name, path string, content []byte) ([]byte, error) {
+	formatted, err := imports.Process(path, content, formatOptions)
+	if err != nil {
+		return nil, newTemplateError(name, path, "format", string(content), err)
+	}
+	return formatted, nil
+}
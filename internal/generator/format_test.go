@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"bytes"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatGo(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "indentation and spacing",
+			content: "package app\n\nfunc add(a,b int) int {\n  return a+b\n}\n",
+			want:    "package app\n\nfunc add(a, b int) int {\n\treturn a + b\n}\n",
+		},
+		{
+			name:    "blank lines left by conditional imports",
+			content: "package app\n\nimport (\n\t\"os\"\n\t\"context\"\n\n\n\t\"github.com/acme/demo/internal/config\"\n\n\n)\n\nvar _ = os.Args\nvar _ context.Context\nvar _ config.Config\n",
+			want:    "package app\n\nimport (\n\t\"context\"\n\t\"os\"\n\n\t\"github.com/acme/demo/internal/config\"\n)\n\nvar _ = os.Args\nvar _ context.Context\nvar _ config.Config\n",
+		},
+		{
+			name:    "already formatted",
+			content: "package app\n\n// Name is the name of the service\nconst Name = \"demo\"\n",
+			want:    "package app\n\n// Name is the name of the service\nconst Name = \"demo\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatGo("internal/app/app.go", "demo/internal/app/app.go", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("formatGo() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("formatGo() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneratedGoFilesAreFormatted(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "every component", args: []string{"--preset", "full"}},
+		{name: "minimal", args: []string{"--preset", "minimal"}},
+		{name: "echo with mysql", args: []string{"--components", "http,mysql,redis,auth,metrics,tracing", "--http-framework", "echo", "--token-format", "paseto-public"}},
+		{name: "net/http with sqlite", args: []string{"--components", "http,sqlite,redis,metrics,tracing", "--http-framework", "stdlib", "--tls", "--coalescing-example"}},
+		{name: "chi with sqlc", args: []string{"--components", "http,postgres,metrics,tracing,auth", "--http-framework", "chi", "--data-layer", "sqlc"}},
+		{name: "grpc with named databases", args: []string{"--components", "grpc,postgres,tracing", "--databases", "main,analytics", "--postgres-driver", "pgx"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := generateProject(t, tt.args...)
+
+			files := 0
+			err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") {
+					return err
+				}
+				files++
+
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				formatted, err := format.Source(content)
+				if err != nil {
+					t.Errorf("%s doesn't parse: %v", path, err)
+					return nil
+				}
+				if !bytes.Equal(formatted, content) {
+					t.Errorf("%s isn't gofmt-clean", path)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to walk %s: %v", projectDir, err)
+			}
+			if files == 0 {
+				t.Fatal("the project has no Go files")
+			}
+		})
+	}
+}
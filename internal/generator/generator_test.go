@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+// generateProject generates the project demo into a temporary directory with
+// the given command line flags, offline and without the environment checks,
+// and returns the project directory
+func generateProject(t *testing.T, args ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	cfg, err := config.ParseArgs(append([]string{
+		"--project", "demo",
+		"--username", "acme",
+		"--output", dir,
+		"--offline",
+		"--no-doctor",
+	}, args...))
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if err := NewGenerator(logger.NewLogger(), cfg).Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	return filepath.Join(dir, "demo")
+}
+
+func TestGeneratedProjectBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds generated projects")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "every component", args: []string{"--preset", "full"}},
+		{name: "postgres with pgx", args: []string{"--components", "http,postgres,auth,tracing", "--postgres-driver", "pgx", "--http-framework", "chi"}},
+		{name: "mysql", args: []string{"--components", "http,mysql,redis,metrics", "--http-framework", "echo"}},
+		{name: "sqlite", args: []string{"--components", "grpc,sqlite", "--databases", "main,analytics"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := generateProject(t, tt.args...)
+
+			// go vet builds every package, tests included, against the module cache
+			cmd := exec.Command("go", "vet", "./...")
+			cmd.Dir = projectDir
+			cmd.Env = append(os.Environ(), "GOPROXY=off", "GOFLAGS=-mod=mod", "GOTOOLCHAIN=local")
+			output, err := cmd.CombinedOutput()
+			if strings.Contains(string(output), "module lookup disabled") {
+				t.Skip("the dependencies of the project are not in the module cache")
+			}
+			if err != nil {
+				t.Fatalf("go vet failed: %v\n%s", err, output)
+			}
+		})
+	}
+}
+
+func TestGeneratedProjectLints(t *testing.T) {
+	if testing.Short() {
+		t.Skip("lints generated projects")
+	}
+	if _, err := exec.LookPath("golangci-lint"); err != nil {
+		t.Skip("golangci-lint is not installed")
+	}
+
+	for _, preset := range config.PresetNames() {
+		t.Run(preset, func(t *testing.T) {
+			projectDir := generateProject(t, "--preset", preset)
+
+			// The project is linted with the configuration it was generated with
+			cmd := exec.Command("golangci-lint", "run", "--config", ".golangci.yml", "./...")
+			cmd.Dir = projectDir
+			cmd.Env = append(os.Environ(), "GOPROXY=off", "GOFLAGS=-mod=mod", "GOTOOLCHAIN=local")
+			output, err := cmd.CombinedOutput()
+			if strings.Contains(string(output), "module lookup disabled") {
+				t.Skip("the dependencies of the project are not in the module cache")
+			}
+			if err != nil {
+				t.Fatalf("golangci-lint failed: %v\n%s", err, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// internal/generator/header.go - Ownership headers marking scaffold-managed files
+package generator
+
+import (
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"strings"
+)
+
+// Version is the generator version written to the file headers,
+// set at build time with -ldflags "-X github.com/neor-it/go-project-gen/internal/generator.Version=v1.2.3"
+var Version = "dev"
+
+// ToolVersion returns Version, or the module version when installed with go install ...@version
+func ToolVersion() string {
+	if Version != "dev" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return Version
+}
+
+// commentStyle returns the comment delimiters for a file, based on its name.
+// Files without comment syntax (JSON, go.sum) get no header, and neither does
+// CHANGELOG.md, which the release tools rewrite from the top. The sqlc query
+// files and output get none either: sqlc documents the first query with the
+// comment at the top of its file, and sqlc generate rewrites its output.
+func commentStyle(path string) (start, end string, ok bool) {
+	if dir := filepath.ToSlash(filepath.Dir(path)); strings.HasSuffix(dir, "internal/db/queries") || strings.HasSuffix(dir, "internal/db/sqlc") {
+		return "", "", false
+	}
+
+	base := filepath.Base(path)
+	switch base {
+	case "CHANGELOG.md":
+		return "", "", false
+	case "go.mod", "go.work":
+		return "// ", "", true
+	case "Makefile", "Dockerfile", ".gitignore", ".dockerignore", ".env", ".env.example":
+		return "# ", "", true
+	}
+
+	switch filepath.Ext(base) {
+	case ".go", ".proto":
+		return "// ", "", true
+	case ".sql":
+		return "-- ", "", true
+	case ".sh", ".ps1", ".yml", ".yaml", ".toml":
+		return "# ", "", true
+	case ".md":
+		return "<!-- ", " -->", true
+	}
+	return "", "", false
+}
+
+// withHeader prepends the ownership header to the content of a generated file;
+// the header goes after a shebang line so scripts stay executable
+func withHeader(path, templateName string, content []byte) []byte {
+	start, end, ok := commentStyle(path)
+	if !ok {
+		return content
+	}
+
+	header := start + "Code generated by go-project-gen " + ToolVersion() + " from template " + templateName +
+		"; edits will be preserved but flagged by `go-project-gen update --plan`" + end + "\n\n"
+
+	var shebang string
+	if text := string(content); strings.HasPrefix(text, "#!") {
+		line, rest, _ := strings.Cut(text, "\n")
+		shebang, content = line+"\n", []byte(rest)
+	}
+
+	return append([]byte(shebang+header), content...)
+}
+
+// headerPattern matches the ownership header at the start of a file, after an
+// optional shebang line, capturing the name of the template
+var headerPattern = regexp.MustCompile(`^(?:#![^\n]*\n)?(?://|#|--|<!--) Code generated by go-project-gen \S+ from template (\S+);`)
+
+// headerTemplate returns the name of the template in the ownership header of
+// content, or "" when it has none
+func headerTemplate(content []byte) string {
+	match := headerPattern.FindSubmatch(content)
+	if match == nil {
+		return ""
+	}
+	return string(match[1])
+}
+
+// hasOwnHeader reports whether the file at path starts with the ownership
+// header of the template it is generated from; template names are relative
+// to the project directory, so they are matched against the end of the path
+func hasOwnHeader(path string, content []byte) bool {
+	name := headerTemplate(content)
+	if name == "" {
+		return false
+	}
+	slashPath := filepath.ToSlash(path)
+	return slashPath == name || strings.HasSuffix(slashPath, "/"+name)
+}
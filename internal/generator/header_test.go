@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHeaderTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    string
+	}{
+		{name: "go file", path: "internal/api/server.go", content: "package api\n", want: "internal/api/server.go"},
+		{name: "shell script keeps its shebang", path: "scripts/migrate.sh", content: "#!/bin/sh\necho ok\n", want: "scripts/migrate.sh"},
+		{name: "sql", path: "migrations/000001_init.up.sql", content: "SELECT 1;\n", want: "migrations/000001_init.up.sql"},
+		{name: "markdown", path: "README.md", content: "# demo\n", want: "README.md"},
+		{name: "Makefile", path: "Makefile", content: "build:\n", want: "Makefile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := withHeader(tt.path, tt.path, []byte(tt.content))
+			if got := headerTemplate(content); got != tt.want {
+				t.Errorf("headerTemplate() = %q, want %q\n%s", got, tt.want, content)
+			}
+		})
+	}
+}
+
+func TestHeaderTemplateWithoutHeader(t *testing.T) {
+	for _, content := range []string{
+		"",
+		"package main\n",
+		"// Code generated by protoc-gen-go. DO NOT EDIT.\n",
+		"package main\n\n// Code generated by go-project-gen dev from template main.go; edits will be preserved\n",
+	} {
+		if got := headerTemplate([]byte(content)); got != "" {
+			t.Errorf("headerTemplate(%q) = %q, want none", content, got)
+		}
+	}
+}
+
+func TestHasOwnHeader(t *testing.T) {
+	content := withHeader("internal/api/server.go", "internal/api/server.go", []byte("package api\n"))
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "relative path", path: "internal/api/server.go", want: true},
+		{name: "under the project directory", path: filepath.Join("out", "demo", "internal", "api", "server.go"), want: true},
+		{name: "another file", path: filepath.Join("out", "demo", "internal", "api", "routes.go"), want: false},
+		{name: "same suffix", path: filepath.Join("out", "demo", "internal", "xapi", "server.go"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasOwnHeader(tt.path, content); got != tt.want {
+				t.Errorf("hasOwnHeader(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanEditedWithoutManifest(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "demo", "internal", "api", "server.go")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    bool
+	}{
+		{name: "header of its template", content: withHeader(path, "internal/api/server.go", []byte("package api\n")), want: false},
+		{name: "header of another template", content: withHeader(path, "internal/api/routes.go", []byte("package api\n")), want: true},
+		{name: "no header", content: []byte("package api\n"), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edited, err := NewPlanWriter(root, false).edited(path, tt.content)
+			if err != nil {
+				t.Fatalf("edited() error = %v", err)
+			}
+			if edited != tt.want {
+				t.Errorf("edited() = %v, want %v", edited, tt.want)
+			}
+		})
+	}
+}
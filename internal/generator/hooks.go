@@ -0,0 +1,133 @@
+// internal/generator/hooks.go - Running the post-generation hooks
+package generator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+// HookError reports a post-generation hook that failed
+type HookError struct {
+	// Hook is the command as configured
+	Hook string
+	// ExitCode is the exit code of the command, or -1 when it could not be started
+	ExitCode int
+	Err      error
+}
+
+// Error implements the error interface
+func (e *HookError) Error() string {
+	if e.ExitCode < 0 {
+		return fmt.Sprintf("post-generation hook %q failed to start: %v", e.Hook, e.Err)
+	}
+	return fmt.Sprintf("post-generation hook %q failed with exit code %d", e.Hook, e.ExitCode)
+}
+
+// Unwrap returns the underlying error
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
+
+// runPostHooks runs the post-generation hooks in order in dir, the directory of the
+// project or workspace named name with module path module, stopping at the first
+// failing one
+func (g *Generator) runPostHooks(dir, name, module string) error {
+	hooks := g.config.PostHooks
+	if len(hooks) == 0 {
+		return nil
+	}
+	if g.recordOnly() {
+		g.log.Info("Dry run, skipping the post-generation hooks", "hooks", len(hooks))
+		return nil
+	}
+
+	outputDir, err := filepath.Abs(g.config.OutputDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the output directory: %w", err)
+	}
+	env := map[string]string{
+		"PROJECT_NAME": name,
+		"MODULE_NAME":  module,
+		"OUTPUT_DIR":   outputDir,
+	}
+
+	for _, hook := range hooks {
+		if err := g.runPostHook(dir, hook, env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runPostHook runs a single hook, streaming its output through the logger
+func (g *Generator) runPostHook(dir, hook string, env map[string]string) error {
+	args, err := config.ParseHook(hook)
+	if err != nil {
+		return err
+	}
+	for i, arg := range args {
+		args[i] = config.ExpandHookEnv(arg, env)
+	}
+
+	g.log.Info("Running post-generation hook", "hook", hook)
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	for _, name := range config.HookEnv {
+		cmd.Env = append(cmd.Env, name+"="+env[name])
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return &HookError{Hook: hook, ExitCode: -1, Err: err}
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return &HookError{Hook: hook, ExitCode: -1, Err: err}
+	}
+	if err := cmd.Start(); err != nil {
+		return &HookError{Hook: hook, ExitCode: -1, Err: err}
+	}
+
+	// Wait closes the pipes, so the output is read to the end before calling it
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go logLines(&wg, g.log, stdout, hook, "stdout")
+	go logLines(&wg, g.log, stderr, hook, "stderr")
+	wg.Wait()
+
+	if err := cmd.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return &HookError{Hook: hook, ExitCode: exitErr.ExitCode(), Err: err}
+		}
+		return &HookError{Hook: hook, ExitCode: -1, Err: err}
+	}
+
+	g.log.Info("Post-generation hook succeeded", "hook", hook)
+	return nil
+}
+
+// logLines logs every line read from r as the output of hook on stream
+func logLines(wg *sync.WaitGroup, log logger.Logger, r io.Reader, hook, stream string) {
+	defer wg.Done()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		log.Info(scanner.Text(), "hook", hook, "stream", stream)
+	}
+
+	// Keep draining a line too long for the scanner, so the hook doesn't block on a full pipe
+	_, _ = io.Copy(io.Discard, r)
+}
@@ -0,0 +1,161 @@
+// internal/generator/manifest.go - Checksums of the generated files, used to detect user edits
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is written to the project root and records the configuration
+// the project was generated with and the checksum of every generated file as
+// it was written
+const ManifestFile = ".goprojectgen.yaml"
+
+// manifestHeader explains the manifest to readers of the project
+const manifestHeader = "# " + ManifestFile + " - Configuration and checksums of the files written by go-project-gen;\n" +
+	"# update and --plan --apply only overwrite files that still match them. Commit it, but don't edit it by hand.\n\n"
+
+// Manifest describes a generated project
+type Manifest struct {
+	// GeneratorVersion is the version of go-project-gen that last wrote the project
+	GeneratorVersion string `yaml:"generatorVersion,omitempty"`
+	// Project is the configuration the project was last generated with; nil in
+	// manifests written before it was recorded
+	Project *config.ProjectFile `yaml:"project,omitempty"`
+	// Files maps the slash-separated path of each generated file, relative to
+	// the manifest, to the SHA-256 of its content
+	Files map[string]string `yaml:"files"`
+	// Resolved maps the paths of edited files whose conflict with the generated
+	// content was resolved to the SHA-256 of that content, so later runs
+	// generating the same content don't ask again
+	Resolved map[string]string `yaml:"resolved,omitempty"`
+}
+
+// LoadManifest reads the manifest of the project in dir; a project generated
+// without one gets an empty manifest
+func LoadManifest(dir string) (*Manifest, error) {
+	m := &Manifest{Files: map[string]string{}, Resolved: map[string]string{}}
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+	if err := yaml.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("invalid %s in %s: %w", ManifestFile, dir, err)
+	}
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	if m.Resolved == nil {
+		m.Resolved = map[string]string{}
+	}
+	return m, nil
+}
+
+// Matches reports whether content is the content recorded for the file; files
+// missing from the manifest never match, so they are treated as edited
+func (m *Manifest) Matches(path string, content []byte) bool {
+	sum, ok := m.Files[filepath.ToSlash(path)]
+	return ok && sum == checksum(content)
+}
+
+// Marshal renders the manifest; the encoder sorts the paths, so the file
+// only changes where the generated files did
+func (m *Manifest) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", ManifestFile, err)
+	}
+	return append([]byte(manifestHeader), data...), nil
+}
+
+// write records projectCfg as the configuration of the project and writes the
+// manifest to dir
+func (m *Manifest) write(dir string, projectCfg config.ProjectConfig) error {
+	recorded := config.NewProjectFile(projectCfg)
+	m.GeneratorVersion, m.Project = ToolVersion(), &recorded
+	data, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ManifestFile, err)
+	}
+	return nil
+}
+
+// checksum returns the hex-encoded SHA-256 of content
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// saveManifest records the configuration and the checksums of the files
+// generated into dir. Entries of files this run didn't write are kept: files
+// the user edited keep the checksum they were generated with, so they stay
+// flagged as edited.
+func (g *Generator) saveManifest(dir string) error {
+	if g.recordOnly() {
+		return nil
+	}
+
+	m, err := LoadManifest(dir)
+	if err != nil {
+		return err
+	}
+	g.recorded = m.Project
+	m.GeneratorVersion, m.Project = ToolVersion(), g.projectFile()
+	for path := range g.generated {
+		if g.plan != nil && g.plan.IsSkipped(path) {
+			continue
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+
+		// Read the file back, go mod tidy may have rewritten go.mod since
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		m.Files[filepath.ToSlash(rel)] = checksum(content)
+	}
+
+	data, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+
+	// The manifest is bookkeeping rather than a planned file, so it bypasses the plan
+	writer := g.writer
+	if g.plan != nil {
+		writer = g.plan.disk
+	}
+	if err := writer.WriteFile(filepath.Join(dir, ManifestFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ManifestFile, err)
+	}
+	return nil
+}
+
+// projectFile returns the configuration recorded in the manifest, with the
+// services of a workspace
+func (g *Generator) projectFile() *config.ProjectFile {
+	file := config.NewProjectFile(g.config.ProjectConfig)
+	if g.config.Workspace != nil {
+		for _, service := range g.config.Workspace.Services {
+			file.Services = append(file.Services, config.NewProjectFile(service))
+		}
+	}
+	return &file
+}
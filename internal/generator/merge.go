@@ -0,0 +1,102 @@
+// internal/generator/merge.go - Three-way merge of regenerated files into files the user edited
+package generator
+
+import (
+	"sort"
+	"strings"
+)
+
+// lineEdit replaces the lines [start, end) of a text with lines
+type lineEdit struct {
+	start, end int
+	lines      []string
+}
+
+// mergeLines applies the changes from base to generated onto edited, the
+// base as the user changed it. It fails when a change touches or borders
+// lines the user changed too, since the result could no longer be trusted.
+func mergeLines(base, edited, generated []byte) ([]byte, bool) {
+	baseLines, editedLines := splitLines(base), splitLines(edited)
+
+	// at[i] is the line of edited holding the line i of base, -1 when the user changed it
+	at := make([]int, len(baseLines))
+	b, e := 0, 0
+	for _, line := range diffLines(baseLines, editedLines) {
+		switch line.op {
+		case ' ':
+			at[b] = e
+			b++
+			e++
+		case '-':
+			at[b] = -1
+			b++
+		case '+':
+			e++
+		}
+	}
+
+	// kept reports whether the base lines from to to are unchanged in edited,
+	// with nothing inserted between them
+	kept := func(from, to int) bool {
+		for line := from; line <= to; line++ {
+			if at[line] < 0 || at[line]-at[from] != line-from {
+				return false
+			}
+		}
+		return true
+	}
+
+	var edits []lineEdit
+	lines := diffLines(baseLines, splitLines(generated))
+	for k, i := 0, 0; k < len(lines); {
+		if lines[k].op == ' ' {
+			k++
+			i++
+			continue
+		}
+
+		// A hunk replaces the base lines [i, end) with the added lines
+		end, added := i, []string{}
+		for ; k < len(lines) && lines[k].op != ' '; k++ {
+			if lines[k].op == '-' {
+				end++
+			} else {
+				added = append(added, lines[k].text)
+			}
+		}
+
+		// The hunk and the lines around it must be untouched in edited
+		from, to := max(i-1, 0), min(end, len(baseLines)-1)
+		switch {
+		case len(baseLines) == 0:
+			if len(editedLines) > 0 {
+				return nil, false
+			}
+		case !kept(from, to):
+			return nil, false
+		case i == 0 && at[0] != 0:
+			return nil, false
+		case end == len(baseLines) && at[len(baseLines)-1] != len(editedLines)-1:
+			return nil, false
+		}
+
+		start := len(editedLines)
+		if i < len(baseLines) {
+			start = at[i]
+		}
+		edits = append(edits, lineEdit{start: start, end: start + end - i, lines: added})
+		i = end
+	}
+
+	// Apply the edits from the last one, so the line numbers of the others hold
+	sort.Slice(edits, func(a, b int) bool { return edits[a].start > edits[b].start })
+	merged := editedLines
+	for _, edit := range edits {
+		merged = append(merged[:edit.start:edit.start], append(edit.lines, merged[edit.end:]...)...)
+	}
+
+	if len(merged) == 0 {
+		return nil, true
+	}
+	return []byte(strings.Join(merged, "\n") + "\n"), true
+}
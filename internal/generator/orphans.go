@@ -0,0 +1,104 @@
+// internal/generator/orphans.go - Files recorded in the manifest that the generator no longer produces
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Orphan is a file recorded in the manifest that a run didn't generate, such
+// as the file of a template renamed by a newer version of go-project-gen or a
+// file of a component that was left out
+type Orphan struct {
+	// Path is relative to the project directory
+	Path string
+	// Edited is set when the file no longer matches the manifest; pruning keeps it
+	Edited bool
+}
+
+// Orphans returns the files of the manifest of the project that this run
+// didn't generate and that are still on disk, in path order. It is only
+// meaningful once Generate has run in plan mode; workspaces are updated one
+// service at a time, so they have none.
+func (g *Generator) Orphans() ([]Orphan, error) {
+	if g.plan == nil || g.config.Workspace != nil {
+		return nil, nil
+	}
+
+	projectDir := filepath.Join(g.config.OutputDir, g.config.ProjectConfig.ProjectName)
+	m, err := LoadManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []Orphan
+	for name := range m.Files {
+		// A manifest edited by hand must not point the deletion outside the project
+		rel := filepath.FromSlash(name)
+		if !filepath.IsLocal(rel) {
+			continue
+		}
+		path := filepath.Join(projectDir, rel)
+		if g.generated[path] {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		orphans = append(orphans, Orphan{Path: rel, Edited: !m.Matches(name, content)})
+	}
+
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].Path < orphans[j].Path })
+	return orphans, nil
+}
+
+// PruneOrphans deletes the orphans that are unchanged since they were
+// generated, with the directories they leave empty, and drops them from the
+// manifest; edited orphans are kept and stay flagged as edited. It returns the
+// deleted paths.
+func (g *Generator) PruneOrphans(orphans []Orphan) ([]string, error) {
+	if g.recordOnly() {
+		return nil, fmt.Errorf("files are only deleted when applying the plan")
+	}
+
+	projectDir := filepath.Join(g.config.OutputDir, g.config.ProjectConfig.ProjectName)
+	m, err := LoadManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, orphan := range orphans {
+		if orphan.Edited {
+			continue
+		}
+		path := filepath.Join(projectDir, orphan.Path)
+		if err := os.Remove(path); err != nil {
+			return deleted, fmt.Errorf("failed to delete %s: %w", orphan.Path, err)
+		}
+		removeEmptyDirs(filepath.Dir(path), projectDir)
+		delete(m.Files, filepath.ToSlash(orphan.Path))
+		delete(m.Resolved, filepath.ToSlash(orphan.Path))
+		deleted = append(deleted, orphan.Path)
+	}
+	if len(deleted) == 0 {
+		return nil, nil
+	}
+
+	data, err := m.Marshal()
+	if err != nil {
+		return deleted, err
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, ManifestFile), data, 0644); err != nil {
+		return deleted, fmt.Errorf("failed to write %s: %w", ManifestFile, err)
+	}
+	return deleted, nil
+}
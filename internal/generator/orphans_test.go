@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+// writeOldFile writes content to name in the project in dir and records it in
+// the manifest, as an older generator producing the file would have
+func writeOldFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+
+	m, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	m.Files[name] = checksum([]byte(content))
+	data, err := m.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), data, 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", ManifestFile, err)
+	}
+}
+
+// regenerate runs the generator over the project in dir in plan mode,
+// applying the plan when apply is set
+func regenerate(t *testing.T, dir string, apply bool, args ...string) *Generator {
+	t.Helper()
+
+	args = append([]string{
+		"--project", "demo",
+		"--username", "acme",
+		"--output", filepath.Dir(dir),
+		"--offline",
+		"--no-doctor",
+		"--plan",
+	}, args...)
+	if apply {
+		args = append(args, "--apply")
+	}
+	cfg, err := config.ParseArgs(args)
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	gen := NewGenerator(logger.NewLogger(), cfg)
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	return gen
+}
+
+func TestPruneOrphansAfterTemplateRename(t *testing.T) {
+	args := []string{"--components", "http", "--http-framework", "gin"}
+	dir := generateProject(t, args...)
+
+	// The previous generator version wrote the health handler to healthcheck.go,
+	// and two packages it no longer generates, one of which the user has since edited
+	const oldHealth = "package handlers\n\n// Health reports whether the service is up\nfunc Health() {}\n"
+	writeOldFile(t, dir, "internal/api/handlers/healthcheck.go", oldHealth)
+	writeOldFile(t, dir, "internal/version/version.go", "package version\n")
+	writeOldFile(t, dir, "internal/legacy/legacy.go", "package legacy\n")
+	if err := os.WriteFile(filepath.Join(dir, "internal", "legacy", "legacy.go"), []byte("package legacy\n\n// Kept is used by the service\nconst Kept = true\n"), 0644); err != nil {
+		t.Fatalf("failed to edit legacy.go: %v", err)
+	}
+
+	wantOrphans := []Orphan{
+		{Path: filepath.Join("internal", "api", "handlers", "healthcheck.go")},
+		{Path: filepath.Join("internal", "legacy", "legacy.go"), Edited: true},
+		{Path: filepath.Join("internal", "version", "version.go")},
+	}
+
+	// The plan lists the orphans without deleting anything
+	plan := regenerate(t, dir, false, args...)
+	orphans, err := plan.Orphans()
+	if err != nil {
+		t.Fatalf("Orphans() error = %v", err)
+	}
+	if !reflect.DeepEqual(orphans, wantOrphans) {
+		t.Errorf("Orphans() = %+v, want %+v", orphans, wantOrphans)
+	}
+	if _, err := plan.PruneOrphans(orphans); err == nil {
+		t.Error("PruneOrphans() deleted files without --apply")
+	}
+	if _, err := os.Stat(filepath.Join(dir, wantOrphans[0].Path)); err != nil {
+		t.Errorf("the plan deleted healthcheck.go: %v", err)
+	}
+
+	// Applying the plan deletes the unedited orphan and keeps the edited one
+	apply := regenerate(t, dir, true, args...)
+	orphans, err = apply.Orphans()
+	if err != nil {
+		t.Fatalf("Orphans() error = %v", err)
+	}
+	if !reflect.DeepEqual(orphans, wantOrphans) {
+		t.Fatalf("Orphans() = %+v, want %+v", orphans, wantOrphans)
+	}
+	deleted, err := apply.PruneOrphans(orphans)
+	if err != nil {
+		t.Fatalf("PruneOrphans() error = %v", err)
+	}
+	if want := []string{wantOrphans[0].Path, wantOrphans[2].Path}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("PruneOrphans() = %v, want %v", deleted, want)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, wantOrphans[0].Path)); !os.IsNotExist(err) {
+		t.Errorf("healthcheck.go is still on disk: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, wantOrphans[1].Path)); err != nil {
+		t.Errorf("the edited legacy.go was deleted: %v", err)
+	}
+	// The directory left empty goes, the one with the generated handlers stays
+	if _, err := os.Stat(filepath.Join(dir, "internal", "version")); !os.IsNotExist(err) {
+		t.Errorf("the empty version directory is still on disk: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "internal", "api", "handlers", "health.go")); err != nil {
+		t.Errorf("the generated health.go was deleted: %v", err)
+	}
+
+	m, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if _, ok := m.Files["internal/api/handlers/healthcheck.go"]; ok {
+		t.Error("the manifest still records healthcheck.go")
+	}
+	if _, ok := m.Files["internal/legacy/legacy.go"]; !ok {
+		t.Error("the manifest no longer records the edited legacy.go")
+	}
+
+	// A later run finds only the edited orphan
+	orphans, err = regenerate(t, dir, false, args...).Orphans()
+	if err != nil {
+		t.Fatalf("Orphans() error = %v", err)
+	}
+	if want := wantOrphans[1:2]; !reflect.DeepEqual(orphans, want) {
+		t.Errorf("Orphans() after pruning = %+v, want %+v", orphans, want)
+	}
+}
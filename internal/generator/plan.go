@@ -0,0 +1,381 @@
+// internal/generator/plan.go - Plan mode, comparing the generated files with the files on disk
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FileStatus is how a generated file compares with the file on disk
+type FileStatus string
+
+const (
+	// FileNew is a file that doesn't exist yet
+	FileNew FileStatus = "new"
+	// FileChanged is a file whose content on disk differs from the generated one
+	FileChanged FileStatus = "changed"
+	// FileUnchanged is a file whose content on disk is the generated one
+	FileUnchanged FileStatus = "unchanged"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// plannedFile is a generated file and the file it would replace
+type plannedFile struct {
+	status FileStatus
+	// exists and previous describe the file on disk before the run
+	exists   bool
+	previous []byte
+	content  []byte
+	perm     os.FileMode
+	// edited is set for changed files that no longer match the manifest,
+	// which --apply leaves alone
+	edited bool
+}
+
+// PlanWriter renders the generated files into memory and compares them with
+// the files on disk. With apply set it also writes the new files and the
+// changed files that are unchanged since they were generated. It is safe for
+// concurrent use.
+type PlanWriter struct {
+	mu    sync.Mutex
+	root  string
+	apply bool
+	disk  FileWriter
+	files map[string]*plannedFile
+	// manifests caches the manifest of each directory, nil when there is none
+	manifests map[string]*Manifest
+}
+
+// NewPlanWriter creates a plan writer for paths under root
+func NewPlanWriter(root string, apply bool) *PlanWriter {
+	return &PlanWriter{
+		root:      root,
+		apply:     apply,
+		disk:      osWriter{},
+		files:     map[string]*plannedFile{},
+		manifests: map[string]*Manifest{},
+	}
+}
+
+// MkdirAll creates the directory when applying the plan
+func (w *PlanWriter) MkdirAll(path string, perm os.FileMode) error {
+	if !w.apply {
+		return nil
+	}
+	return w.disk.MkdirAll(path, perm)
+}
+
+// WriteFile compares data with the file on disk, and writes it when applying
+// the plan unless the file was edited since it was generated
+func (w *PlanWriter) WriteFile(path string, data []byte, perm os.FileMode) error {
+	rel, err := w.rel(path)
+	if err != nil {
+		return err
+	}
+	write, err := w.record(path, rel, data, perm)
+	if err != nil || !write {
+		return err
+	}
+	// The lock only guards the bookkeeping, so the workers write in parallel
+	return w.disk.WriteFile(path, data, perm)
+}
+
+// record records data as the new content of the file at path and reports
+// whether applying the plan writes it
+func (w *PlanWriter) record(path, rel string, data []byte, perm os.FileMode) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// A file written twice is compared with the content it had before the run
+	file, ok := w.files[rel]
+	if !ok {
+		file = &plannedFile{}
+		previous, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return false, fmt.Errorf("failed to read %s: %w", path, err)
+		default:
+			file.exists, file.previous = true, previous
+			edited, err := w.edited(path, previous)
+			if err != nil {
+				return false, err
+			}
+			file.edited = edited
+		}
+		w.files[rel] = file
+	}
+
+	file.content, file.perm = data, perm
+	switch {
+	case !file.exists:
+		file.status = FileNew
+	case bytes.Equal(file.previous, data):
+		file.status = FileUnchanged
+	default:
+		file.status = FileChanged
+	}
+
+	return w.apply && file.status != FileUnchanged && !w.skipped(file), nil
+}
+
+// Chmod records the mode of a planned file, and changes it when the plan wrote the file
+func (w *PlanWriter) Chmod(path string, mode os.FileMode) error {
+	rel, err := w.rel(path)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	file := w.files[rel]
+	if file != nil {
+		file.perm = mode
+	}
+	written := w.apply && file != nil && file.status != FileUnchanged && !w.skipped(file)
+	w.mu.Unlock()
+
+	if !written {
+		return nil
+	}
+	return w.disk.Chmod(path, mode)
+}
+
+// Skipped returns the changed files that were edited since they were
+// generated, relative to the root, which applying the plan doesn't overwrite
+func (w *PlanWriter) Skipped() []string {
+	return w.paths(func(file *plannedFile) bool {
+		return w.skipped(file)
+	})
+}
+
+// IsSkipped reports whether applying the plan left the file at path alone
+func (w *PlanWriter) IsSkipped(path string) bool {
+	rel, err := w.rel(path)
+	if err != nil {
+		return false
+	}
+	file := w.files[rel]
+	return file != nil && w.skipped(file)
+}
+
+// Diff renders a unified diff of every new and changed file, relative to the root
+func (w *PlanWriter) Diff() string {
+	var b strings.Builder
+	for _, rel := range w.paths(func(file *plannedFile) bool { return file.status != FileUnchanged }) {
+		file := w.files[rel]
+		from := "a/" + filepath.ToSlash(rel)
+		if file.status == FileNew {
+			from = "/dev/null"
+		}
+		b.WriteString(unifiedDiff(from, "b/"+filepath.ToSlash(rel), file.previous, file.content))
+	}
+	return b.String()
+}
+
+// Summary counts the files by status; the plan never deletes files, see
+// Generator.Orphans for the files it no longer produces
+func (w *PlanWriter) Summary() string {
+	counts := map[FileStatus]int{}
+	for _, file := range w.files {
+		counts[file.status]++
+	}
+	return fmt.Sprintf("%d new, %d changed, %d unchanged\n", counts[FileNew], counts[FileChanged], counts[FileUnchanged])
+}
+
+// skipped reports whether applying the plan leaves a changed file alone
+func (w *PlanWriter) skipped(file *plannedFile) bool {
+	return file.status == FileChanged && file.edited
+}
+
+// paths returns the sorted paths of the files matching keep
+func (w *PlanWriter) paths(keep func(*plannedFile) bool) []string {
+	var paths []string
+	for rel, file := range w.files {
+		if keep(file) {
+			paths = append(paths, rel)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// edited reports whether the content on disk of the file at path differs from
+// the checksum in the nearest manifest. Without a manifest, only a file
+// starting with the ownership header of its template counts as unedited,
+// since nothing else tells it from a file the user wrote
+func (w *PlanWriter) edited(path string, content []byte) (bool, error) {
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		m, err := w.manifest(dir)
+		if err != nil {
+			return false, err
+		}
+		if m != nil {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return false, fmt.Errorf("failed to resolve %s: %w", path, err)
+			}
+			return !m.Matches(rel, content), nil
+		}
+		if dir == w.root || dir == filepath.Dir(dir) {
+			return !hasOwnHeader(path, content), nil
+		}
+	}
+}
+
+// manifest returns the manifest in dir, or nil when there is none
+func (w *PlanWriter) manifest(dir string) (*Manifest, error) {
+	if m, ok := w.manifests[dir]; ok {
+		return m, nil
+	}
+
+	var m *Manifest
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+		if m, err = LoadManifest(dir); err != nil {
+			return nil, err
+		}
+	}
+	w.manifests[dir] = m
+	return m, nil
+}
+
+// rel returns path relative to the root, rejecting paths outside of it
+func (w *PlanWriter) rel(path string) (string, error) {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside of %s", path, w.root)
+	}
+	return rel, nil
+}
+
+// diffLine is a line of a unified diff: ' ' kept, '-' removed or '+' added
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// unifiedDiff renders the differences between two texts as a unified diff
+// with diffContext lines of context, or "" when they are equal
+func unifiedDiff(fromName, toName string, from, to []byte) string {
+	lines := diffLines(splitLines(from), splitLines(to))
+
+	// Line numbers in the old and new text before each diff line
+	fromLine, toLine := make([]int, len(lines)+1), make([]int, len(lines)+1)
+	for i, line := range lines {
+		fromLine[i+1], toLine[i+1] = fromLine[i], toLine[i]
+		if line.op != '+' {
+			fromLine[i+1]++
+		}
+		if line.op != '-' {
+			toLine[i+1]++
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		if lines[i].op == ' ' {
+			i++
+			continue
+		}
+
+		// Extend the hunk while the next change is close enough to share context
+		last := i
+		for j := i + 1; j < len(lines) && j-last <= 2*diffContext; j++ {
+			if lines[j].op != ' ' {
+				last = j
+			}
+		}
+		start, end := max(i-diffContext, 0), min(last+diffContext+1, len(lines))
+
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n",
+			hunkRange(fromLine[start], fromLine[end]-fromLine[start]),
+			hunkRange(toLine[start], toLine[end]-toLine[start]))
+		for _, line := range lines[start:end] {
+			b.WriteByte(line.op)
+			b.WriteString(line.text)
+			b.WriteByte('\n')
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// hunkRange formats the start and length of a hunk; an empty range starts
+// at the line before it
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// splitLines splits text into lines without their line endings
+func splitLines(text []byte) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(text), "\n"), "\n")
+}
+
+// diffLines returns the edit script turning a into b, from their longest
+// common subsequence; common leading and trailing lines are kept out of the
+// quadratic part, so small edits of large files stay cheap
+func diffLines(a, b []string) []diffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	x, y := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// common[i][j] is the length of the longest common subsequence of x[i:] and y[j:]
+	common := make([][]int, len(x)+1)
+	for i := range common {
+		common[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+	for _, text := range a[:prefix] {
+		lines = append(lines, diffLine{' ', text})
+	}
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			lines = append(lines, diffLine{' ', x[i]})
+			i++
+			j++
+		case j < len(y) && (i == len(x) || common[i][j+1] > common[i+1][j]):
+			lines = append(lines, diffLine{'+', y[j]})
+			j++
+		default:
+			lines = append(lines, diffLine{'-', x[i]})
+			i++
+		}
+	}
+	for _, text := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{' ', text})
+	}
+	return lines
+}
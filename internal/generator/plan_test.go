@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// blockingWriter holds the write of one file until another file was written
+type blockingWriter struct {
+	osWriter
+	blocked string
+	written chan struct{}
+}
+
+// WriteFile waits for a write of another file before writing the blocked one
+func (w *blockingWriter) WriteFile(path string, data []byte, perm os.FileMode) error {
+	if path != w.blocked {
+		defer close(w.written)
+		return w.osWriter.WriteFile(path, data, perm)
+	}
+	select {
+	case <-w.written:
+		return w.osWriter.WriteFile(path, data, perm)
+	case <-time.After(5 * time.Second):
+		return errors.New("another file couldn't be written meanwhile")
+	}
+}
+
+func TestPlanWriterWritesConcurrently(t *testing.T) {
+	dir := t.TempDir()
+	slow, fast := filepath.Join(dir, "slow.txt"), filepath.Join(dir, "fast.txt")
+
+	w := NewPlanWriter(dir, true)
+	w.disk = &blockingWriter{blocked: slow, written: make(chan struct{})}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- w.WriteFile(slow, []byte("slow\n"), 0644)
+	}()
+	// Let the slow write start first; the fast one must not wait for it
+	time.Sleep(10 * time.Millisecond)
+	if err := w.WriteFile(fast, []byte("fast\n"), 0644); err != nil {
+		t.Fatalf("WriteFile(%s) error = %v", fast, err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("WriteFile(%s) error = %v", slow, err)
+	}
+
+	if got := w.Summary(); got != "2 new, 0 changed, 0 unchanged\n" {
+		t.Errorf("Summary() = %q, want 2 new files", got)
+	}
+	for _, path := range []string{slow, fast} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s was not written: %v", path, err)
+		}
+	}
+}
+
+func TestPlanWriterStatuses(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"same.txt": "same\n", "old.txt": "old\n"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := NewPlanWriter(dir, false)
+	for name, content := range map[string]string{"same.txt": "same\n", "old.txt": "new\n", "new.txt": "new\n"} {
+		if err := w.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile(%s) error = %v", name, err)
+		}
+	}
+
+	if got := w.Summary(); got != "1 new, 1 changed, 1 unchanged\n" {
+		t.Errorf("Summary() = %q, want 1 new, 1 changed, 1 unchanged", got)
+	}
+	// Without a manifest, a file without the ownership header was written by the user
+	if got := w.Skipped(); len(got) != 1 || got[0] != "old.txt" {
+		t.Errorf("Skipped() = %v, want [old.txt]", got)
+	}
+	// Without --apply nothing is written
+	if _, err := os.Stat(filepath.Join(dir, "new.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("new.txt was written without --apply: %v", err)
+	}
+}
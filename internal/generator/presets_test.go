@@ -0,0 +1,195 @@
+package generator
+
+import (
+	"bufio"
+	"flag"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// presetGoldenFiles are the generated files compared in full with testdata/presets/<preset>
+var presetGoldenFiles = []string{"main.go", "internal/app/app.go"}
+
+// assertGolden compares got with the golden file at path, or rewrites it with -update
+func assertGolden(t *testing.T, path, got string) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s (run go test with -update to create it): %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from the generated output (run go test with -update if the change is intended)\ngot:\n%s", path, got)
+	}
+}
+
+// projectFiles lists the files of the project in dir, slash-separated and sorted
+func projectFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to list %s: %v", dir, err)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func TestPresetGoldenOutput(t *testing.T) {
+	for _, preset := range config.PresetNames() {
+		t.Run(preset, func(t *testing.T) {
+			projectDir := generateProject(t, "--preset", preset, "--no-headers")
+			golden := filepath.Join("testdata", "presets", preset)
+
+			assertGolden(t, filepath.Join(golden, "files.golden"), strings.Join(projectFiles(t, projectDir), "\n")+"\n")
+			for _, name := range presetGoldenFiles {
+				content, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(name)))
+				if err != nil {
+					t.Fatalf("failed to read %s: %v", name, err)
+				}
+				assertGolden(t, filepath.Join(golden, filepath.Base(name)+".golden"), string(content))
+			}
+		})
+	}
+}
+
+func TestMinimalPresetImports(t *testing.T) {
+	projectDir := generateProject(t, "--preset", config.PresetMinimal)
+
+	for _, name := range []string{"main.go", "internal/app/app.go"} {
+		content, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		for _, pkg := range []string{"/internal/api", "/internal/db", "/internal/grpc", "/internal/cache"} {
+			if strings.Contains(string(content), `"github.com/acme/demo`+pkg) {
+				t.Errorf("%s imports %s, which the minimal preset doesn't generate", name, pkg)
+			}
+		}
+	}
+
+	for _, path := range []string{"internal/api", "internal/db", "Dockerfile"} {
+		if _, err := os.Stat(filepath.Join(projectDir, path)); err == nil {
+			t.Errorf("the minimal preset generated %s", path)
+		}
+	}
+}
+
+func TestMinimalPresetStopsOnInterrupt(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs a generated project")
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupting a process isn't supported on windows")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+
+	projectDir := generateProject(t, "--preset", config.PresetMinimal)
+	binary := filepath.Join(t.TempDir(), "demo")
+
+	build := exec.Command("go", "build", "-o", binary, ".")
+	build.Dir = projectDir
+	build.Env = append(os.Environ(), "GOPROXY=off", "GOFLAGS=-mod=mod", "GOTOOLCHAIN=local")
+	if output, err := build.CombinedOutput(); err != nil {
+		if strings.Contains(string(output), "module lookup disabled") {
+			t.Skip("the dependencies of the project are not in the module cache")
+		}
+		t.Fatalf("go build failed: %v\n%s", err, output)
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create a pipe: %v", err)
+	}
+	defer reader.Close()
+
+	// The admin server is disabled so the test doesn't depend on a free port
+	cmd := exec.Command(binary)
+	cmd.Dir = projectDir
+	cmd.Env = append(os.Environ(), "DEBUG_INFO_ENABLED=false")
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start the project: %v", err)
+	}
+	writer.Close()
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	// Killing the project closes the pipe, so the output can be drained however the test ends
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		for range lines {
+		}
+	})
+
+	// waitFor reads the output until a line contains want
+	waitFor := func(want string) {
+		t.Helper()
+		timeout := time.After(30 * time.Second)
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					t.Fatalf("the project exited before logging %q", want)
+				}
+				if strings.Contains(line, want) {
+					return
+				}
+			case <-timeout:
+				_ = cmd.Process.Kill()
+				t.Fatalf("the project didn't log %q in time", want)
+			}
+		}
+	}
+
+	waitFor("Starting application")
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		t.Fatalf("failed to interrupt the project: %v", err)
+	}
+	waitFor("Service stopped")
+
+	if err := cmd.Wait(); err != nil {
+		t.Errorf("the project exited with %v, want a clean exit", err)
+	}
+}
@@ -0,0 +1,86 @@
+// internal/generator/progress.go - Progress display of a generation run
+package generator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// Phases of a generation run, in the order they run
+const (
+	phaseStructure  = "structure"
+	phaseFiles      = "files"
+	phaseComponents = "components"
+	phaseTidy       = "tidy"
+	phaseVerify     = "verify"
+)
+
+// phases lists the phases in display order
+var phases = []string{phaseStructure, phaseFiles, phaseComponents, phaseTidy, phaseVerify}
+
+// progress shows the current phase and the number of files written on a
+// writer. On a terminal the file count is redrawn in place; elsewhere, such as
+// in CI logs, only its final value is printed. A nil progress shows nothing.
+type progress struct {
+	mu       sync.Mutex
+	out      io.Writer
+	terminal bool
+}
+
+// newProgress creates a progress display writing to out; /dev/null is a
+// character device too, so the file mode alone doesn't tell a terminal
+func newProgress(out io.Writer) *progress {
+	file, ok := out.(*os.File)
+	return &progress{out: out, terminal: ok && term.IsTerminal(int(file.Fd()))}
+}
+
+// SetProgress shows the phases of the run and the files written on out
+func (g *Generator) SetProgress(out io.Writer) {
+	g.progress = newProgress(out)
+}
+
+// phase starts a phase
+func (p *progress) phase(name string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	step := 0
+	for i, phase := range phases {
+		if phase == name {
+			step = i + 1
+		}
+	}
+	fmt.Fprintf(p.out, "[%d/%d] %s\n", step, len(phases), name)
+}
+
+// files shows that done of total files of the phase are written
+func (p *progress) files(done, total int) {
+	if p == nil || !p.terminal {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fmt.Fprintf(p.out, "\r      %d/%d files written", done, total)
+}
+
+// filesDone ends the file count of the phase once its files are written
+func (p *progress) filesDone(done, total int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.terminal {
+		fmt.Fprint(p.out, "\r")
+	}
+	fmt.Fprintf(p.out, "      %d/%d files written\n", done, total)
+}
@@ -0,0 +1,286 @@
+// internal/generator/remove.go - Removal of a component from an existing project
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/generator/templates"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+// referenceSkipDirs are the directories of a project that are not searched
+// for references to a removed component
+var referenceSkipDirs = map[string]bool{
+	".git": true, "vendor": true, "tmp": true, "bin": true, "dist": true, "node_modules": true,
+}
+
+// RemovalReport lists the changes made by removing a component; paths are
+// relative to the project directory
+type RemovalReport struct {
+	// Deleted are the files of the component that were deleted
+	Deleted []string
+	// Kept are the files of the component left in place because they were edited
+	Kept []string
+	// Updated are the shared files regenerated without the component
+	Updated []string
+	// Edited are the shared files left alone because they were edited; the
+	// component still has to be taken out of them by hand
+	Edited []string
+	// References maps the remaining files that were not generated as they are
+	// now to the references to the component they contain
+	References map[string][]string
+}
+
+// RemoveComponent removes a component from the project generated with cfg.
+// Files only the component needs are deleted and the files it changed are
+// regenerated without it, unless they no longer match the manifest: edited
+// files are never deleted or overwritten, they are reported instead. go.mod
+// is updated by go mod tidy.
+func RemoveComponent(log logger.Logger, cfg *config.Config, component string) (*RemovalReport, error) {
+	if cfg.Workspace != nil {
+		return nil, fmt.Errorf("removing a component from a workspace service is not supported: remove it from the service in the config file and re-run with --plan")
+	}
+
+	projectDir := filepath.Join(cfg.OutputDir, cfg.ProjectConfig.ProjectName)
+	if _, err := os.Stat(filepath.Join(projectDir, ManifestFile)); err != nil {
+		return nil, fmt.Errorf("%s has no %s to tell generated files from edited ones, remove the component by hand: %w", projectDir, ManifestFile, err)
+	}
+	manifest, err := LoadManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	without, err := cfg.ProjectConfig.Components.Without(component)
+	if err != nil {
+		return nil, err
+	}
+	target := cfg.ProjectConfig
+	target.Components = without
+	if !without.HasDatabase() {
+		target.Databases = nil
+	}
+
+	// Render the project with and without the component to find what it adds
+	before, err := renderPlan(log, cfg, cfg.ProjectConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the project with the %s component: %w", component, err)
+	}
+	after, err := renderPlan(log, cfg, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the project without the %s component: %w", component, err)
+	}
+
+	report := &RemovalReport{References: map[string][]string{}}
+	for _, rel := range before.paths(func(file *plannedFile) bool { return file.exists }) {
+		if _, ok := after.files[rel]; ok {
+			continue
+		}
+		path := filepath.Join(before.root, rel)
+		name, err := filepath.Rel(projectDir, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+
+		if before.files[rel].edited {
+			report.Kept = append(report.Kept, name)
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to delete %s: %w", name, err)
+		}
+		removeEmptyDirs(filepath.Dir(path), projectDir)
+		delete(manifest.Files, filepath.ToSlash(name))
+		report.Deleted = append(report.Deleted, name)
+	}
+
+	for _, rel := range after.paths(func(file *plannedFile) bool { return file.status != FileUnchanged }) {
+		file := after.files[rel]
+		path := filepath.Join(after.root, rel)
+		name, err := filepath.Rel(projectDir, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+
+		if after.skipped(file) {
+			report.Edited = append(report.Edited, name)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+		if err := os.WriteFile(path, file.content, file.perm); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		manifest.Files[filepath.ToSlash(name)] = checksum(file.content)
+		report.Updated = append(report.Updated, name)
+	}
+
+	if err := findReferences(report, projectDir, after, componentReferences(cfg.ProjectConfig, component)); err != nil {
+		return nil, err
+	}
+
+	// Record the files as written before go mod tidy, which can fail on the
+	// references left behind; the manifest then still tells them from edits
+	// when tidy is run again by hand
+	if err := manifest.write(projectDir, target); err != nil {
+		return report, err
+	}
+
+	// go mod tidy drops the requirements only the component needed; a go.mod
+	// that was unedited stays tracked as generated
+	g := NewGenerator(log, &config.Config{OutputDir: cfg.OutputDir, ProjectConfig: target, NoHeaders: cfg.NoHeaders, Offline: cfg.Offline})
+	goModFile := filepath.Join(projectDir, "go.mod")
+	goMod, _ := os.ReadFile(goModFile)
+	goModGenerated := manifest.Matches("go.mod", goMod)
+	if err := g.runGoModTidy(projectDir); err != nil {
+		return report, fmt.Errorf("%w; remove the references to the %s component listed above and run go mod tidy again", err, component)
+	}
+	if target.Vendor {
+		if err := g.runGoModVendor(projectDir); err != nil {
+			return report, err
+		}
+	}
+	if goModGenerated {
+		if goMod, err = os.ReadFile(goModFile); err == nil {
+			manifest.Files["go.mod"] = checksum(goMod)
+			if err := manifest.write(projectDir, target); err != nil {
+				return report, err
+			}
+		}
+	}
+	return report, nil
+}
+
+// renderPlan renders the project generated with projectCfg into a plan,
+// without writing anything
+func renderPlan(log logger.Logger, cfg *config.Config, projectCfg config.ProjectConfig) (*PlanWriter, error) {
+	g := NewGenerator(log, &config.Config{
+		OutputDir:     cfg.OutputDir,
+		ProjectConfig: projectCfg,
+		Provided:      cfg.Provided,
+		Plan:          true,
+		SkipVerify:    true,
+		NoHeaders:     cfg.NoHeaders,
+		Offline:       cfg.Offline,
+	})
+	if err := g.Generate(); err != nil {
+		return nil, err
+	}
+	return g.plan, nil
+}
+
+// findReferences records the files of the project still referring to the
+// removed component; files identical to their regenerated content are skipped
+func findReferences(report *RemovalReport, projectDir string, after *PlanWriter, references []string) error {
+	if len(references) == 0 {
+		return nil
+	}
+
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != projectDir && referenceSkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == ManifestFile || d.Name() == "go.sum" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if rel, err := after.rel(path); err == nil {
+			if file, ok := after.files[rel]; ok && bytes.Equal(file.content, content) {
+				return nil
+			}
+		}
+
+		var found []string
+		for _, reference := range references {
+			if bytes.Contains(content, []byte(reference)) {
+				found = append(found, reference)
+			}
+		}
+		if len(found) > 0 {
+			name, _ := filepath.Rel(projectDir, path)
+			report.References[name] = found
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to search %s for references: %w", projectDir, err)
+	}
+	return nil
+}
+
+// componentReferences returns the strings that show code or configuration
+// still depends on a component: its packages, environment variables and
+// Docker Compose service
+func componentReferences(cfg config.ProjectConfig, component string) []string {
+	pkg := func(name string) string { return cfg.ModuleName + "/internal/" + name }
+
+	switch component {
+	case config.ComponentPostgres, config.ComponentMySQL, config.ComponentSQLite:
+		references := []string{pkg("db"), pkg("migrations")}
+		if cfg.HasNamedDatabases() {
+			for _, name := range cfg.Databases {
+				references = append(references, templates.DatabaseEnv(cfg, name, "CONNECTION_STRING"))
+			}
+		} else {
+			references = append(references, "DB_CONNECTION_STRING")
+		}
+		if component != config.ComponentSQLite {
+			references = append(references, component+":")
+		}
+		return references
+	case config.ComponentRedis:
+		return []string{pkg("cache"), "REDIS_ADDR", "redis:"}
+	case config.ComponentGRPC:
+		return []string{pkg("grpc"), "GRPC_PORT"}
+	case config.ComponentHTTP:
+		return []string{pkg("api"), "SERVER_PORT"}
+	case config.ComponentDocker:
+		return []string{"docker-compose", "docker compose", "Dockerfile"}
+	case config.ComponentCICD:
+		return []string{".github/workflows", ".gitlab-ci.yml", "bitbucket-pipelines.yml", ".gitea/workflows", ".releaserc.yml", "release-please"}
+	case config.ComponentMetrics:
+		return []string{"prometheus", "/metrics"}
+	case config.ComponentTracing:
+		return []string{pkg("telemetry"), "go.opentelemetry.io", "OTEL_"}
+	case config.ComponentAuth:
+		return []string{pkg("auth"), "JWT_SECRET", "PASETO_KEYS"}
+	}
+	return nil
+}
+
+// removeEmptyDirs removes dir and its parents up to root while they are empty
+func removeEmptyDirs(dir, root string) {
+	for dir != root && strings.HasPrefix(dir, root) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// ReferencingFiles returns the files with references to the component in path order
+func (r *RemovalReport) ReferencingFiles() []string {
+	paths := make([]string, 0, len(r.References))
+	for path := range r.References {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
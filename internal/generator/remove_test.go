@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+// removeComponent removes a component from the project in dir with the
+// configuration recorded in its manifest and the given command line flags
+func removeComponent(t *testing.T, dir, component string, args ...string) *RemovalReport {
+	t.Helper()
+
+	m, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	cfg, err := config.ParseUpdateArgs(append([]string{"--no-doctor"}, args...), m.Project)
+	if err != nil {
+		t.Fatalf("ParseUpdateArgs() error = %v", err)
+	}
+	cfg.OutputDir = filepath.Dir(dir)
+
+	report, err := RemoveComponent(logger.NewLogger(), cfg, component)
+	if err != nil {
+		t.Fatalf("RemoveComponent() error = %v", err)
+	}
+	return report
+}
+
+// postgresFiles are files only the postgres component generates
+var postgresFiles = []string{
+	"internal/db/db.go",
+	"internal/db/models/users.go",
+	"internal/db/repositories/repositories.go",
+	"internal/migrations/migrations.go",
+	"internal/migrations/sql/001_init.up.sql",
+	"internal/api/handlers/users.go",
+	"scripts/migrate.sh",
+	"scripts/modelgen/modelgen.go",
+	"modelgen.yaml",
+}
+
+func TestRemoveComponent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go mod tidy, which needs the module proxy")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+
+	// go mod tidy drops the requirements of the component, so the project is
+	// generated online
+	dir := t.TempDir()
+	cfg, err := config.ParseArgs([]string{
+		"--project", "demo",
+		"--username", "acme",
+		"--output", dir,
+		"--components", "http,postgres",
+		"--skip-verify",
+		"--no-doctor",
+	})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if err := NewGenerator(logger.NewLogger(), cfg).Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	projectDir := filepath.Join(dir, "demo")
+
+	report := removeComponent(t, projectDir, config.ComponentPostgres)
+
+	for _, name := range postgresFiles {
+		if !slices.Contains(report.Deleted, filepath.FromSlash(name)) {
+			t.Errorf("Deleted lacks %s", name)
+		}
+		if _, err := os.Stat(filepath.Join(projectDir, name)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s was not deleted: %v", name, err)
+		}
+	}
+	for _, name := range []string{"internal/db", "internal/migrations"} {
+		if _, err := os.Stat(filepath.Join(projectDir, name)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("the empty directory %s was left: %v", name, err)
+		}
+	}
+	if len(report.Kept) > 0 || len(report.Edited) > 0 || len(report.References) > 0 {
+		t.Errorf("Kept, Edited, References = %v, %v, %v, want none for an unedited project", report.Kept, report.Edited, report.References)
+	}
+
+	goMod, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	modules := requiredModules(t, goMod)
+	for _, module := range []string{"github.com/lib/pq", "github.com/jmoiron/sqlx", "github.com/golang-migrate/migrate/v4"} {
+		if modules[module] {
+			t.Errorf("go.mod still requires %s", module)
+		}
+	}
+	if !modules["github.com/gin-gonic/gin"] {
+		t.Error("go.mod no longer requires github.com/gin-gonic/gin")
+	}
+
+	// README.md is regenerated without the sections of the database
+	if !slices.Contains(report.Updated, "README.md") {
+		t.Errorf("Updated = %v, want README.md among them", report.Updated)
+	}
+	readme, err := os.ReadFile(filepath.Join(projectDir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, section := range []string{"PostgreSQL", "## Users API", "Set up database", "migrate.sh"} {
+		if strings.Contains(string(readme), section) {
+			t.Errorf("README.md still mentions %q", section)
+		}
+	}
+
+	// The manifest records the project without the component
+	m, err := LoadManifest(projectDir)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if slices.Contains(m.Project.Components, config.ComponentPostgres) {
+		t.Errorf("the manifest records the components %v", m.Project.Components)
+	}
+	if !m.Matches("go.mod", goMod) || !m.Matches("README.md", readme) {
+		t.Error("the manifest doesn't record go.mod and README.md as generated")
+	}
+	for _, name := range postgresFiles {
+		if _, ok := m.Files[name]; ok {
+			t.Errorf("the manifest still records %s", name)
+		}
+	}
+}
+
+func TestRemoveComponentKeepsEditedFiles(t *testing.T) {
+	projectDir := generateProject(t, "--components", "http,postgres")
+
+	edited := filepath.Join(projectDir, "internal", "db", "db.go")
+	content, err := os.ReadFile(edited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content = append(content, "\n// Our tweak\n"...)
+	if err := os.WriteFile(edited, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report := removeComponent(t, projectDir, config.ComponentPostgres, "--offline")
+
+	name := filepath.Join("internal", "db", "db.go")
+	if slices.Contains(report.Deleted, name) {
+		t.Errorf("the edited %s was deleted", name)
+	}
+	if !slices.Contains(report.Kept, name) {
+		t.Errorf("Kept = %v, want %s", report.Kept, name)
+	}
+	got, err := os.ReadFile(edited)
+	if err != nil {
+		t.Fatalf("the edited %s is gone: %v", name, err)
+	}
+	if string(got) != string(content) {
+		t.Errorf("the edited %s was changed", name)
+	}
+
+	// The unedited files of the component are still deleted
+	for _, other := range postgresFiles[1:] {
+		if _, err := os.Stat(filepath.Join(projectDir, other)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s was not deleted: %v", other, err)
+		}
+	}
+}
+
+// requiredModules returns the modules required by a go.mod file
+func requiredModules(t *testing.T, goMod []byte) map[string]bool {
+	t.Helper()
+
+	f, err := modfile.ParseLax("go.mod", goMod, nil)
+	if err != nil {
+		t.Fatalf("failed to parse go.mod: %v", err)
+	}
+	modules := map[string]bool{}
+	for _, req := range f.Require {
+		modules[req.Mod.Path] = true
+	}
+	return modules
+}
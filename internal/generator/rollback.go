@@ -0,0 +1,199 @@
+// internal/generator/rollback.go - Undoing the filesystem changes of a failed run
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// change is a filesystem change made by the run
+type change struct {
+	path string
+	// created is set for a file or directory that didn't exist before the run;
+	// otherwise content and mode are those of the file the run overwrote
+	created bool
+	content []byte
+	mode    fs.FileMode
+}
+
+// RollbackWriter wraps a FileWriter and records the changes it makes, so that
+// the files and directories a failed run created can be removed and the files
+// it overwrote restored. It is safe for concurrent use.
+type RollbackWriter struct {
+	mu   sync.Mutex
+	next FileWriter
+	// changes lists the changes in the order they were made
+	changes []change
+	// seen holds the paths already recorded; only their first state matters
+	seen map[string]bool
+	// dirs holds the directories the run created, whose content needs no recording
+	dirs map[string]bool
+}
+
+// NewRollbackWriter creates a writer recording the changes made through next
+func NewRollbackWriter(next FileWriter) *RollbackWriter {
+	return &RollbackWriter{
+		next: next,
+		seen: map[string]bool{},
+		dirs: map[string]bool{},
+	}
+}
+
+// MkdirAll creates a directory and any missing parents, recording the topmost
+// directory it creates
+func (w *RollbackWriter) MkdirAll(path string, perm os.FileMode) error {
+	created := ""
+	for dir := filepath.Clean(path); ; dir = filepath.Dir(dir) {
+		if _, err := os.Lstat(dir); err == nil || filepath.Dir(dir) == dir {
+			break
+		}
+		created = dir
+	}
+
+	if err := w.next.MkdirAll(path, perm); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if created != "" && !w.inCreatedDir(created) {
+		w.seen[created], w.dirs[created] = true, true
+		w.changes = append(w.changes, change{path: created, created: true})
+	}
+	return nil
+}
+
+// WriteFile writes data to a file, recording whether it existed and its content
+func (w *RollbackWriter) WriteFile(path string, data []byte, perm os.FileMode) error {
+	w.Track(path)
+	return w.next.WriteFile(path, data, perm)
+}
+
+// Chmod changes the mode of a file, which WriteFile recorded beforehand
+func (w *RollbackWriter) Chmod(path string, mode os.FileMode) error {
+	w.Track(path)
+	return w.next.Chmod(path, mode)
+}
+
+// Track records the current state of path before something other than the writer,
+// such as go mod tidy, changes it. A missing path is removed by Rollback and a
+// regular file is restored; existing directories are left as they are.
+func (w *RollbackWriter) Track(path string) {
+	path = filepath.Clean(path)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.seen[path] || w.inCreatedDir(path) {
+		return
+	}
+	w.seen[path] = true
+
+	info, err := os.Lstat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		w.changes = append(w.changes, change{path: path, created: true})
+	case err != nil || !info.Mode().IsRegular():
+		return
+	default:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return
+		}
+		w.changes = append(w.changes, change{path: path, content: content, mode: info.Mode().Perm()})
+	}
+}
+
+// Rollback undoes the recorded changes, the latest first, and returns the number of
+// paths removed and files restored
+func (w *RollbackWriter) Rollback() (removed, restored int, err error) {
+	var errs []error
+	for i := len(w.changes) - 1; i >= 0; i-- {
+		c := w.changes[i]
+		if c.created {
+			if _, statErr := os.Lstat(c.path); errors.Is(statErr, fs.ErrNotExist) {
+				continue
+			}
+			if err := os.RemoveAll(c.path); err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove %s: %w", c.path, err))
+				continue
+			}
+			removed++
+			continue
+		}
+
+		if err := os.WriteFile(c.path, c.content, c.mode); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s: %w", c.path, err))
+			continue
+		}
+		if err := os.Chmod(c.path, c.mode); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore the mode of %s: %w", c.path, err))
+			continue
+		}
+		restored++
+	}
+
+	w.changes, w.seen, w.dirs = nil, map[string]bool{}, map[string]bool{}
+	return removed, restored, errors.Join(errs...)
+}
+
+// inCreatedDir reports whether path is inside a directory the run created, which
+// Rollback removes as a whole
+func (w *RollbackWriter) inCreatedDir(path string) bool {
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if w.dirs[dir] {
+			return true
+		}
+		if filepath.Dir(dir) == dir {
+			return false
+		}
+	}
+}
+
+// withRollback runs generate with its filesystem changes recorded, and undoes them
+// when it fails or panics, so a failed run leaves the output directory as it was
+func (g *Generator) withRollback(generate func() error) (err error) {
+	// In plan mode with --apply, the plan writes to disk through its own writer
+	target := &g.writer
+	if g.plan != nil {
+		target = &g.plan.disk
+	}
+	next := *target
+	g.rollback = NewRollbackWriter(next)
+	*target = g.rollback
+
+	defer func() {
+		rollback := g.rollback
+		*target, g.rollback = next, nil
+
+		if r := recover(); r != nil {
+			g.log.Warn("Generation panicked, undoing the changes of the run", "panic", r)
+			g.undo(rollback)
+			panic(r)
+		}
+		if err != nil {
+			g.log.Warn("Generation failed, undoing the changes of the run", "error", err)
+			g.undo(rollback)
+		}
+	}()
+
+	return generate()
+}
+
+// undo rolls back the changes recorded by rollback, logging what it did
+func (g *Generator) undo(rollback *RollbackWriter) {
+	removed, restored, err := rollback.Rollback()
+	if err != nil {
+		g.log.Error("Failed to undo some changes of the run", "error", err)
+	}
+	g.log.Info("Undid the changes of the run", "removed", removed, "restored", restored)
+}
+
+// track records the state of a path that a command run by the generator is about
+// to change, so that it is undone if the run fails
+func (g *Generator) track(path string) {
+	if g.rollback != nil {
+		g.rollback.Track(path)
+	}
+}
@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+// failingWriter writes to disk and fails the writes of files under failDir
+type failingWriter struct {
+	osWriter
+	failDir string
+
+	mu      sync.Mutex
+	written map[string]bool
+}
+
+// WriteFile fails for files under failDir and writes the others
+func (w *failingWriter) WriteFile(path string, data []byte, perm os.FileMode) error {
+	if strings.HasPrefix(path, w.failDir+string(filepath.Separator)) {
+		return errors.New("disk full")
+	}
+	w.mu.Lock()
+	w.written[path] = true
+	w.mu.Unlock()
+	return w.osWriter.WriteFile(path, data, perm)
+}
+
+func TestGenerateRollsBackOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	projectDir := filepath.Join(dir, "demo")
+
+	// An existing project directory with a README the run overwrites and a
+	// file of the user the run doesn't touch
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	readme := filepath.Join(projectDir, "README.md")
+	if err := os.WriteFile(readme, []byte("# My notes\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	notes := filepath.Join(projectDir, "notes.txt")
+	if err := os.WriteFile(notes, []byte("keep me\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.ParseArgs([]string{
+		"--project", "demo",
+		"--username", "acme",
+		"--output", dir,
+		"--components", "http,postgres",
+		"--offline",
+		"--no-doctor",
+	})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+
+	// The components are written after the project files, README.md among them
+	writer := &failingWriter{failDir: filepath.Join(projectDir, "internal", "db"), written: map[string]bool{}}
+	g := NewGenerator(logger.NewLogger(), cfg)
+	g.writer = writer
+
+	if err := g.Generate(); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("Generate() error = %v, want the write failure", err)
+	}
+	if !writer.written[readme] {
+		t.Fatal("README.md was not overwritten before the failure")
+	}
+
+	// The overwritten file is restored with its mode
+	content, err := os.ReadFile(readme)
+	if err != nil {
+		t.Fatalf("README.md was not restored: %v", err)
+	}
+	if string(content) != "# My notes\n" {
+		t.Errorf("README.md = %q, want the content before the run", content)
+	}
+	if info, err := os.Stat(readme); err == nil && info.Mode().Perm() != 0600 {
+		t.Errorf("README.md mode = %v, want -rw-------", info.Mode().Perm())
+	}
+
+	// The created files and directories are removed, the user's file is kept
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if got := strings.Join(names, " "); got != "README.md notes.txt" {
+		t.Errorf("project directory holds %s, want README.md notes.txt", got)
+	}
+
+	// Nothing is left in the output directory either
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 1 {
+		t.Errorf("output directory holds %d entries, want the project only (%v)", len(entries), err)
+	}
+}
+
+func TestGenerateRollbackRemovesCreatedProject(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := config.ParseArgs([]string{
+		"--project", "demo",
+		"--username", "acme",
+		"--output", dir,
+		"--components", "http,postgres",
+		"--offline",
+		"--no-doctor",
+	})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+
+	g := NewGenerator(logger.NewLogger(), cfg)
+	g.writer = &failingWriter{failDir: filepath.Join(dir, "demo", "internal", "db"), written: map[string]bool{}}
+	if err := g.Generate(); err == nil {
+		t.Fatal("Generate() error = nil, want the write failure")
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "demo")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("the project directory the run created is left: %v", err)
+	}
+}
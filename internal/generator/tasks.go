@@ -0,0 +1,140 @@
+// internal/generator/tasks.go - Rendering and writing the generated files with a pool of workers
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// fileWorkers bounds the number of files rendered and written at once; writing
+// dominates on network filesystems, so it doesn't depend on the number of CPUs
+const fileWorkers = 8
+
+// fileTask is a generated file; render returns its content before formatting
+// and the ownership header
+type fileTask struct {
+	path   string
+	perm   os.FileMode
+	render func() ([]byte, error)
+}
+
+// taskQueue collects the files of a phase, which are independent of each other
+type taskQueue struct {
+	tasks []fileTask
+	// index maps the queued paths to their task; a file written twice keeps the
+	// position of the first write and the content of the last one
+	index map[string]int
+}
+
+// add queues a task, replacing an earlier one for the same path
+func (q *taskQueue) add(task fileTask) {
+	if i, ok := q.index[task.path]; ok {
+		q.tasks[i] = task
+		return
+	}
+	q.index[task.path] = len(q.tasks)
+	q.tasks = append(q.tasks, task)
+}
+
+// queueFiles runs generate with the files it writes queued instead of written,
+// then writes them with runTasks
+func (g *Generator) queueFiles(generate func() error) error {
+	g.queue = &taskQueue{index: map[string]int{}}
+	err := generate()
+	queue := g.queue
+	g.queue = nil
+	if err != nil {
+		return err
+	}
+	return g.runTasks(queue.tasks)
+}
+
+// runTasks renders and writes the files with up to fileWorkers workers. The first
+// failure cancels the tasks not started yet, and the failures of the tasks already
+// running are joined with it, so that every error is reported.
+func (g *Generator) runTasks(tasks []fileTask) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pending := make(chan fileTask)
+	go func() {
+		defer close(pending)
+		for _, task := range tasks {
+			select {
+			case pending <- task:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		done int
+		wg   sync.WaitGroup
+	)
+	g.progress.files(0, len(tasks))
+	for range min(fileWorkers, len(tasks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range pending {
+				// The task may have been handed over just before a failure
+				if ctx.Err() != nil {
+					continue
+				}
+				err := g.runTask(task)
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, err)
+					cancel()
+				} else {
+					done++
+					g.progress.files(done, len(tasks))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	g.progress.filesDone(done, len(tasks))
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to write %d of %d files: %w", len(errs), len(tasks), errors.Join(errs...))
+	}
+	return nil
+}
+
+// runTask renders a file, formats it when it is Go source and writes it with the
+// ownership header unless disabled
+func (g *Generator) runTask(task fileTask) error {
+	content, err := task.render()
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(task.path, ".go") {
+		if content, err = formatGo(g.templateName(task.path), task.path, content); err != nil {
+			return err
+		}
+	}
+	if err := g.writer.WriteFile(task.path, g.generatedContent(task.path, content), task.perm); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	// WriteFile keeps the mode of a file it overwrites, which scripts must not lose
+	if task.perm&0111 != 0 {
+		if err := g.writer.Chmod(task.path, task.perm); err != nil {
+			return fmt.Errorf("failed to make %s executable: %w", g.templateName(task.path), err)
+		}
+	}
+
+	g.generatedMu.Lock()
+	g.generated[task.path] = true
+	g.generatedMu.Unlock()
+	return nil
+}
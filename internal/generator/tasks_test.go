@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+func TestRunTasksJoinsErrors(t *testing.T) {
+	dir := t.TempDir()
+	g := NewGenerator(logger.NewLogger(), &config.Config{OutputDir: dir})
+
+	// The failing tasks wait for each other, so that they are all running
+	// when the first one fails and cancels the rest
+	const failing = 3
+	var started sync.WaitGroup
+	started.Add(failing)
+
+	var (
+		tasks []fileTask
+		errs  []error
+	)
+	for i := range failing {
+		err := fmt.Errorf("task %d failed", i)
+		errs = append(errs, err)
+		tasks = append(tasks, fileTask{
+			path: filepath.Join(dir, fmt.Sprintf("failing%d.txt", i)),
+			perm: 0644,
+			render: func() ([]byte, error) {
+				started.Done()
+				started.Wait()
+				return nil, err
+			},
+		})
+	}
+
+	err := g.runTasks(tasks)
+	if err == nil {
+		t.Fatal("runTasks() error = nil, want the errors of the failing tasks")
+	}
+	for _, want := range errs {
+		if !errors.Is(err, want) {
+			t.Errorf("runTasks() error = %v, want it to wrap %q", err, want)
+		}
+	}
+	if want := fmt.Sprintf("failed to write %d of %d files", failing, failing); !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("runTasks() error = %v, want it to start with %q", err, want)
+	}
+}
+
+func TestRunTasksWritesFiles(t *testing.T) {
+	dir := t.TempDir()
+	g := NewGenerator(logger.NewLogger(), &config.Config{OutputDir: dir})
+
+	var tasks []fileTask
+	for i := range 2 * fileWorkers {
+		content := fmt.Sprintf("file %d\n", i)
+		tasks = append(tasks, fileTask{
+			path:   filepath.Join(dir, fmt.Sprintf("file%d.txt", i)),
+			perm:   0644,
+			render: func() ([]byte, error) { return []byte(content), nil },
+		})
+	}
+
+	if err := g.runTasks(tasks); err != nil {
+		t.Fatalf("runTasks() error = %v", err)
+	}
+	for _, task := range tasks {
+		if !g.generated[task.path] {
+			t.Errorf("%s is not recorded as generated", task.path)
+		}
+	}
+}
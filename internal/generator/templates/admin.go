@@ -0,0 +1,26 @@
+// internal/generator/templates/admin.go - Templates for the admin server
+package templates
+
+import (
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// AdminServerTemplate returns the content of the internal/admin/server.go file,
+// which serves the operational endpoints on ADMIN_PORT
+func AdminServerTemplate() (string, error) {
+	return render("admin_server.tmpl", nil)
+}
+
+// AdminInfoTemplate returns the content of the internal/admin/info.go file, the
+// debug info reporting the build, the runtime, the database pools and the
+// components the project was generated with
+func AdminInfoTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("admin_info.tmpl", map[string]any{
+		"Components": cfg.Components.Names(),
+	})
+}
+
+// AdminTestTemplate returns the content of the internal/admin/admin_test.go file
+func AdminTestTemplate() (string, error) {
+	return render("admin_test.tmpl", nil)
+}
@@ -1,237 +1,416 @@
 // internal/generator/templates/api.go - Templates for API files
 package templates
 
-// APIServerTemplate returns the content of the server.go file
-func APIServerTemplate() string {
-	return `// internal/api/server.go - HTTP server implementation
-package api
-
 import (
-	"context"
 	"fmt"
-	"net/http"
-
-	"github.com/gin-gonic/gin"
-	"github.com/gin-contrib/cors"
-	"github.com/gin-contrib/pprof"
 
-	"{{ .ModuleName }}/internal/api/middleware"
-	"{{ .ModuleName }}/internal/api/routes"
-	"{{ .ModuleName }}/internal/config"
-	"{{ .ModuleName }}/internal/logger"
+	"github.com/neor-it/go-project-gen/internal/config"
 )
 
-// Server represents the HTTP server
-type Server struct {
-	log    logger.Logger
-	cfg    *config.Config
-	router *gin.Engine
-	server *http.Server
+// apiFramework holds the framework-specific parts of the generated HTTP API
+type apiFramework struct {
+	// Name is the framework name of the configuration and Label its human-readable name
+	Name  string
+	Label string
+	// ServerImports are the framework imports of server.go
+	ServerImports string
+	// PprofImport is the net/http/pprof import spec of server.go, for frameworks
+	// serving the profiles with the standard library
+	PprofImport string
+	// RouterType is the type of the Server.router field
+	RouterType string
+	// ServerSetup is the template file of the code creating the router, adding
+	// middleware and registering routes in NewServer
+	ServerSetup string
+	// ServerHandler is the expression used as http.Server.Handler
+	ServerHandler string
+	// NetHTTPHandlers is set when handlers use plain http.ResponseWriter/*http.Request
+	NetHTTPHandlers bool
+	// MetricsImports are the imports of metrics.go
+	MetricsImports string
+	// MetricsMiddleware is the template file of the framework-specific Metrics
+	// middleware of metrics.go
+	MetricsMiddleware string
+	// TracingMiddleware is the template file of tracing.go
+	TracingMiddleware string
+
+	// HealthHandler, Middleware and Routes are the template files of health.go,
+	// middleware.go and routes.go
+	HealthHandler string
+	Middleware    string
+	Routes        string
+
+	// AuthMiddleware and AuthHandler are the template files of the auth.go files of
+	// the middleware and handlers packages
+	AuthMiddleware string
+	AuthHandler    string
+	// RateLimitMiddleware is the template file of the ratelimit.go file of the
+	// middleware package, limiting the requests of each client IP
+	RateLimitMiddleware string
+	// CacheMiddleware is the template file of the cache.go file of the middleware
+	// package, serving the responses of a route from the response cache
+	CacheMiddleware string
+
+	// UsersHandler returns the users.go file of the handlers package, generated with a database
+	UsersHandler func(cfg config.ProjectConfig) (string, error)
+	// StatsHandler returns the stats.go file of the request coalescing example
+	StatsHandler func(cfg config.ProjectConfig) (string, error)
+	// Descriptor holds the parts of the descriptor.go file of the handlers package,
+	// serving the service descriptor and the OpenAPI document
+	Descriptor descriptorFramework
+
+	// MiddlewareTest is the template file of the tests of the middleware package; it
+	// is empty for frameworks without generated middleware tests
+	MiddlewareTest string
+
+	// TestRouter builds the router of the handler tests
+	TestRouter handlersTestRouter
 }
 
-// NewServer creates a new HTTP server
-func NewServer(log logger.Logger, cfg *config.Config, dependencies ...interface{}) (*Server, error) {
-	// Set Gin mode
-	gin.SetMode(gin.ReleaseMode)
+// handlersTestRouter describes how the generated handler tests build the router of a framework
+type handlersTestRouter struct {
+	// Import is the framework import of the test, empty for net/http
+	Import string
+	// New declares the router variable
+	New string
+	// PassThrough is an auth middleware of the framework letting every request through
+	PassThrough string
+	// MethodNotAllowed tells whether the router answers 405 to a wrong method on a known path
+	MethodNotAllowed bool
+}
 
-	// Create router
-	router := gin.New()
+// apiFrameworks maps HTTP framework names to their templates
+var apiFrameworks = map[string]apiFramework{
+	config.HTTPFrameworkGin:    ginFramework,
+	config.HTTPFrameworkEcho:   echoFramework,
+	config.HTTPFrameworkChi:    chiFramework,
+	config.HTTPFrameworkStdlib: stdlibFramework,
+}
 
-	// Add middleware
-	router.Use(middleware.Logger(log))
-	router.Use(middleware.Recovery(log))
-	router.Use(cors.Default())
+// frameworkFor returns the templates of the HTTP framework selected in cfg
+func frameworkFor(cfg config.ProjectConfig) apiFramework {
+	if framework, ok := apiFrameworks[cfg.Components.HTTPFramework]; ok {
+		return framework
+	}
+	return apiFrameworks[config.DefaultHTTPFramework]
+}
 
-	// Add pprof endpoints in debug mode
-	pprof.Register(router)
+// HTTPFrameworkLabel returns the human-readable name of the selected HTTP framework
+func HTTPFrameworkLabel(cfg config.ProjectConfig) string {
+	return frameworkFor(cfg).Label
+}
 
-	// Register routes
-	routes.RegisterRoutes(router, log, dependencies...)
+// APIServerTemplate returns the content of the server.go file
+func APIServerTemplate(cfg config.ProjectConfig) (string, error) {
+	framework := frameworkFor(cfg)
 
-	// Create server
-	server := &Server{
-		log:    log,
-		cfg:    cfg,
-		router: router,
-		server: &http.Server{
-			Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
-			Handler:      router,
-			ReadTimeout:  cfg.Server.ReadTimeout,
-			WriteTimeout: cfg.Server.WriteTimeout,
-		},
+	setup, err := render(framework.ServerSetup, map[string]any{"Cfg": cfg})
+	if err != nil {
+		return "", err
 	}
 
-	return server, nil
-}
-
-// Start starts the HTTP server
-func (s *Server) Start() error {
-	s.log.Info("Starting HTTP server", "port", s.cfg.Server.Port)
+	stdImports := []string{`"context"`, `"errors"`, `"fmt"`, `"net/http"`, `"syscall"`}
+	if framework.PprofImport != "" {
+		stdImports = append(stdImports, framework.PprofImport)
+	}
 
-	// Start server in a goroutine
-	go func() {
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			s.log.Error("Failed to start HTTP server", "error", err)
-		}
-	}()
+	return render("api_server.tmpl", map[string]any{
+		"Cfg":        cfg,
+		"Framework":  framework,
+		"StdImports": importLines(stdImports),
+		"Setup":      setup,
+		"TLS":        cfg.HasTLS(),
+	})
+}
 
-	return nil
+// APIServerTestTemplate returns the content of the server_test.go file
+func APIServerTestTemplate() (string, error) {
+	return render("api_server_test.tmpl", nil)
 }
 
-// Stop stops the HTTP server
-func (s *Server) Stop(ctx context.Context) error {
-	s.log.Info("Stopping HTTP server")
+// APIHandlersTemplate returns the content of the handlers.go file
+func APIHandlersTemplate(cfg config.ProjectConfig) (string, error) {
+	// Handlers take the dependencies that match the selected components
+	handlers := [][2]string{
+		{"Health", "NewHealthHandler()"},
+		{"Ready", "NewReadyHandler(deps.Breakers)"},
+		{"Status", "NewStatusHandler()"},
+		{"Descriptor", "NewDescriptorHandler(deps.Version, deps.BasePath)"},
+	}
+	public := []string{"h.Health", "h.Ready", "h.Status", "h.Descriptor"}
+	protected := []string{}
 
-	// Shutdown server
-	if err := s.server.Shutdown(ctx); err != nil {
-		return fmt.Errorf("failed to shutdown HTTP server: %w", err)
+	// The status route is the example of a cached route
+	if cfg.HasResponseCache() {
+		handlers[2][1] = "NewStatusHandler(deps.ResponseCache)"
 	}
 
-	return nil
-}
-`
-}
+	// usersDatabase is the expression of the database storing the users
+	usersDatabase := "deps.DB"
+	if cfg.HasNamedDatabases() {
+		usersDatabase = "deps.Databases.Get(" + mainDatabaseConstant(cfg) + ")"
+	}
 
-// APIHandlersTemplate returns the content of the handlers.go file
-func APIHandlersTemplate() string {
-	return `// internal/api/handlers/handlers.go - HTTP request handlers
-package handlers
+	if cfg.Components.HasDatabase() {
+		// The readiness check pings the database and the users handlers store rows in it;
+		// with named connections every one is pinged and the users live in the main one
+		if cfg.HasNamedDatabases() {
+			handlers[1][1] = "NewReadyHandler(deps.Databases, deps.Breakers)"
+		} else {
+			handlers[1][1] = "NewReadyHandler(deps.DB, deps.Breakers)"
+		}
+		handlers = append(handlers, [2]string{"Users", "NewUsersHandler(deps.Log, " + usersDatabase + ", deps.Clock, deps.Passwords)"})
+	}
 
-import (
-	"net/http"
+	// The statistics of the coalescing example are an aggregate of the users table,
+	// so they are registered like the users routes
+	if cfg.HasCoalescingExample() {
+		handlers = append(handlers, [2]string{"Stats", "NewStatsHandler(deps.Log, " + usersDatabase + ", deps.Clock)"})
+	}
 
-	"github.com/gin-gonic/gin"
+	if cfg.Components.Auth {
+		handlers = append(handlers,
+			[2]string{"Auth", "NewAuthHandler(deps.Log, deps.Auth, deps.LoginLimiter)"},
+			[2]string{"CurrentUser", "NewCurrentUserHandler()"},
+		)
+		// Sign-up and login are public, everything else needs a bearer token
+		public = append(public, "h.Auth")
+		protected = append(protected, "h.CurrentUser")
+		if cfg.Components.HasDatabase() {
+			protected = append(protected, "h.Users")
+		}
+		if cfg.HasCoalescingExample() {
+			protected = append(protected, "h.Stats")
+		}
+	} else if cfg.Components.HasDatabase() {
+		public = append(public, "h.Users")
+		if cfg.HasCoalescingExample() {
+			public = append(public, "h.Stats")
+		}
+	}
 
-	"{{ .ModuleName }}/internal/logger"
-)
+	fields := make([][2]string, len(handlers))
+	for i, handler := range handlers {
+		fields[i] = [2]string{handler[0], "*" + handler[0] + "Handler"}
+	}
 
-// Handler represents a HTTP handler
-type Handler struct {
-	log logger.Logger
+	return render("api_handlers.tmpl", map[string]any{
+		"Cfg":          cfg,
+		"Framework":    frameworkFor(cfg),
+		"Fields":       alignedLines("\t", "", fields),
+		"Constructors": alignedLines("\t\t", ":", handlers),
+		"Public":       public,
+		"Protected":    protected,
+	})
 }
 
-// NewHandler creates a new handler
-func NewHandler(log logger.Logger) *Handler {
-	return &Handler{
-		log: log,
+// alignedLines renders name and value pairs one per line, padding the names to the
+// longest one as gofmt does; with sep ":" the lines are the elements of a keyed
+// composite literal, and with no sep they are struct fields
+func alignedLines(indent, sep string, pairs [][2]string) string {
+	width := 0
+	for _, pair := range pairs {
+		width = max(width, len(pair[0]+sep))
+	}
+
+	lines := ""
+	for _, pair := range pairs {
+		lines += indent + fmt.Sprintf("%-*s %s", width, pair[0]+sep, pair[1])
+		if sep == ":" {
+			lines += ","
+		}
+		lines += "\n"
 	}
+	return lines
 }
 
-// HealthCheck handles the health check endpoint
-func (h *Handler) HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status": "ok",
-	})
+// APIHealthHandlerTemplate returns the content of the health.go file
+func APIHealthHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	return render(frameworkFor(cfg).HealthHandler, nil)
 }
 
-// Status handles the status endpoint
-func (h *Handler) Status(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status": "ok",
-		"version": "1.0.0",
-	})
+// APIReadyHandlerTemplate returns the content of the ready.go file; /health stays
+// a pure liveness check while /ready also fails when the database is unreachable
+func APIReadyHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("api_ready.tmpl", frameworkData(cfg))
 }
-`
+
+// APIStatusHandlerTemplate returns the content of the status.go file; with the
+// response cache, the status route is the example of a cached route
+func APIStatusHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("api_status.tmpl", frameworkData(cfg))
+}
+
+// frameworkData returns the data of the templates shared by the HTTP frameworks,
+// which tell them apart with the Gin, Echo and Chi flags; none is set for net/http
+func frameworkData(cfg config.ProjectConfig) map[string]any {
+	name := frameworkFor(cfg).Name
+	return map[string]any{
+		"Cfg":      cfg,
+		"Database": cfg.Components.HasDatabase(),
+		"Cache":    cfg.HasResponseCache(),
+		"Gin":      name == config.HTTPFrameworkGin,
+		"Echo":     name == config.HTTPFrameworkEcho,
+		"Chi":      name == config.HTTPFrameworkChi,
+	}
 }
 
 // APIMiddlewareTemplate returns the content of the middleware.go file
-func APIMiddlewareTemplate() string {
-	return `// internal/api/middleware/middleware.go - HTTP middleware
-package middleware
+func APIMiddlewareTemplate(cfg config.ProjectConfig) (string, error) {
+	return render(frameworkFor(cfg).Middleware, nil)
+}
 
-import (
-	"time"
+// APICacheMiddlewareTemplate returns the content of the middleware/cache.go file
+func APICacheMiddlewareTemplate(cfg config.ProjectConfig) (string, error) {
+	return render(frameworkFor(cfg).CacheMiddleware, nil)
+}
 
-	"github.com/gin-gonic/gin"
+// APIRequestIDTemplate returns the content of the request_id.go file shared by every framework
+func APIRequestIDTemplate() (string, error) {
+	return render("api_request_id.tmpl", nil)
+}
 
-	"{{ .ModuleName }}/internal/logger"
-)
+// APIRoutesTemplate returns the content of the routes.go file
+func APIRoutesTemplate(cfg config.ProjectConfig) (string, error) {
+	return render(frameworkFor(cfg).Routes, map[string]any{"Cfg": cfg})
+}
 
-// Logger returns a middleware that logs HTTP requests
-func Logger(log logger.Logger) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Start timer
-		start := time.Now()
-		path := c.Request.URL.Path
-		raw := c.Request.URL.RawQuery
-
-		// Process request
-		c.Next()
-
-		// Log request
-		latency := time.Since(start)
-		clientIP := c.ClientIP()
-		method := c.Request.Method
-		statusCode := c.Writer.Status()
-
-		if raw != "" {
-			path = path + "?" + raw
-		}
+// APITracingMiddlewareTemplate returns the content of the tracing.go file
+func APITracingMiddlewareTemplate(cfg config.ProjectConfig) (string, error) {
+	return render(frameworkFor(cfg).TracingMiddleware, nil)
+}
 
-		log.Info("HTTP request",
-			"status", statusCode,
-			"method", method,
-			"path", path,
-			"ip", clientIP,
-			"latency", latency,
-			"user_agent", c.Request.UserAgent(),
-		)
+// APIMiddlewareTestTemplate returns the content of the middleware_test.go file,
+// or an empty string when the selected framework has no generated middleware tests
+func APIMiddlewareTestTemplate(cfg config.ProjectConfig) (string, error) {
+	if test := frameworkFor(cfg).MiddlewareTest; test != "" {
+		return render(test, nil)
 	}
+	return "", nil
 }
 
-// Recovery returns a middleware that recovers from panics
-func Recovery(log logger.Logger) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		defer func() {
-			if err := recover(); err != nil {
-				// Log error
-				log.Error("Panic recovered", "error", err)
-
-				// Return error response
-				c.AbortWithStatus(500)
-			}
-		}()
+// APIHandlersTestTemplate returns the content of the handlers_test.go file, built on
+// the router of the selected framework; responses are compared with the golden files
+// written by APIHandlerFixturesTemplate
+func APIHandlersTestTemplate(cfg config.ProjectConfig) (string, error) {
+	deps, imports := handlersTestDependencies(cfg)
+	imports = append(imports,
+		`"{{ .ModuleName }}/internal/api/openapi"`,
+		`"{{ .ModuleName }}/internal/api/routes"`,
+	)
+
+	return render("api_handlers_test.tmpl", map[string]any{
+		"Cfg":            cfg,
+		"Router":         frameworkFor(cfg).TestRouter,
+		"Passwords":      HasPasswords(cfg),
+		"Dependencies":   alignedLines("\t\t", ":", deps),
+		"ProjectImports": importLines(imports),
+	})
+}
 
-		c.Next()
+// handlersTestDependencies returns the handler dependencies of the generated handler
+// tests, with databases that are never connected and users kept in memory, and the
+// project imports they need
+func handlersTestDependencies(cfg config.ProjectConfig) ([][2]string, []string) {
+	deps := [][2]string{{"Log", "logger.NewLogger()"}, {"Version", "testVersion"}}
+	imports := []string{`"{{ .ModuleName }}/internal/logger"`}
+
+	if cfg.HasNamedDatabases() {
+		deps = append(deps, [2]string{"Databases", "newTestDatabases(t)"}, [2]string{"Clock", "clock.New()"})
+	} else if cfg.Components.HasDatabase() {
+		deps = append(deps, [2]string{"DB", "newTestDatabase(t)"}, [2]string{"Clock", "clock.New()"})
+	}
+	if cfg.Components.HasDatabase() {
+		imports = append(imports, `"{{ .ModuleName }}/internal/db"`)
+		deps = append(deps, [2]string{"Passwords", "testPasswords"})
 	}
-}
 
-// RequestID returns a middleware that adds a request ID to the context
-func RequestID() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Add request ID to context
-		c.Next()
+	// The users handlers and the auth service share a cheap hasher
+	if HasPasswords(cfg) {
+		imports = append(imports, `"{{ .ModuleName }}/pkg/password"`)
 	}
-}
-`
-}
 
-// APIRoutesTemplate returns the content of the routes.go file
-func APIRoutesTemplate() string {
-	return `// internal/api/routes/routes.go - HTTP routes
-package routes
+	if cfg.Components.HasPASETO() {
+		imports = append(imports, `"{{ .ModuleName }}/internal/auth"`)
+		deps = append(deps, [2]string{"Auth", `auth.NewService(logger.NewLogger(), auth.NewMemoryStore(), newTestTokens(t), testPasswords, nil)`})
+	} else if cfg.Components.Auth {
+		imports = append(imports, `"{{ .ModuleName }}/internal/auth"`)
+		deps = append(deps, [2]string{"Auth", `auth.NewService(logger.NewLogger(), auth.NewMemoryStore(), auth.NewTokens("test-secret", time.Hour, clock.New()), testPasswords, nil)`})
+	}
 
-import (
-	"github.com/gin-gonic/gin"
+	// Each test router limits the logins of each client IP to testLoginLimit a minute
+	if cfg.Components.Auth {
+		imports = append(imports, `"{{ .ModuleName }}/pkg/ratelimit"`)
+		deps = append(deps, [2]string{"LoginLimiter", "ratelimit.New(testLoginLimit, time.Minute, clock.New())"})
+	}
 
-	"{{ .ModuleName }}/internal/api/handlers"
-	"{{ .ModuleName }}/internal/logger"
-)
+	if cfg.Components.HasDatabase() || cfg.Components.Auth {
+		imports = append(imports, `"{{ .ModuleName }}/pkg/clock"`)
+	}
+	return deps, imports
+}
 
-// RegisterRoutes registers the HTTP routes
-func RegisterRoutes(router *gin.Engine, log logger.Logger, dependencies ...interface{}) {
-	// Create handlers
-	handler := handlers.NewHandler(log)
+// Fixture is a file of the handlers testdata directory
+type Fixture struct {
+	// Name is the file name inside testdata
+	Name string
+	// Content is the canonical JSON the generated tests compare against
+	Content string
+}
 
-	// Register top-level routes
-	router.GET("/health", handler.HealthCheck)
-	router.GET("/status", handler.Status)
+// APIHandlerFixturesTemplate returns the JSON request and response bodies shared by the
+// generated handler tests
+func APIHandlerFixturesTemplate(cfg config.ProjectConfig) ([]Fixture, error) {
+	names := []string{"health.response.json", "status.response.json"}
+	if cfg.Components.HasDatabase() {
+		// Named connections report which one is unreachable
+		names = append(names, "ready_unavailable.response.json")
+	} else {
+		names = append(names, "ready.response.json")
+	}
+	if cfg.Components.HasDatabase() {
+		names = append(names,
+			"users_create_invalid.request.json",
+			"users_create_invalid.response.json",
+			"users_invalid_id.response.json",
+			"users_list_invalid.response.json",
+		)
+	}
+	if cfg.Components.Auth {
+		names = append(names,
+			"auth_register.request.json",
+			"auth_register.response.json",
+			"auth_register_conflict.response.json",
+			"auth_login.request.json",
+		)
+	}
 
-	// Register API v1 routes with TODO
-	v1 := router.Group("/api/v1")
-	{
-		// TODO: Add API v1 routes here
+	descriptor, err := serviceDescriptorFixture(cfg)
+	if err != nil {
+		return nil, err
 	}
+	fixtures := []Fixture{{Name: "service_descriptor.response.json", Content: descriptor}}
+	for _, name := range names {
+		content, err := render("api_fixtures_"+name, map[string]any{"Cfg": cfg})
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, Fixture{Name: name, Content: content})
+	}
+	return fixtures, nil
 }
-`
+
+// APIMetricsMiddlewareTemplate returns the content of the metrics.go file
+func APIMetricsMiddlewareTemplate(cfg config.ProjectConfig) (string, error) {
+	framework := frameworkFor(cfg)
+
+	middleware, err := render(framework.MetricsMiddleware, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return render("api_metrics.tmpl", map[string]any{
+		"Framework":  framework,
+		"Middleware": middleware,
+	})
 }
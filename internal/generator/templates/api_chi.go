@@ -0,0 +1,99 @@
+// internal/generator/templates/api_chi.go - Templates for the Chi HTTP API
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// chiFramework holds the Chi-specific HTTP templates
+var chiFramework = apiFramework{
+	Name:  config.HTTPFrameworkChi,
+	Label: "Chi",
+	ServerImports: `
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+`,
+	RouterType:      "*chi.Mux",
+	ServerSetup:     "api_server_chi.tmpl",
+	ServerHandler:   "router",
+	NetHTTPHandlers: true,
+	MetricsImports: `	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+`,
+	MetricsMiddleware: "api_metrics_chi.tmpl",
+	TracingMiddleware: "api_tracing_chi.tmpl",
+
+	HealthHandler: "api_health_chi.tmpl",
+	Middleware:    "api_middleware_chi.tmpl",
+	Routes:        "api_routes_chi.tmpl",
+
+	AuthMiddleware:      "api_auth_middleware_nethttp.tmpl",
+	AuthHandler:         "api_auth_handler_nethttp.tmpl",
+	RateLimitMiddleware: "api_ratelimit_nethttp.tmpl",
+	CacheMiddleware:     "api_cache_nethttp.tmpl",
+
+	UsersHandler: chiUsersHandlerTemplate,
+	StatsHandler: chiStatsHandlerTemplate,
+
+	Descriptor: descriptorFramework{
+		Import:      `"github.com/go-chi/chi/v5"`,
+		RouterParam: "r chi.Router",
+		Routes: `	r.Get(DescriptorPath, h.Descriptor)
+	r.Get(openapi.Path, h.OpenAPI)
+`,
+		HandlerSignature: "w http.ResponseWriter, r *http.Request)",
+		Respond:          "writeJSON(w, %s, %s)",
+		RespondBytes:     netHTTPRespondBytes,
+	},
+
+	TestRouter: handlersTestRouter{
+		Import:           `"github.com/go-chi/chi/v5"`,
+		New:              "router := chi.NewRouter()",
+		PassThrough:      "func(next http.Handler) http.Handler { return next }",
+		MethodNotAllowed: true,
+	},
+}
+
+// chiUsersHandlerTemplate returns the content of the handlers/users.go file for Chi
+func chiUsersHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	collection, item := usersPath(cfg, "", "/users"), usersPath(cfg, "", "/users/{id}")
+	export := usersPath(cfg, "", "/users/export")
+
+	return usersHandlerTemplate(cfg, usersFramework{
+		DecodesJSON: true,
+		Import:      `"github.com/go-chi/chi/v5"`,
+		RouterParam: "r chi.Router",
+		Routes: `	r.Get(` + collection + `, h.List)
+	r.Post(` + collection + `, h.Create)
+	r.Get(` + export + `, h.Export)
+	r.Get(` + item + `, h.Get)
+	r.Put(` + item + `, h.Update)
+	r.Delete(` + item + `, h.Delete)
+`,
+		HandlerSignature: "w http.ResponseWriter, r *http.Request)",
+		Ctx:              "r.Context()",
+		Writer:           "w",
+		ID:               `chi.URLParam(r, "id")`,
+		Query:            `r.URL.Query().Get("%s")`,
+		Decode:           "json.NewDecoder(r.Body).Decode(&req)",
+		Respond:          "writeJSON(w, %s, %s)",
+		NoContent:        "w.WriteHeader(%s)",
+	})
+}
+
+// chiStatsHandlerTemplate returns the content of the handlers/stats.go file for Chi
+func chiStatsHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	return statsHandlerTemplate(cfg, statsFramework{
+		Import:           `"github.com/go-chi/chi/v5"`,
+		RouterParam:      "r chi.Router",
+		Route:            `	r.Get(` + usersPath(cfg, "", "/stats") + `, h.Get)` + "\n",
+		HandlerSignature: "w http.ResponseWriter, r *http.Request)",
+		Ctx:              "r.Context()",
+		Header:           "w.Header().Set(%s, %s)",
+		Respond:          "writeJSON(w, %s, %s)",
+	})
+}
@@ -0,0 +1,98 @@
+// internal/generator/templates/api_echo.go - Templates for the Echo HTTP API
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// echoFramework holds the Echo-specific HTTP templates
+var echoFramework = apiFramework{
+	Name:  config.HTTPFrameworkEcho,
+	Label: "Echo",
+	ServerImports: `
+	"github.com/labstack/echo/v4"
+`,
+	PprofImport:   `_ "net/http/pprof"`,
+	RouterType:    "*echo.Echo",
+	ServerSetup:   "api_server_echo.tmpl",
+	ServerHandler: "router",
+	MetricsImports: `	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+`,
+	MetricsMiddleware: "api_metrics_echo.tmpl",
+	TracingMiddleware: "api_tracing_echo.tmpl",
+
+	HealthHandler: "api_health_echo.tmpl",
+	Middleware:    "api_middleware_echo.tmpl",
+	Routes:        "api_routes_echo.tmpl",
+
+	AuthMiddleware:      "api_auth_middleware_echo.tmpl",
+	AuthHandler:         "api_auth_handler_echo.tmpl",
+	RateLimitMiddleware: "api_ratelimit_echo.tmpl",
+	CacheMiddleware:     "api_cache_echo.tmpl",
+
+	UsersHandler: echoUsersHandlerTemplate,
+	StatsHandler: echoStatsHandlerTemplate,
+
+	Descriptor: descriptorFramework{
+		Import:      `"github.com/labstack/echo/v4"`,
+		RouterParam: "g *echo.Group",
+		Routes: `	g.GET(DescriptorPath, h.Descriptor)
+	g.GET(openapi.Path, h.OpenAPI)
+`,
+		HandlerSignature: "c echo.Context) error",
+		Respond:          "return c.JSON(%s, %s)",
+		RespondBytes:     "return c.Blob(%s, %s, %s)",
+	},
+
+	TestRouter: handlersTestRouter{
+		Import:           `"github.com/labstack/echo/v4"`,
+		New:              "router := echo.New()",
+		PassThrough:      "func(next echo.HandlerFunc) echo.HandlerFunc { return next }",
+		MethodNotAllowed: true,
+	},
+}
+
+// echoUsersHandlerTemplate returns the content of the handlers/users.go file for Echo
+func echoUsersHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	collection, item := usersPath(cfg, "", "/users"), usersPath(cfg, "", "/users/:id")
+	export := usersPath(cfg, "", "/users/export")
+
+	return usersHandlerTemplate(cfg, usersFramework{
+		Import:      `"github.com/labstack/echo/v4"`,
+		RouterParam: "g *echo.Group",
+		Routes: `	g.GET(` + collection + `, h.List)
+	g.POST(` + collection + `, h.Create)
+	g.GET(` + export + `, h.Export)
+	g.GET(` + item + `, h.Get)
+	g.PUT(` + item + `, h.Update)
+	g.DELETE(` + item + `, h.Delete)
+`,
+		HandlerSignature: "c echo.Context) error",
+		Ctx:              "c.Request().Context()",
+		Writer:           "c.Response()",
+		ID:               `c.Param("id")`,
+		Query:            `c.QueryParam("%s")`,
+		Decode:           "c.Bind(&req)",
+		Respond:          "return c.JSON(%s, %s)",
+		NoContent:        "return c.NoContent(%s)",
+		Returns:          true,
+	})
+}
+
+// echoStatsHandlerTemplate returns the content of the handlers/stats.go file for Echo
+func echoStatsHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	return statsHandlerTemplate(cfg, statsFramework{
+		Import:           `"github.com/labstack/echo/v4"`,
+		RouterParam:      "g *echo.Group",
+		Route:            `	g.GET(` + usersPath(cfg, "", "/stats") + `, h.Get)` + "\n",
+		HandlerSignature: "c echo.Context) error",
+		Ctx:              "c.Request().Context()",
+		Header:           "c.Response().Header().Set(%s, %s)",
+		Respond:          "return c.JSON(%s, %s)",
+	})
+}
@@ -0,0 +1,95 @@
+// internal/generator/templates/api_gin.go - Templates for the Gin HTTP API
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// ginFramework holds the Gin-specific HTTP templates
+var ginFramework = apiFramework{
+	Name:  config.HTTPFrameworkGin,
+	Label: "Gin",
+	ServerImports: `
+	"github.com/gin-contrib/pprof"
+	"github.com/gin-gonic/gin"
+`,
+	RouterType:    "*gin.Engine",
+	ServerSetup:   "api_server_gin.tmpl",
+	ServerHandler: "router",
+	MetricsImports: `	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+`,
+	MetricsMiddleware: "api_metrics_gin.tmpl",
+	TracingMiddleware: "api_tracing_gin.tmpl",
+
+	HealthHandler: "api_health_gin.tmpl",
+	Middleware:    "api_middleware_gin.tmpl",
+	Routes:        "api_routes_gin.tmpl",
+
+	AuthMiddleware:      "api_auth_middleware_gin.tmpl",
+	AuthHandler:         "api_auth_handler_gin.tmpl",
+	RateLimitMiddleware: "api_ratelimit_gin.tmpl",
+	CacheMiddleware:     "api_cache_gin.tmpl",
+
+	UsersHandler: ginUsersHandlerTemplate,
+	StatsHandler: ginStatsHandlerTemplate,
+
+	Descriptor: descriptorFramework{
+		Import:      `"github.com/gin-gonic/gin"`,
+		RouterParam: "r *gin.RouterGroup",
+		Routes: `	r.GET(DescriptorPath, h.Descriptor)
+	r.GET(openapi.Path, h.OpenAPI)
+`,
+		HandlerSignature: "c *gin.Context)",
+		Respond:          "c.JSON(%s, %s)",
+		RespondBytes:     "c.Data(%s, %s, %s)",
+	},
+
+	// Gin answers 404 to a wrong method unless HandleMethodNotAllowed is set
+	TestRouter: handlersTestRouter{
+		Import:      `"github.com/gin-gonic/gin"`,
+		New:         "gin.SetMode(gin.TestMode)\n\trouter := gin.New()",
+		PassThrough: "func(c *gin.Context) { c.Next() }",
+	},
+}
+
+// ginUsersHandlerTemplate returns the content of the handlers/users.go file for Gin
+func ginUsersHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	collection, item := usersPath(cfg, "", "/users"), usersPath(cfg, "", "/users/:id")
+	export := usersPath(cfg, "", "/users/export")
+
+	return usersHandlerTemplate(cfg, usersFramework{
+		Import:      `"github.com/gin-gonic/gin"`,
+		RouterParam: "r *gin.RouterGroup",
+		Routes: `	r.GET(` + collection + `, h.List)
+	r.POST(` + collection + `, h.Create)
+	r.GET(` + export + `, h.Export)
+	r.GET(` + item + `, h.Get)
+	r.PUT(` + item + `, h.Update)
+	r.DELETE(` + item + `, h.Delete)
+`,
+		HandlerSignature: "c *gin.Context)",
+		Ctx:              "c.Request.Context()",
+		Writer:           "c.Writer",
+		ID:               `c.Param("id")`,
+		Query:            `c.Query("%s")`,
+		Decode:           "c.ShouldBindJSON(&req)",
+		Respond:          "c.JSON(%s, %s)",
+		NoContent:        "c.Status(%s)",
+	})
+}
+
+// ginStatsHandlerTemplate returns the content of the handlers/stats.go file for Gin
+func ginStatsHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	return statsHandlerTemplate(cfg, statsFramework{
+		Import:           `"github.com/gin-gonic/gin"`,
+		RouterParam:      "r *gin.RouterGroup",
+		Route:            `	r.GET(` + usersPath(cfg, "", "/stats") + `, h.Get)` + "\n",
+		HandlerSignature: "c *gin.Context)",
+		Ctx:              "c.Request.Context()",
+		Header:           "c.Header(%s, %s)",
+		Respond:          "c.JSON(%s, %s)",
+	})
+}
@@ -0,0 +1,94 @@
+// internal/generator/templates/api_stdlib.go - Templates for the net/http HTTP API
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// stdlibFramework holds the net/http-specific HTTP templates
+var stdlibFramework = apiFramework{
+	Name:            config.HTTPFrameworkStdlib,
+	Label:           "net/http",
+	PprofImport:     `"net/http/pprof"`,
+	RouterType:      "*http.ServeMux",
+	ServerSetup:     "api_server_stdlib.tmpl",
+	ServerHandler:   "handler",
+	NetHTTPHandlers: true,
+	MetricsImports: `	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+`,
+	MetricsMiddleware: "api_metrics_stdlib.tmpl",
+	TracingMiddleware: "api_tracing_stdlib.tmpl",
+
+	HealthHandler: "api_health_stdlib.tmpl",
+	Middleware:    "api_middleware_stdlib.tmpl",
+	Routes:        "api_routes_stdlib.tmpl",
+
+	AuthMiddleware:      "api_auth_middleware_nethttp.tmpl",
+	AuthHandler:         "api_auth_handler_nethttp.tmpl",
+	RateLimitMiddleware: "api_ratelimit_nethttp.tmpl",
+	CacheMiddleware:     "api_cache_nethttp.tmpl",
+
+	UsersHandler: stdlibUsersHandlerTemplate,
+	StatsHandler: stdlibStatsHandlerTemplate,
+
+	Descriptor: descriptorFramework{
+		RouterParam: "mux *http.ServeMux",
+		Routes: `	mux.HandleFunc("GET "+DescriptorPath, h.Descriptor)
+	mux.HandleFunc("GET "+openapi.Path, h.OpenAPI)
+`,
+		HandlerSignature: "w http.ResponseWriter, r *http.Request)",
+		Respond:          "writeJSON(w, %s, %s)",
+		RespondBytes:     netHTTPRespondBytes,
+	},
+
+	MiddlewareTest: "api_middleware_test_stdlib.tmpl",
+
+	TestRouter: handlersTestRouter{
+		New:              "router := http.NewServeMux()",
+		PassThrough:      "func(next http.Handler) http.Handler { return next }",
+		MethodNotAllowed: true,
+	},
+}
+
+// stdlibUsersHandlerTemplate returns the content of the handlers/users.go file for net/http
+func stdlibUsersHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	route := func(method, path, handler string) string {
+		return `	mux.HandleFunc(` + usersPath(cfg, method+" ", path) + `, h.` + handler + `)
+`
+	}
+
+	return usersHandlerTemplate(cfg, usersFramework{
+		DecodesJSON: true,
+		RouterParam: "mux *http.ServeMux",
+		Routes: route("GET", "/users", "List") +
+			route("POST", "/users", "Create") +
+			route("GET", "/users/export", "Export") +
+			route("GET", "/users/{id}", "Get") +
+			route("PUT", "/users/{id}", "Update") +
+			route("DELETE", "/users/{id}", "Delete"),
+		HandlerSignature: "w http.ResponseWriter, r *http.Request)",
+		Ctx:              "r.Context()",
+		Writer:           "w",
+		ID:               `r.PathValue("id")`,
+		Query:            `r.URL.Query().Get("%s")`,
+		Decode:           "json.NewDecoder(r.Body).Decode(&req)",
+		Respond:          "writeJSON(w, %s, %s)",
+		NoContent:        "w.WriteHeader(%s)",
+	})
+}
+
+// stdlibStatsHandlerTemplate returns the content of the handlers/stats.go file for net/http
+func stdlibStatsHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	return statsHandlerTemplate(cfg, statsFramework{
+		RouterParam:      "mux *http.ServeMux",
+		Route:            `	mux.HandleFunc(` + usersPath(cfg, "GET ", "/stats") + `, h.Get)` + "\n",
+		HandlerSignature: "w http.ResponseWriter, r *http.Request)",
+		Ctx:              "r.Context()",
+		Header:           "w.Header().Set(%s, %s)",
+		Respond:          "writeJSON(w, %s, %s)",
+	})
+}
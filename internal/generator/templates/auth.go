@@ -0,0 +1,97 @@
+// internal/generator/templates/auth.go - Templates for token authentication files
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// AuthTokensTemplate returns the content of the tokens.go file, issuing JWTs or
+// PASETO tokens depending on the token format
+func AuthTokensTemplate(cfg config.ProjectConfig) (string, error) {
+	if cfg.Components.HasPASETO() {
+		return render("auth_tokens_paseto.tmpl", authTokensData(cfg))
+	}
+	return render("auth_tokens.tmpl", nil)
+}
+
+// AuthServiceTemplate returns the content of the service.go file
+func AuthServiceTemplate() (string, error) {
+	return render("auth_service.tmpl", nil)
+}
+
+// AuthStoreTemplate returns the content of the store.go file
+func AuthStoreTemplate() (string, error) {
+	return render("auth_store.tmpl", nil)
+}
+
+// AuthDatabaseStoreTemplate returns the content of the store_db.go file
+func AuthDatabaseStoreTemplate() (string, error) {
+	return render("auth_database_store.tmpl", nil)
+}
+
+// AuthLockoutTemplate returns the content of the lockout.go file
+func AuthLockoutTemplate() (string, error) {
+	return render("auth_lockout.tmpl", nil)
+}
+
+// AuthAttemptsRedisTemplate returns the content of the attempts_redis.go file
+func AuthAttemptsRedisTemplate() (string, error) {
+	return render("auth_attempts_redis.tmpl", nil)
+}
+
+// AuthAttemptsDatabaseTemplate returns the content of the attempts_db.go file
+func AuthAttemptsDatabaseTemplate() (string, error) {
+	return render("auth_attempts_db.tmpl", nil)
+}
+
+// DBLoginAttemptsRepositoryTemplate returns the content of the repositories/login_attempts.go file
+func DBLoginAttemptsRepositoryTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("db_login_attempts_repository.tmpl", map[string]any{
+		"MySQL": cfg.Components.Database == config.ComponentMySQL,
+	})
+}
+
+// AuthTestTemplate returns the content of the auth_test.go file
+func AuthTestTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("auth_test.tmpl", authTokensData(cfg))
+}
+
+// authTokensData returns the data of the token templates
+func authTokensData(cfg config.ProjectConfig) map[string]any {
+	return map[string]any{
+		"PASETO": cfg.Components.HasPASETO(),
+		"Public": cfg.Components.TokenFormat == config.TokenFormatPASETOPublic,
+	}
+}
+
+// AuthKeyVariable returns the environment variable holding the token keys: the
+// JWT secret, or the list of PASETO keys
+func AuthKeyVariable(cfg config.ProjectConfig) string {
+	if cfg.Components.HasPASETO() {
+		return "PASETO_KEYS"
+	}
+	return "JWT_SECRET"
+}
+
+// authTTLVariable returns the environment variable holding the token lifetime
+func authTTLVariable(cfg config.ProjectConfig) string {
+	if cfg.Components.HasPASETO() {
+		return "PASETO_TTL"
+	}
+	return "JWT_TTL"
+}
+
+// APIAuthMiddlewareTemplate returns the content of the middleware/auth.go file
+func APIAuthMiddlewareTemplate(cfg config.ProjectConfig) (string, error) {
+	return render(frameworkFor(cfg).AuthMiddleware, nil)
+}
+
+// APIRateLimitMiddlewareTemplate returns the content of the middleware/ratelimit.go file
+func APIRateLimitMiddlewareTemplate(cfg config.ProjectConfig) (string, error) {
+	return render(frameworkFor(cfg).RateLimitMiddleware, nil)
+}
+
+// APIAuthHandlerTemplate returns the content of the handlers/auth.go file; the
+// frameworks using net/http handlers share one that only differs in the route
+// registration
+func APIAuthHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	return render(frameworkFor(cfg).AuthHandler, frameworkData(cfg))
+}
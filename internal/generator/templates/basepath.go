@@ -0,0 +1,22 @@
+// internal/generator/templates/basepath.go - Templates for serving the HTTP API under a path prefix
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// APIBasePathTemplate returns the content of the internal/api/basepath.go file
+func APIBasePathTemplate() (string, error) {
+	return render("api_base_path.tmpl", nil)
+}
+
+// APIBasePathTestTemplate returns the content of the internal/api/basepath_test.go file
+func APIBasePathTestTemplate() (string, error) {
+	return render("api_base_path_test.tmpl", nil)
+}
+
+// basePathReadmeSection returns the README section on SERVER_BASE_PATH
+func basePathReadmeSection(cfg config.ProjectConfig) (string, error) {
+	if !cfg.Components.HTTP {
+		return "", nil
+	}
+	return render("api_base_path_readme.tmpl", map[string]any{"Cfg": cfg})
+}
@@ -0,0 +1,13 @@
+// internal/generator/templates/cache.go - Templates for cache files
+package templates
+
+// RedisTemplate returns the content of the redis.go file
+func RedisTemplate() (string, error) {
+	return render("cache_redis.tmpl", nil)
+}
+
+// ResponseStoreTemplate returns the content of the responses.go file, the Redis
+// store of the HTTP response cache
+func ResponseStoreTemplate() (string, error) {
+	return render("cache_responses.tmpl", nil)
+}
@@ -1,76 +1,295 @@
 // internal/generator/templates/cicd.go - Templates for CI/CD files
 package templates
 
-import "github.com/neor-it/go-project-gen/internal/config"
+import (
+	"strings"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
 
 // GitHubWorkflowTemplate returns the content of the GitHub Actions workflow file
-func GitHubWorkflowTemplate(cfg config.ProjectConfig) string {
-	return `name: Build and Deploy
-
-on:
-  push:
-    branches: [main]
-  pull_request:
-    branches: [main]
-
-jobs:
-  test:
-    name: Test
-    runs-on: ubuntu-latest
-    steps:
-      - name: Checkout
-        uses: actions/checkout@v4
-
-      - name: Set up Go
-        uses: actions/setup-go@v5
+func GitHubWorkflowTemplate(cfg config.ProjectConfig) (string, error) {
+	permissions, login := githubRegistryLogin(cfg)
+	return render("cicd_github.tmpl", map[string]any{
+		"Cfg":         cfg,
+		"Image":       cfg.Image(),
+		"Permissions": permissions,
+		"Login":       login,
+	})
+}
+
+// githubRegistryLogin returns the job permissions and the steps logging in to the
+// project registry. ECR and Artifact Registry use OIDC instead of stored credentials.
+func githubRegistryLogin(cfg config.ProjectConfig) (permissions, steps string) {
+	registry := cfg.Registry
+
+	switch registry.Kind {
+	case config.RegistryGHCR:
+		return `    permissions:
+      contents: read
+      packages: write
+`, `
+      - name: Login to GitHub Container Registry
+        uses: docker/login-action@v3
         with:
-          go-version: "1.23"
+          registry: ghcr.io
+          username: ${{ github.actor }}
+          password: ${{ secrets.GITHUB_TOKEN }}
+`
+
+	case config.RegistryECR:
+		return `    permissions:
+      contents: read
+      id-token: write
+`, `
+      - name: Configure AWS credentials
+        uses: aws-actions/configure-aws-credentials@v4
+        with:
+          role-to-assume: ${{ secrets.AWS_ROLE_ARN }}
+          aws-region: ` + registry.Region() + `
+
+      - name: Login to Amazon ECR
+        uses: aws-actions/amazon-ecr-login@v2
+`
 
-      - name: Install dependencies
-        run: go mod download
+	case config.RegistryGAR:
+		return `    permissions:
+      contents: read
+      id-token: write
+`, `
+      - name: Authenticate to Google Cloud
+        id: auth
+        uses: google-github-actions/auth@v2
+        with:
+          token_format: access_token
+          workload_identity_provider: ${{ secrets.GCP_WORKLOAD_IDENTITY_PROVIDER }}
+          service_account: ${{ secrets.GCP_SERVICE_ACCOUNT }}
 
-      - name: Run golangci-lint
-        uses: golangci/golangci-lint-action@v3
+      - name: Login to Artifact Registry
+        uses: docker/login-action@v3
         with:
-          version: latest
+          registry: ` + registry.LoginHost() + `
+          username: oauth2accesstoken
+          password: ${{ steps.auth.outputs.access_token }}
+`
 
-      - name: Run tests
-        run: go test -race -coverprofile=coverage.txt -covermode=atomic ./...
+	case config.RegistryGitLab:
+		return "", `
+      - name: Login to GitLab Container Registry
+        uses: docker/login-action@v3
+        with:
+          registry: ` + registry.LoginHost() + `
+          username: ${{ secrets.REGISTRY_USERNAME }}
+          password: ${{ secrets.REGISTRY_PASSWORD }}
+`
 
-      - name: Upload coverage
-        uses: codecov/codecov-action@v3
+	case config.RegistryCustom:
+		return "", `
+      - name: Login to container registry
+        uses: docker/login-action@v3
         with:
-          file: ./coverage.txt
-          token: ${{ secrets.CODECOV_TOKEN }}
-          fail_ci_if_error: false
-
-  build:
-    name: Build
-    runs-on: ubuntu-latest
-    needs: test
-    if: github.event_name == 'push' && github.ref == 'refs/heads/main'
-    steps:
-      - name: Checkout
-        uses: actions/checkout@v4
-
-      - name: Set up Docker Buildx
-        uses: docker/setup-buildx-action@v3
+          registry: ` + registry.LoginHost() + `
+          username: ${{ secrets.REGISTRY_USERNAME }}
+          password: ${{ secrets.REGISTRY_PASSWORD }}
+`
+	}
 
+	return "", `
       - name: Login to Docker Hub
         uses: docker/login-action@v3
         with:
           username: ${{ secrets.DOCKER_USERNAME }}
           password: ${{ secrets.DOCKER_PASSWORD }}
+`
+}
+
+// GitLabCIPipelineTemplate returns the content of the .gitlab-ci.yml pipeline. It runs the same
+// test, lint and image jobs as the GitHub workflow, plus a deploy job enabled by KUBE_CONTEXT.
+func GitLabCIPipelineTemplate(cfg config.ProjectConfig) (string, error) {
+	// The GitLab registry image follows the project path, which may differ from the image name
+	image := cfg.Image()
+	if cfg.Registry.Kind == config.RegistryGitLab {
+		image = "$CI_REGISTRY_IMAGE"
+	}
+	idTokens, login := gitlabRegistryLogin(cfg)
+
+	return render("cicd_gitlab.tmpl", map[string]any{
+		"Cfg":            cfg,
+		"Image":          image,
+		"IDTokens":       idTokens,
+		"Login":          login,
+		"ReleaseNode":    semanticReleaseNode,
+		"ReleaseCommand": semanticReleaseCommand(cfg),
+	})
+}
+
+// gitlabRegistryLogin returns the ID tokens and the before_script lines logging the
+// build job in to the project registry. ECR uses OIDC instead of stored credentials.
+func gitlabRegistryLogin(cfg config.ProjectConfig) (idTokens, script string) {
+	registry := cfg.Registry
+
+	switch registry.Kind {
+	case config.RegistryGitLab:
+		return "", `    - echo "$CI_REGISTRY_PASSWORD" | docker login "$CI_REGISTRY" --username "$CI_REGISTRY_USER" --password-stdin
+`
+
+	case config.RegistryECR:
+		return `  id_tokens:
+    AWS_ID_TOKEN:
+      aud: sts.amazonaws.com
+`, `    - apk add --no-cache aws-cli
+    - echo "$AWS_ID_TOKEN" > /tmp/web-identity-token
+    - export AWS_WEB_IDENTITY_TOKEN_FILE=/tmp/web-identity-token AWS_REGION=` + registry.Region() + `
+    - aws ecr get-login-password | docker login ` + registry.LoginHost() + ` --username AWS --password-stdin
+`
+
+	case config.RegistryGAR:
+		return "", `    - docker login https://` + registry.LoginHost() + ` --username _json_key --password-stdin < "$GCP_SERVICE_ACCOUNT_KEY"
+`
+
+	case config.RegistryGHCR, config.RegistryCustom:
+		return "", `    - echo "$REGISTRY_PASSWORD" | docker login ` + registry.LoginHost() + ` --username "$REGISTRY_USERNAME" --password-stdin
+`
+	}
+
+	return "", `    - echo "$DOCKER_PASSWORD" | docker login --username "$DOCKER_USERNAME" --password-stdin
+`
+}
+
+// BitbucketPipelinesTemplate returns the content of the bitbucket-pipelines.yml pipeline. Tests
+// and golangci-lint run in parallel on pull requests and on the default branch, which also
+// builds and pushes the image with the Docker component, or the binary without it.
+func BitbucketPipelinesTemplate(cfg config.ProjectConfig) (string, error) {
+	oidc, login := bitbucketRegistryLogin(cfg)
+	return render("cicd_bitbucket.tmpl", map[string]any{
+		"Cfg":            cfg,
+		"Image":          cfg.Image(),
+		"OIDC":           oidc,
+		"Login":          login,
+		"ReleaseNode":    semanticReleaseNode,
+		"ReleaseCommand": semanticReleaseCommand(cfg),
+	})
+}
+
+// bitbucketRegistryLogin returns the OIDC setting and the script lines logging the build step
+// in to the project registry. ECR uses an OIDC token instead of stored credentials.
+func bitbucketRegistryLogin(cfg config.ProjectConfig) (oidc, script string) {
+	registry := cfg.Registry
+
+	switch registry.Kind {
+	case config.RegistryECR:
+		return `        oidc: true
+`, `          - echo "$BITBUCKET_STEP_OIDC_TOKEN" > web-identity-token
+          - docker run --rm --volume "$BITBUCKET_CLONE_DIR:/work" --env AWS_ROLE_ARN --env AWS_WEB_IDENTITY_TOKEN_FILE=/work/web-identity-token --env AWS_REGION=` + registry.Region() + ` amazon/aws-cli ecr get-login-password | docker login ` + registry.LoginHost() + ` --username AWS --password-stdin
+          - rm web-identity-token
+`
+
+	case config.RegistryGAR:
+		return "", `          - echo "$GCP_SERVICE_ACCOUNT_KEY" | base64 -d | docker login https://` + registry.LoginHost() + ` --username _json_key --password-stdin
+`
+
+	case config.RegistryGHCR, config.RegistryGitLab, config.RegistryCustom:
+		return "", `          - echo "$REGISTRY_PASSWORD" | docker login ` + registry.LoginHost() + ` --username "$REGISTRY_USERNAME" --password-stdin
+`
+	}
+
+	return "", `          - echo "$DOCKER_PASSWORD" | docker login --username "$DOCKER_USERNAME" --password-stdin
+`
+}
+
+// GiteaWorkflowTemplate returns the content of the Gitea Actions workflow file. It runs the
+// same jobs as the GitHub workflow, without the Codecov upload, and builds the binary instead
+// of the image without the Docker component.
+func GiteaWorkflowTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("cicd_gitea.tmpl", map[string]any{
+		"Cfg":            cfg,
+		"Image":          cfg.Image(),
+		"Login":          giteaRegistryLogin(cfg),
+		"ReleaseNode":    semanticReleaseNode,
+		"ReleaseCommand": semanticReleaseCommand(cfg),
+	})
+}
+
+// giteaRegistryLogin returns the steps logging in to the project registry. Gitea Actions has
+// no OIDC tokens, so every registry uses stored credentials.
+func giteaRegistryLogin(cfg config.ProjectConfig) string {
+	registry := cfg.Registry
+
+	switch registry.Kind {
+	case config.RegistryECR:
+		return `
+      - name: Configure AWS credentials
+        uses: aws-actions/configure-aws-credentials@v4
+        with:
+          aws-access-key-id: ${{ secrets.AWS_ACCESS_KEY_ID }}
+          aws-secret-access-key: ${{ secrets.AWS_SECRET_ACCESS_KEY }}
+          aws-region: ` + registry.Region() + `
+
+      - name: Login to Amazon ECR
+        uses: aws-actions/amazon-ecr-login@v2
+`
 
-      - name: Build and push
-        uses: docker/build-push-action@v5
+	case config.RegistryGAR:
+		return `
+      - name: Login to Artifact Registry
+        uses: docker/login-action@v3
+        with:
+          registry: ` + registry.LoginHost() + `
+          username: _json_key
+          password: ${{ secrets.GCP_SERVICE_ACCOUNT_KEY }}
+`
+
+	case config.RegistryGHCR, config.RegistryGitLab, config.RegistryCustom:
+		return `
+      - name: Login to container registry
+        uses: docker/login-action@v3
+        with:
+          registry: ` + registry.LoginHost() + `
+          username: ${{ secrets.REGISTRY_USERNAME }}
+          password: ${{ secrets.REGISTRY_PASSWORD }}
+`
+	}
+
+	return `
+      - name: Login to Docker Hub
+        uses: docker/login-action@v3
         with:
-          context: .
-          push: true
-          tags: |
-            ` + cfg.Username + `/` + cfg.ProjectName + `:latest
-            ` + cfg.Username + `/` + cfg.ProjectName + `:${{ github.sha }}
-          cache-from: type=registry,ref=` + cfg.Username + `/` + cfg.ProjectName + `:latest
-          cache-to: type=inline
+          username: ${{ secrets.DOCKER_USERNAME }}
+          password: ${{ secrets.DOCKER_PASSWORD }}
 `
 }
+
+// ciBadge returns the README badge showing the pipeline status of the default
+// branch, or "" when the repository is not hosted by the CI provider
+func ciBadge(cfg config.ProjectConfig) (string, error) {
+	repo := cfg.RepositoryPath()
+	provider := cfg.Components.CIProvider
+	if !cfg.Components.CICD || repo == "" || config.HostCIProvider(cfg.ModuleName) != provider {
+		return "", nil
+	}
+
+	return render("cicd_badge.tmpl", map[string]any{
+		"Cfg":           cfg,
+		"Repo":          repo,
+		"BitbucketRepo": strings.TrimPrefix(repo, "bitbucket.org/"),
+		"GitHub":        provider == config.CIProviderGitHub,
+		"GitLab":        provider == config.CIProviderGitLab,
+		"Bitbucket":     provider == config.CIProviderBitbucket,
+		"Gitea":         provider == config.CIProviderGitea,
+	})
+}
+
+// ciReadmeSection returns the README section describing the CI pipeline
+func ciReadmeSection(cfg config.ProjectConfig) (string, error) {
+	provider := cfg.Components.CIProvider
+	if !cfg.Components.CICD || provider == config.CIProviderNone {
+		return "", nil
+	}
+
+	return render("cicd_readme.tmpl", map[string]any{
+		"Cfg":       cfg,
+		"GitLab":    provider == config.CIProviderGitLab,
+		"Bitbucket": provider == config.CIProviderBitbucket,
+		"Gitea":     provider == config.CIProviderGitea,
+	})
+}
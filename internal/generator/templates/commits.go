@@ -0,0 +1,49 @@
+// internal/generator/templates/commits.go - Templates for the conventional commits setup
+package templates
+
+import (
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// commitTypes are the commit types of @commitlint/config-conventional; the
+// commitlint config and the commit-msg hook list the same types
+var commitTypes = []string{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"}
+
+// commitHeaderMaxLength is the longest commit header both checks accept
+const commitHeaderMaxLength = "72"
+
+// CommitlintConfigTemplate returns the content of the .commitlintrc.yml file
+func CommitlintConfigTemplate() (string, error) {
+	return render("commits_commitlint.tmpl", map[string]any{
+		"Types":     commitTypes,
+		"MaxLength": commitHeaderMaxLength,
+	})
+}
+
+// CommitMsgHookTemplate returns the content of the .githooks/commit-msg hook
+func CommitMsgHookTemplate() (string, error) {
+	return render("commits_commit_msg.tmpl", map[string]any{
+		"Types":     commitTypes,
+		"MaxLength": commitHeaderMaxLength,
+	})
+}
+
+// commitsContributingSection returns the CONTRIBUTING.md section on commit messages
+func commitsContributingSection(cfg config.ProjectConfig) (string, error) {
+	if !cfg.ConventionalCommits {
+		return "", nil
+	}
+	return render("commits_contributing.tmpl", map[string]any{
+		"Cfg":       cfg,
+		"Types":     commitTypes,
+		"MaxLength": commitHeaderMaxLength,
+	})
+}
+
+// commitsReadmeSection returns the README section on the commit conventions
+func commitsReadmeSection(cfg config.ProjectConfig) (string, error) {
+	if !cfg.ConventionalCommits {
+		return "", nil
+	}
+	return render("commits_readme.tmpl", nil)
+}
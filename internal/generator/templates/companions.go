@@ -0,0 +1,30 @@
+// internal/generator/templates/companions.go - Templates for companion modules
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// Markers guarding the companion replace directives in go.mod
+const (
+	CompanionReplacesBegin = "// BEGIN companion replaces (remove before release: make drop-replaces)"
+	CompanionReplacesEnd   = "// END companion replaces"
+)
+
+// ProjectGoWorkTemplate returns the content of the go.work file using the project,
+// its nested modules and its companions
+func ProjectGoWorkTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("companion_go_work.tmpl", map[string]any{"Cfg": cfg})
+}
+
+// CompanionReplacesTemplate returns the guarded replace directives appended to go.mod
+func CompanionReplacesTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("companion_replaces.tmpl", map[string]any{
+		"Cfg":   cfg,
+		"Begin": CompanionReplacesBegin,
+		"End":   CompanionReplacesEnd,
+	})
+}
+
+// CompanionGitignoreTemplate returns the .gitignore entries for the local go.work
+func CompanionGitignoreTemplate() (string, error) {
+	return render("companion_gitignore.tmpl", nil)
+}
@@ -0,0 +1,153 @@
+// internal/generator/templates/database.go - Database engine specifics shared by the templates
+package templates
+
+import (
+	"strings"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// dbEngine describes how the generated code talks to a database engine
+type dbEngine struct {
+	// Label is the display name of the engine
+	Label string
+	// DriverName is the database/sql driver name
+	DriverName string
+	// DriverImport is the import path registering the database/sql driver
+	DriverImport string
+	// DriverRequire is the go.mod requirement of the driver
+	DriverRequire string
+	// MigrateDriver is the golang-migrate database driver package
+	MigrateDriver string
+	// MigrateScheme is prepended to the connection string to form the golang-migrate URL
+	MigrateScheme string
+	// IDColumn is the definition of an auto-incrementing primary key column
+	IDColumn string
+	// IDType is the column type referencing such a key
+	IDType string
+	// ModelIDType is the Go type the model generator maps the key column to
+	ModelIDType string
+	// SemconvSystem is the OpenTelemetry semconv attribute identifying the engine on traced queries
+	SemconvSystem string
+}
+
+// dbEngines maps the database components to their engine details
+var dbEngines = map[string]dbEngine{
+	config.ComponentPostgres: {
+		Label:         "PostgreSQL",
+		DriverName:    "postgres",
+		DriverImport:  "github.com/lib/pq",
+		DriverRequire: "github.com/lib/pq v1.10.9",
+		MigrateDriver: "github.com/golang-migrate/migrate/v4/database/postgres",
+		IDColumn:      "SERIAL PRIMARY KEY",
+		IDType:        "INTEGER",
+		ModelIDType:   "int",
+		SemconvSystem: "DBSystemPostgreSQL",
+	},
+	config.ComponentMySQL: {
+		Label:         "MySQL",
+		DriverName:    "mysql",
+		DriverImport:  "github.com/go-sql-driver/mysql",
+		DriverRequire: "github.com/go-sql-driver/mysql v1.8.1",
+		MigrateDriver: "github.com/golang-migrate/migrate/v4/database/mysql",
+		MigrateScheme: "mysql://",
+		IDColumn:      "BIGINT AUTO_INCREMENT PRIMARY KEY",
+		IDType:        "BIGINT",
+		ModelIDType:   "int64",
+		SemconvSystem: "DBSystemMySQL",
+	},
+	config.ComponentSQLite: {
+		Label:         "SQLite",
+		DriverName:    "sqlite",
+		DriverImport:  "modernc.org/sqlite",
+		DriverRequire: "modernc.org/sqlite v1.34.4",
+		MigrateDriver: "github.com/golang-migrate/migrate/v4/database/sqlite",
+		MigrateScheme: "sqlite://",
+		IDColumn:      "INTEGER PRIMARY KEY AUTOINCREMENT",
+		IDType:        "INTEGER",
+		ModelIDType:   "int64",
+		SemconvSystem: "DBSystemSqlite",
+	},
+}
+
+// pgxEngine is PostgreSQL accessed with pgx instead of lib/pq: database/sql borrows
+// the connections of a pgxpool through the stdlib package, and golang-migrate
+// selects its pgx driver with the pgx5 URL scheme
+var pgxEngine = dbEngine{
+	Label:         "PostgreSQL",
+	DriverName:    "pgx",
+	DriverImport:  "github.com/jackc/pgx/v5/stdlib",
+	DriverRequire: "github.com/jackc/pgx/v5 v5.7.4",
+	MigrateDriver: "github.com/golang-migrate/migrate/v4/database/pgx/v5",
+	MigrateScheme: "pgx5://",
+	IDColumn:      "SERIAL PRIMARY KEY",
+	IDType:        "INTEGER",
+	ModelIDType:   "int",
+	SemconvSystem: "DBSystemPostgreSQL",
+}
+
+// databaseEngine returns the engine of the selected database, defaulting to PostgreSQL
+func databaseEngine(cfg config.ProjectConfig) dbEngine {
+	if cfg.Components.HasPgx() {
+		return pgxEngine
+	}
+	if engine, ok := dbEngines[cfg.Components.Database]; ok {
+		return engine
+	}
+	return dbEngines[config.ComponentPostgres]
+}
+
+// DatabaseLabel returns the display name of the selected database engine
+func DatabaseLabel(cfg config.ProjectConfig) string {
+	return databaseEngine(cfg).Label
+}
+
+// DatabaseConnectionString returns the connection string of the project database;
+// docker selects the docker-compose service as the host instead of localhost
+func DatabaseConnectionString(cfg config.ProjectConfig, docker bool) string {
+	host := "localhost"
+	if docker {
+		host = cfg.Components.Database
+	}
+
+	switch cfg.Components.Database {
+	case config.ComponentMySQL:
+		return "mysql:mysql@tcp(" + host + ":3306)/" + cfg.ProjectName + "?parseTime=true"
+	case config.ComponentSQLite:
+		// SQLite is a file next to the binary; docker-compose mounts data/ as a volume
+		return "data/" + cfg.ProjectName + ".db?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	}
+	return "postgres://postgres:postgres@" + host + ":5432/" + cfg.ProjectName + "?sslmode=disable"
+}
+
+// DatabaseEnv returns the environment variable of a database setting, such as
+// DB_CONNECTION_STRING; with named connections it is prefixed with the name of
+// the connection, the main one when name is empty: DB_MAIN_CONNECTION_STRING
+func DatabaseEnv(cfg config.ProjectConfig, name, setting string) string {
+	if !cfg.HasNamedDatabases() {
+		return "DB_" + setting
+	}
+	if name == "" {
+		name = cfg.Databases[0]
+	}
+	return "DB_" + strings.ToUpper(name) + "_" + setting
+}
+
+// mainDatabaseConstant returns the Go expression naming the main connection
+// in the generated db package, e.g. db.Main
+func mainDatabaseConstant(cfg config.ProjectConfig) string {
+	return "db." + config.DatabaseConstant(cfg.Databases[0])
+}
+
+// connectionStringEnvs returns the connection string variables of every database
+// connection, main one first
+func connectionStringEnvs(cfg config.ProjectConfig) []string {
+	if !cfg.HasNamedDatabases() {
+		return []string{DatabaseEnv(cfg, "", "CONNECTION_STRING")}
+	}
+	envs := make([]string, len(cfg.Databases))
+	for i, name := range cfg.Databases {
+		envs[i] = DatabaseEnv(cfg, name, "CONNECTION_STRING")
+	}
+	return envs
+}
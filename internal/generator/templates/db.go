@@ -1,247 +1,191 @@
 // internal/generator/templates/db.go - Templates for database files
 package templates
 
-// DBTemplate returns the content of the db.go file
-func DBTemplate() string {
-	return `// internal/db/db.go - Database connection and management
-package db
-
 import (
-	"context"
-	"fmt"
-	"time"
+	"sort"
+	"strings"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
-
-	"{{ .ModuleName }}/internal/logger"
+	"github.com/neor-it/go-project-gen/internal/config"
 )
 
-// Database represents a database connection
-type Database struct {
-	log        logger.Logger
-	connString string
-	db         *sqlx.DB
-}
-
-// NewDatabase creates a new database connection
-func NewDatabase(log logger.Logger, connString string) (*Database, error) {
-	return &Database{
-		log:        log,
-		connString: connString,
-	}, nil
-}
-
-// Connect connects to the database
-func (d *Database) Connect() error {
-	d.log.Info("Connecting to database")
-
-	// Connect to database
-	db, err := sqlx.Connect("postgres", d.connString)
-	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+// DBTemplate returns the content of the db.go file
+func DBTemplate(cfg config.ProjectConfig) (string, error) {
+	engine := databaseEngine(cfg)
+	named := cfg.HasNamedDatabases()
+
+	// Named connections take their connection string, pool and timeout from their settings
+	connString := "d.connString"
+	statementTimeout := "d.statementTimeout"
+	poolSettings := "d.poolSettings"
+	if named {
+		connString = "d.settings.ConnectionString"
+		statementTimeout = "d.settings.StatementTimeout"
+		poolSettings = "d.settings"
 	}
 
-	// Configure connection pool
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
-
-	// Set database connection
-	d.db = db
-
-	d.log.Info("Connected to database")
-	return nil
-}
-
-// Close closes the database connection
-func (d *Database) Close() error {
-	if d.db != nil {
-		d.log.Info("Closing database connection")
-		return d.db.Close()
+	// PostgreSQL also enforces the statement timeout on the sessions
+	if cfg.Components.Database == config.ComponentPostgres {
+		connString = "withStatementTimeout(" + connString + ", " + statementTimeout + ")"
 	}
-	return nil
-}
-
-// Ping pings the database
-func (d *Database) Ping(ctx context.Context) error {
-	return d.db.PingContext(ctx)
-}
-
-// GetDB returns the database connection
-func (d *Database) GetDB() *sqlx.DB {
-	return d.db
-}
-`
-}
-
-// UserModelTemplate returns the template for a User model
-func UserModelTemplate() string {
-	return `// internal/db/models/users.go - User model
-package models
 
-import (
-	"time"
-)
-
-// User represents the users table
-type User struct {
-	Id 	 int        ` + "`db:\"id\" json:\"id\"`" + `
-	Username string ` + "`db:\"username\" json:\"username\"`" + `
-	Email    string ` + "`db:\"email\" json:\"email\"`" + `
-	Password string ` + "`db:\"password\" json:\"-\"`" + `
-	CreatedAt time.Time  ` + "`db:\"created_at\" json:\"created_at\"`" + `
-	UpdatedAt time.Time  ` + "`db:\"updated_at\" json:\"updated_at\"`" + `
-}
-
-// TableName returns the table name for User
-func (u *User) TableName() string {
-	return "users"
-}
-`
-}
-
-// DBRepositoriesTemplate returns the content of the repositories.go file
-func DBRepositoriesTemplate() string {
-	return `// internal/db/repositories/repositories.go - Database repositories
-package repositories
-
-import (
-	"context"
-	"database/sql"
-	"errors"
-	"fmt"
-	"time"
-
-	"github.com/jmoiron/sqlx"
-
-	"{{ .ModuleName }}/internal/db/models"
-	"{{ .ModuleName }}/internal/logger"
-)
-
-// UserRepository represents a repository for users
-type UserRepository struct {
-	log logger.Logger
-	db  *sqlx.DB
-}
-
-// NewUserRepository creates a new user repository
-func NewUserRepository(log logger.Logger, db *sqlx.DB) *UserRepository {
-	return &UserRepository{
-		log: log,
-		db:  db,
+	thirdParty := []string{
+		`"github.com/jmoiron/sqlx"`,
+		`_ "` + engine.DriverImport + `"`,
 	}
-}
-
-// GetByID gets a user by ID
-func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
-	var user models.User
-	query := "SELECT * FROM users WHERE id = $1"
-	err := r.db.GetContext(ctx, &user, query, id)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
+	if cfg.Components.HasPgx() {
+		thirdParty = []string{
+			`"github.com/jackc/pgx/v5/pgxpool"`,
+			`"github.com/jackc/pgx/v5/stdlib"`,
+			`"github.com/jmoiron/sqlx"`,
 		}
-		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
-	return &user, nil
-}
-
-// Create creates a new user
-func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
-	now := time.Now()
-	user.CreatedAt = now
-	user.UpdatedAt = now
-
-	query := ` + "`" + `
-		INSERT INTO users (username, email, password, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id
-	` + "`" + `
-
-	err := r.db.QueryRowContext(
-		ctx,
-		query,
-		user.Username,
-		user.Email,
-		user.Password,
-		user.CreatedAt,
-		user.UpdatedAt,
-	).Scan(&user.ID)
+	if cfg.Components.Tracing {
+		thirdParty = append(thirdParty,
+			`"github.com/XSAM/otelsql"`,
+			`semconv "go.opentelemetry.io/otel/semconv/v1.26.0"`,
+		)
+	}
 
-	if err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
+	// Every connection gets a constant, so repositories are wired to a name the compiler checks
+	constants := make([][2]string, len(cfg.Databases))
+	names := make([]string, len(cfg.Databases))
+	for i, name := range cfg.Databases {
+		constants[i] = [2]string{config.DatabaseConstant(name), `= "` + name + `"`}
+		names[i] = constants[i][0]
 	}
 
-	return nil
+	return render("db.tmpl", map[string]any{
+		"Cfg":        cfg,
+		"Engine":     engine,
+		"Named":      named,
+		"Postgres":   cfg.Components.Database == config.ComponentPostgres,
+		"SQLite":     cfg.Components.Database == config.ComponentSQLite,
+		"Pgx":        cfg.Components.HasPgx(),
+		"ThirdParty": importLines(thirdParty),
+		"ConnString": connString,
+		"Pool":       poolSettings,
+		"Constants":  alignedLines("\t", "", constants),
+		"Names":      names,
+	})
+}
+
+// DBDatabasesTemplate returns the content of the databases.go file, which manages
+// the named database connections
+func DBDatabasesTemplate() (string, error) {
+	return render("db_databases.tmpl", nil)
+}
+
+// DBDatabasesTestTemplate returns the content of the databases_test.go file
+func DBDatabasesTestTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("db_databases_test.tmpl", map[string]any{
+		"Main": config.DatabaseConstant(cfg.Databases[0]),
+	})
+}
+
+// DBTestTemplate returns the content of the db_test.go file, which tests the
+// transactions of InTx on sqlmock; like the repository tests, it only exists
+// for PostgreSQL
+func DBTestTemplate(cfg config.ProjectConfig) (string, error) {
+	if cfg.Components.Database != config.ComponentPostgres {
+		return "", nil
+	}
+	return render("db_test.tmpl", map[string]any{"DriverName": databaseEngine(cfg).DriverName})
 }
 
-// Update updates a user
-func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
-	user.UpdatedAt = time.Now()
-
-	query := ` + "`" + `
-		UPDATE users
-		SET username = $1, email = $2, updated_at = $3
-		WHERE id = $4
-	` + "`" + `
-
-	result, err := r.db.ExecContext(
-		ctx,
-		query,
-		user.Username,
-		user.Email,
-		user.UpdatedAt,
-		user.ID,
-	)
-
+// DBMigrateTemplate returns the content of the migrate.go file, which applies the
+// embedded migrations at startup when DB_AUTO_MIGRATE is set
+func DBMigrateTemplate(cfg config.ProjectConfig) (string, error) {
+	migrateURL, imports, err := migrateURLFunc(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+		return "", err
 	}
+	imports = append(imports, "errors", "fmt")
+	sort.Strings(imports)
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+	stdImports := ""
+	for _, pkg := range imports {
+		stdImports += "\t\"" + pkg + "\"\n"
 	}
 
-	if rowsAffected == 0 {
-		return errors.New("user not found")
+	return render("db_migrate.tmpl", map[string]any{
+		"StdImports":    stdImports,
+		"MigrateDriver": databaseEngine(cfg).MigrateDriver,
+		"MigrateURL":    migrateURL,
+	})
+}
+
+// UserModelTemplate returns the template for a User model; the ID type matches
+// what the model generator produces for the selected database. With sqlc the
+// model is the struct sqlc generates.
+func UserModelTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("db_user_model.tmpl", map[string]any{
+		"SQLC":   cfg.Components.HasSQLC(),
+		"IDType": databaseEngine(cfg).ModelIDType,
+	})
+}
+
+// DBGuardTemplate returns the content of the repositories/guard.go file
+func DBGuardTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("db_guard.tmpl", map[string]any{
+		// Timed-out queries are counted when the service exposes metrics
+		"Metrics": cfg.Components.Metrics,
+	})
+}
+
+// DBRepositoriesTemplate returns the content of the repositories.go file. The user
+// queries are written with sqlx, or generated by sqlc from the query files; MySQL
+// has no RETURNING clause, so the IDs come from the insert results instead.
+func DBRepositoriesTemplate(cfg config.ProjectConfig) (string, error) {
+	// sqlc generates int IDs for the int4 key of the users table
+	idType := databaseEngine(cfg).ModelIDType
+	if cfg.Components.HasSQLC() {
+		idType = "int"
 	}
 
-	return nil
+	return render("db_repositories.tmpl", map[string]any{
+		"SQLC":   cfg.Components.HasSQLC(),
+		"MySQL":  cfg.Components.Database == config.ComponentMySQL,
+		"IDType": idType,
+	})
 }
 
-// Delete deletes a user
-func (r *UserRepository) Delete(ctx context.Context, id int64) error {
-	now := time.Now()
-
-	query := "UPDATE users SET WHERE id = $1"
-	result, err := r.db.ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+// DBRepositoriesTestTemplate returns the content of the repositories_test.go
+// file; the tests replace PostgreSQL with sqlmock, so they only exist for it
+func DBRepositoriesTestTemplate(cfg config.ProjectConfig) (string, error) {
+	if cfg.Components.Database != config.ComponentPostgres {
+		return "", nil
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		return errors.New("user not found")
+	// With sqlc the repository runs the generated Queries on the same connection,
+	// and sqlc expands the columns of SELECT *; streaming still goes through sqlx
+	query := "SELECT *"
+	if cfg.Components.HasSQLC() {
+		query = "SELECT id, username, email, password, created_at, updated_at"
 	}
 
-	return nil
+	return render("db_repositories_test.tmpl", map[string]any{
+		"SQLC":       cfg.Components.HasSQLC(),
+		"DriverName": databaseEngine(cfg).DriverName,
+		"Select":     query,
+	})
 }
 
-// List lists all users
-func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
-	var users []*models.User
-	query := "SELECT * FROM users WHERE ORDER BY id LIMIT $1 OFFSET $2"
-	err := r.db.SelectContext(ctx, &users, query, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list users: %w", err)
+// importLines renders import specs, such as "path" or name "path", one per line
+// and sorted by path, as gofmt does
+func importLines(specs []string) string {
+	sorted := append([]string(nil), specs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return importPath(sorted[i]) < importPath(sorted[j])
+	})
+
+	lines := ""
+	for _, spec := range sorted {
+		lines += "\t" + spec + "\n"
 	}
-	return users, nil
+	return lines
 }
-`
+
+// importPath returns the quoted path of an import spec, without its name
+func importPath(spec string) string {
+	return spec[strings.Index(spec, `"`):]
 }
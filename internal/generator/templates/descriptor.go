@@ -0,0 +1,111 @@
+// internal/generator/templates/descriptor.go - Templates for the service descriptor and the OpenAPI document
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// descriptorFramework holds the framework-specific parts of handlers/descriptor.go
+type descriptorFramework struct {
+	// Import is the framework import, empty for net/http, and RouterParam is the
+	// parameter of Register
+	Import      string
+	RouterParam string
+	// Routes registers the Descriptor handler on DescriptorPath and the OpenAPI
+	// handler on openapi.Path
+	Routes string
+	// HandlerSignature is the parameter list and result of a handler
+	HandlerSignature string
+	// Respond writes the body %[2]s as JSON with the status %[1]s, returning from
+	// the handler if needed
+	Respond string
+	// RespondBytes writes the bytes %[3]s with status %[1]s and content type %[2]s
+	RespondBytes string
+}
+
+// netHTTPRespondBytes writes bytes with a status and a content type from a net/http handler
+const netHTTPRespondBytes = `w.Header().Set("Content-Type", %[2]s)
+	w.WriteHeader(%[1]s)
+	_, _ = w.Write(%[3]s)`
+
+// APIDescriptorHandlerTemplate returns the content of the handlers/descriptor.go file;
+// the metadata recorded at generation is shared and only the thin handlers depend
+// on the framework
+func APIDescriptorHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	// The links point at the operational endpoints of the selected components
+	links := [][2]string{
+		{"Docs", "basePath + openapi.Path"},
+		{"Health", `basePath + "/health"`},
+		{"Ready", `basePath + "/ready"`},
+	}
+	if cfg.Components.Metrics {
+		links = append(links, [2]string{"Metrics", `basePath + "/metrics"`})
+	}
+	linkFields := make([][2]string, len(links))
+	for i, link := range links {
+		linkFields[i] = [2]string{link[0], "string `json:\"" + strings.ToLower(link[0]) + "\"`"}
+	}
+
+	return render("api_descriptor.tmpl", map[string]any{
+		"Cfg":        cfg,
+		"Framework":  frameworkFor(cfg).Descriptor,
+		"Owners":     quotedList(cfg.ServiceOwners()),
+		"LinkFields": alignedLines("\t", "", linkFields),
+		"Links":      alignedLines("\t\t\t", ":", links),
+	})
+}
+
+// quotedList returns values as a comma-separated list of Go string literals,
+// which are JSON strings as well since the values are printable
+func quotedList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// serviceDescriptorFixture returns the golden service descriptor of the handler
+// tests, served by the test router with the version testVersion and no base path
+func serviceDescriptorFixture(cfg config.ProjectConfig) (string, error) {
+	links := map[string]string{
+		"docs":   "/openapi.yaml",
+		"health": "/health",
+		"ready":  "/ready",
+	}
+	if cfg.Components.Metrics {
+		links["metrics"] = "/metrics"
+	}
+	content, err := json.MarshalIndent(map[string]any{
+		"name":        cfg.ProjectName,
+		"version":     "1.2.3",
+		"description": cfg.ServiceDescription(),
+		"owners":      cfg.ServiceOwners(),
+		"api_version": "v1",
+		"links":       links,
+	}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the service descriptor fixture: %w", err)
+	}
+	return string(content) + "\n", nil
+}
+
+// APIOpenAPITemplate returns the content of the internal/api/openapi/openapi.yaml
+// file, documenting the routes of the selected components
+func APIOpenAPITemplate(cfg config.ProjectConfig) (string, error) {
+	return render("api_openapi.tmpl", map[string]any{
+		"Cfg":         cfg,
+		"Database":    cfg.Components.HasDatabase(),
+		"Description": cfg.ServiceDescription(),
+	})
+}
+
+// APIOpenAPIEmbedTemplate returns the content of the internal/api/openapi/openapi.go
+// file embedding the document
+func APIOpenAPIEmbedTemplate() (string, error) {
+	return render("api_openapi_embed.tmpl", nil)
+}
@@ -0,0 +1,43 @@
+// internal/generator/templates/dev.go - Templates for local development tooling
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// AirConfigTemplate returns the content of the .air.toml live-reload configuration
+func AirConfigTemplate(cfg config.ProjectConfig) (string, error) {
+	// Build with the tags selecting the JSON engine of Gin, like make build
+	build := "go build"
+	if tags := cfg.Components.BuildTags(); tags != "" {
+		build += " -tags " + tags
+	}
+
+	return render("dev_air.tmpl", map[string]any{
+		"Cfg":   cfg,
+		"Build": build,
+	})
+}
+
+// DevScriptTemplate returns the content of the scripts/dev.sh live-reload runner
+func DevScriptTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("dev_script.tmpl", map[string]any{"Cfg": cfg})
+}
+
+// ContributingTemplate returns the content of the CONTRIBUTING.md file
+func ContributingTemplate(cfg config.ProjectConfig) (string, error) {
+	commits, err := commitsContributingSection(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	// The tests run with the build tags selecting the JSON engine of Gin
+	testCommand := "go test ./..."
+	if tags := cfg.Components.BuildTags(); tags != "" {
+		testCommand = "go test -tags " + tags + " ./..."
+	}
+
+	return render("dev_contributing.tmpl", map[string]any{
+		"Cfg":         cfg,
+		"TestCommand": testCommand,
+		"Commits":     commits,
+	})
+}
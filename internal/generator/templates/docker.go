@@ -4,141 +4,43 @@ package templates
 import "github.com/neor-it/go-project-gen/internal/config"
 
 // DockerfileTemplate returns the content of the Dockerfile
-func DockerfileTemplate(cfg config.ProjectConfig) string {
-	return `# Build stage
-FROM golang:1.23-alpine AS builder
-
-# Set working directory
-WORKDIR /app
-
-# Copy go.mod and go.sum
-COPY go.mod ./
-COPY go.sum ./
-
-# Download dependencies
-RUN go mod download
-
-# Copy source code
-COPY . .
-
-# Build application
-RUN CGO_ENABLED=0 GOOS=linux go build -o /app/bin/` + cfg.ProjectName + ` main.go
-
-# Final stage
-FROM alpine:latest
-
-# Set working directory
-WORKDIR /app
-
-# Install necessary packages
-RUN apk --no-cache add ca-certificates tzdata
-
-# Copy binary from builder
-COPY --from=builder /app/bin/` + cfg.ProjectName + ` .
-
-# Copy .env file
-COPY --from=builder /app/.env.example /app/.env
-
-# Set environment variables
-ENV TZ=UTC
-
-# Expose port
-EXPOSE 8080
-
-# Run application
-CMD ["./` + cfg.ProjectName + `"]
-`
+func DockerfileTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("docker_dockerfile.tmpl", map[string]any{
+		"Cfg":    cfg,
+		"SQLite": cfg.Components.Database == config.ComponentSQLite,
+	})
 }
 
 // DockerComposeTemplate returns the content of the docker-compose.yml file
-func DockerComposeTemplate(cfg config.ProjectConfig) string {
-	// Base docker-compose.yml
-	compose := `version: '3.8'
-
-services:
-  app:
-    build:
-      context: .
-      dockerfile: Dockerfile
-    container_name: ` + cfg.ProjectName + `
-    restart: unless-stopped
-    env_file:
-      - .env
-    ports:
-      - "8080:8080"
-`
-
-	// Add Postgres service if needed
-	if cfg.Components.Postgres {
-		compose += `
-  postgres:
-    image: postgres:16-alpine
-    container_name: ` + cfg.ProjectName + `-postgres
-    restart: unless-stopped
-    environment:
-      - POSTGRES_USER=postgres
-      - POSTGRES_PASSWORD=postgres
-      - POSTGRES_DB=` + cfg.ProjectName + `
-      - TZ=UTC
-    ports:
-      - "5432:5432"
-    volumes:
-      - postgres_data:/var/lib/postgresql/data
-
-volumes:
-  postgres_data:
-`
-	}
-
-	return compose
+func DockerComposeTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("docker_compose.tmpl", map[string]any{
+		"Cfg":      cfg,
+		"Postgres": cfg.Components.Database == config.ComponentPostgres,
+		"MySQL":    cfg.Components.Database == config.ComponentMySQL,
+		"SQLite":   cfg.Components.Database == config.ComponentSQLite,
+	})
 }
 
 // DockerignoreTemplate returns the content of the .dockerignore file
-func DockerignoreTemplate() string {
-	return `# Git
-.git
-.gitignore
-
-# Docker
-Dockerfile
-docker-compose.yml
-.dockerignore
-
-# IDE
-.idea
-.vscode
-
-# Binaries
-bin/
-*.exe
-*.exe~
-*.dll
-*.so
-*.dylib
-
-# Tests
-*_test.go
-*.test
-
-# Build
-.build/
-
-# Misc
-*.md
-LICENSE
-README.md
-
-# Environment variables
-.env.local
+func DockerignoreTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("docker_dockerignore.tmpl", map[string]any{
+		"Cfg":      cfg,
+		"CertsDir": devCertsDir,
+	})
+}
 
-# Temporary files
-tmp/
-temp/
+// PrometheusConfigTemplate returns the content of the prometheus.yml file
+func PrometheusConfigTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("docker_prometheus.tmpl", map[string]any{"Cfg": cfg})
+}
 
-# Log files
-*.log
+// DockerTuningTemplate returns the content of the internal/tuning/tuning.go file,
+// which fits GOMAXPROCS and the soft memory limit to the container
+func DockerTuningTemplate() (string, error) {
+	return render("docker_tuning.tmpl", nil)
+}
 
-# OS specific files
-.DS_Store
-`
+// DockerTuningTestTemplate returns the content of the internal/tuning/tuning_test.go file
+func DockerTuningTestTemplate() (string, error) {
+	return render("docker_tuning_test.tmpl", nil)
 }
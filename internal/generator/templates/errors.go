@@ -0,0 +1,117 @@
+// internal/generator/templates/errors.go - Structured errors for template rendering
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"go/scanner"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// snippetContext is the number of template lines shown before and after the offending line
+const snippetContext = 2
+
+// templateLineRegex extracts the line number from text/template parse and execution errors,
+// which are formatted as "template: <name>:<line>[:<col>]: ..."
+var templateLineRegex = regexp.MustCompile(`template: [^:]+:(\d+)(?::\d+)?:`)
+
+// TemplateError describes a template that failed to parse or execute, or
+// rendered Go code that failed to format
+type TemplateError struct {
+	// Template is the name of the template
+	Template string
+	// Path is the output path the template was rendered for, empty for the
+	// template files of render, which don't know it
+	Path string
+	// Phase is "parse", "execute" or "format"
+	Phase string
+	// Line is the offending template line, or the line of the rendered code
+	// when formatting, or 0 if unknown
+	Line int
+	// Snippet is the source surrounding the offending line
+	Snippet string
+	// Err is the underlying text/template or Go syntax error
+	Err error
+}
+
+// Error implements the error interface
+func (e *TemplateError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to %s template %s", e.Phase, e.Template)
+	if e.Path != "" {
+		fmt.Fprintf(&b, " for %s", e.Path)
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&b, " at line %d", e.Line)
+	}
+	fmt.Fprintf(&b, ": %v", e.Err)
+	if e.Snippet != "" {
+		b.WriteString("\n")
+		b.WriteString(e.Snippet)
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying error
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// NewTemplateError builds a TemplateError with the offending line and surrounding source;
+// for a format error, source is the rendered code
+func NewTemplateError(name, path, phase, source string, err error) *TemplateError {
+	tmplErr := &TemplateError{
+		Template: name,
+		Path:     path,
+		Phase:    phase,
+		Err:      err,
+	}
+
+	// Execution errors carry the failing template name; the message holds the position
+	var execErr template.ExecError
+	message := err.Error()
+	if errors.As(err, &execErr) {
+		message = execErr.Err.Error()
+	}
+
+	// Syntax errors of the rendered Go code carry their position
+	var syntaxErrs scanner.ErrorList
+	if errors.As(err, &syntaxErrs) && len(syntaxErrs) > 0 {
+		tmplErr.Line = syntaxErrs[0].Pos.Line
+		tmplErr.Snippet = sourceSnippet(source, tmplErr.Line)
+		return tmplErr
+	}
+
+	if match := templateLineRegex.FindStringSubmatch(message); match != nil {
+		if line, convErr := strconv.Atoi(match[1]); convErr == nil {
+			tmplErr.Line = line
+			tmplErr.Snippet = sourceSnippet(source, line)
+		}
+	}
+
+	return tmplErr
+}
+
+// sourceSnippet returns the lines around the given line, marking the line itself
+func sourceSnippet(source string, line int) string {
+	lines := strings.Split(source, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	first := max(line-snippetContext, 1)
+	last := min(line+snippetContext, len(lines))
+
+	var b strings.Builder
+	for i := first; i <= last; i++ {
+		marker := "  "
+		if i == line {
+			marker = "> "
+		}
+		fmt.Fprintf(&b, "%s%4d | %s\n", marker, i, lines[i-1])
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
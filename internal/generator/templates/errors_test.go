@@ -0,0 +1,173 @@
+package templates
+
+import (
+	"errors"
+	"go/format"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestTemplateLineRegex(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{name: "parse error", message: `template: main.go:12: unexpected EOF`, want: "12"},
+		{name: "execute error with column", message: `template: main.go:7:15: executing "main.go" at <.Missing>: map has no entry for key "Missing"`, want: "7"},
+		{name: "template file", message: `template: tmpls/app.tmpl:3: function "nope" not defined`, want: "3"},
+		{name: "no position", message: "connection refused", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			if match := templateLineRegex.FindStringSubmatch(tt.message); match != nil {
+				got = match[1]
+			}
+			if got != tt.want {
+				t.Errorf("line of %q = %q, want %q", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+// parseError parses source as a template named name and returns the error
+func parseError(name, source string) error {
+	_, err := template.New(name).Parse(source)
+	return err
+}
+
+// executeError executes source as a template named name without any data and returns the error
+func executeError(name, source string) error {
+	tmpl := template.Must(template.New(name).Option("missingkey=error").Parse(source))
+	return tmpl.Execute(&strings.Builder{}, map[string]any{})
+}
+
+func TestNewTemplateError(t *testing.T) {
+	tests := []struct {
+		name        string
+		phase       string
+		source      string
+		err         func(source string) error
+		wantLine    int
+		wantSnippet string
+	}{
+		{
+			name:        "unclosed action",
+			phase:       "parse",
+			source:      "package main\n\nfunc {{ .Name }\n\nfunc other() {}\n",
+			err:         func(source string) error { return parseError("main.go", source) },
+			wantLine:    3,
+			wantSnippet: ">    3 | func {{ .Name }",
+		},
+		{
+			name:        "unknown function",
+			phase:       "parse",
+			source:      "package main\n\n// {{ shout .Name }}\n",
+			err:         func(source string) error { return parseError("main.go", source) },
+			wantLine:    3,
+			wantSnippet: ">    3 | // {{ shout .Name }}",
+		},
+		{
+			name:        "missing key",
+			phase:       "execute",
+			source:      "module example.com/demo\n\ngo {{ .GoVersion }}\n",
+			err:         func(source string) error { return executeError("go.mod", source) },
+			wantLine:    3,
+			wantSnippet: ">    3 | go {{ .GoVersion }}",
+		},
+		{
+			name:   "rendered code that is not Go",
+			phase:  "format",
+			source: "package main\n\nfunc main() {\n\treturn )\n}\n",
+			err: func(source string) error {
+				_, err := format.Source([]byte(source))
+				return err
+			},
+			wantLine:    4,
+			wantSnippet: ">    4 | \treturn )",
+		},
+		{
+			name:   "error without a position",
+			phase:  "execute",
+			source: "package main\n",
+			err:    func(string) error { return errors.New("write failed") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err(tt.source)
+			if err == nil {
+				t.Fatal("the broken template didn't fail")
+			}
+
+			tmplErr := NewTemplateError("main.go", "demo/main.go", tt.phase, tt.source, err)
+			if tmplErr.Phase != tt.phase {
+				t.Errorf("Phase = %q, want %q", tmplErr.Phase, tt.phase)
+			}
+			if tmplErr.Line != tt.wantLine {
+				t.Errorf("Line = %d, want %d", tmplErr.Line, tt.wantLine)
+			}
+			if tt.wantSnippet == "" {
+				if tmplErr.Snippet != "" {
+					t.Errorf("Snippet = %q, want none", tmplErr.Snippet)
+				}
+			} else if !strings.Contains(tmplErr.Snippet, tt.wantSnippet) {
+				t.Errorf("Snippet = %q, want it to mark %q", tmplErr.Snippet, tt.wantSnippet)
+			}
+			// A scanner.ErrorList isn't comparable, so errors.Is can't find it
+			if unwrapped := tmplErr.Unwrap(); unwrapped == nil || unwrapped.Error() != err.Error() {
+				t.Errorf("Unwrap() = %v, want %v", unwrapped, err)
+			}
+		})
+	}
+}
+
+func TestTemplateErrorMessage(t *testing.T) {
+	source := "package main\n\nfunc main() {\n\t{{ .Body }}\n}\n"
+	tmplErr := NewTemplateError("main.go", "demo/main.go", "execute", source, executeError("main.go", source))
+
+	message := tmplErr.Error()
+	for _, want := range []string{
+		"failed to execute template main.go for demo/main.go at line 4",
+		`map has no entry for key "Body"`,
+		">    4 | \t{{ .Body }}",
+		"     3 | func main() {",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Error() = %q, want it to contain %q", message, want)
+		}
+	}
+
+	// The template files of render have no output path
+	tmplErr.Path = ""
+	if message := tmplErr.Error(); !strings.HasPrefix(message, "failed to execute template main.go at line 4: ") {
+		t.Errorf("Error() = %q, want no output path", message)
+	}
+}
+
+func TestSourceSnippet(t *testing.T) {
+	source := "one\ntwo\nthree\nfour\nfive\nsix"
+
+	tests := []struct {
+		name string
+		line int
+		want string
+	}{
+		{name: "first line", line: 1, want: ">    1 | one\n     2 | two\n     3 | three"},
+		{name: "middle line", line: 4, want: "     2 | two\n     3 | three\n>    4 | four\n     5 | five\n     6 | six"},
+		{name: "past the end", line: 7, want: ""},
+		{name: "unknown line", line: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sourceSnippet(source, tt.line); got != tt.want {
+				t.Errorf("sourceSnippet(%d) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,796 @@
+// internal/generator/templates/gomod_offline.go - go.mod of the projects generated offline
+package templates
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// OfflineGoModTemplate returns the content of the go.mod file of a project generated
+// without network access, where go mod tidy cannot run: the requirements of
+// GoModTemplate, raised to the versions their module graphs select, and the indirect
+// requirements tidy would add for them, so the project builds from a module cache
+func OfflineGoModTemplate(cfg config.ProjectConfig) (string, error) {
+	direct := map[string]string{}
+	for _, require := range goModRequires(cfg) {
+		path, version, _ := strings.Cut(require, " ")
+		direct[path] = version
+	}
+
+	// Like the minimal version selection of go, a module gets the highest version
+	// required for it; raising a requirement can raise others, until none changes
+	for changed := true; changed; {
+		changed = false
+		for path, version := range direct {
+			requirements := offlineRequires[path+" "+version]
+			for _, require := range append(requirements.indirect, requirements.raises...) {
+				if raiseRequire(direct, require, false) {
+					changed = true
+				}
+			}
+		}
+	}
+
+	indirect := map[string]string{}
+	for path, version := range direct {
+		for _, require := range offlineRequires[path+" "+version].indirect {
+			if _, ok := direct[requirePath(require)]; !ok {
+				raiseRequire(indirect, require, true)
+			}
+		}
+	}
+	for path, version := range direct {
+		for _, require := range offlineRequires[path+" "+version].raises {
+			raiseRequire(indirect, require, false)
+		}
+	}
+
+	return render("gomod_offline.tmpl", map[string]any{
+		"Cfg":      cfg,
+		"Direct":   requireLines(direct),
+		"Indirect": requireLines(indirect),
+	})
+}
+
+// requirePath returns the module path of a "path version" requirement
+func requirePath(require string) string {
+	path, _, _ := strings.Cut(require, " ")
+	return path
+}
+
+// raiseRequire raises the version of the module of require in versions to the one of
+// require, adding the module when add is set, and reports whether it changed
+func raiseRequire(versions map[string]string, require string, add bool) bool {
+	path, version, _ := strings.Cut(require, " ")
+	current, ok := versions[path]
+	if (!ok && !add) || semver.Compare(version, current) <= 0 {
+		return false
+	}
+	versions[path] = version
+	return true
+}
+
+// requireLines returns the lines of a require block, sorted by module path like go mod tidy does
+func requireLines(versions map[string]string) []string {
+	paths := make([]string, 0, len(versions))
+	for path := range versions {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	lines := make([]string, len(paths))
+	for i, path := range paths {
+		lines[i] = path + " " + versions[path]
+	}
+	return lines
+}
+
+// moduleRequirements are the requirements a module brings into go.mod
+type moduleRequirements struct {
+	// indirect are the requirements go mod tidy adds for the packages the project
+	// imports from the module, at the versions of its module graph
+	indirect []string
+	// raises are the versions its module graph selects for the modules go.mod may
+	// list, when higher than in indirect or missing from it
+	raises []string
+}
+
+// offlineRequires maps the requirements of GoModTemplate, and the versions other
+// requirements raise them to, to the requirements they bring. The database drivers
+// include the golang-migrate driver of their engine. When changing a version, update
+// its entry from go mod tidy and go list -m all run in a module requiring only that
+// version and importing the packages the project imports from it.
+var offlineRequires = map[string]moduleRequirements{
+	"aidanwoods.dev/go-paseto v1.5.4": {
+		indirect: []string{
+			"aidanwoods.dev/go-result v0.3.1",
+			"golang.org/x/crypto v0.33.0",
+			"golang.org/x/sys v0.30.0",
+		},
+		raises: []string{
+			"golang.org/x/net v0.21.0",
+			"golang.org/x/text v0.22.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"github.com/XSAM/otelsql v0.36.0": {
+		indirect: []string{
+			"github.com/go-logr/logr v1.4.2",
+			"github.com/go-logr/stdr v1.2.2",
+			"go.opentelemetry.io/auto/sdk v1.1.0",
+			"go.opentelemetry.io/otel v1.33.0",
+			"go.opentelemetry.io/otel/metric v1.33.0",
+			"go.opentelemetry.io/otel/trace v1.33.0",
+		},
+		raises: []string{
+			"github.com/google/uuid v1.6.0",
+			"github.com/kr/text v0.2.0",
+			"go.opentelemetry.io/otel/sdk v1.33.0",
+			"golang.org/x/sys v0.28.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"github.com/bytedance/sonic v1.15.4": {
+		indirect: []string{
+			"github.com/bytedance/gopkg v0.1.3",
+			"github.com/bytedance/sonic/loader v0.5.2",
+			"github.com/cloudwego/base64x v0.1.6",
+			"github.com/klauspost/cpuid/v2 v2.2.9",
+			"github.com/twitchyliquid64/golang-asm v0.15.1",
+			"golang.org/x/arch v0.0.0-20210923205945-b76863e36670",
+			"golang.org/x/sys v0.22.0",
+		},
+		raises: []string{
+			"golang.org/x/net v0.24.0",
+			"golang.org/x/text v0.14.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"github.com/gin-contrib/cors v1.7.3": {
+		indirect: []string{
+			"github.com/bytedance/sonic v1.12.6",
+			"github.com/bytedance/sonic/loader v0.2.1",
+			"github.com/cloudwego/base64x v0.1.4",
+			"github.com/cloudwego/iasm v0.2.0",
+			"github.com/gabriel-vasile/mimetype v1.4.7",
+			"github.com/gin-contrib/sse v0.1.0",
+			"github.com/gin-gonic/gin v1.10.0",
+			"github.com/go-playground/locales v0.14.1",
+			"github.com/go-playground/universal-translator v0.18.1",
+			"github.com/go-playground/validator/v10 v10.23.0",
+			"github.com/goccy/go-json v0.10.4",
+			"github.com/json-iterator/go v1.1.12",
+			"github.com/klauspost/cpuid/v2 v2.2.9",
+			"github.com/kr/text v0.2.0",
+			"github.com/leodido/go-urn v1.4.0",
+			"github.com/mattn/go-isatty v0.0.20",
+			"github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd",
+			"github.com/modern-go/reflect2 v1.0.2",
+			"github.com/pelletier/go-toml/v2 v2.2.3",
+			"github.com/twitchyliquid64/golang-asm v0.15.1",
+			"github.com/ugorji/go/codec v1.2.12",
+			"golang.org/x/arch v0.12.0",
+			"golang.org/x/crypto v0.31.0",
+			"golang.org/x/net v0.33.0",
+			"golang.org/x/sys v0.28.0",
+			"golang.org/x/text v0.21.0",
+			"google.golang.org/protobuf v1.36.1",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+		raises: []string{
+			"golang.org/x/sync v0.10.0",
+		},
+	},
+	"github.com/gin-contrib/pprof v1.5.3": {
+		indirect: []string{
+			"github.com/bytedance/sonic v1.13.2",
+			"github.com/bytedance/sonic/loader v0.2.4",
+			"github.com/cloudwego/base64x v0.1.5",
+			"github.com/gabriel-vasile/mimetype v1.4.8",
+			"github.com/gin-contrib/sse v1.0.0",
+			"github.com/gin-gonic/gin v1.10.0",
+			"github.com/go-playground/locales v0.14.1",
+			"github.com/go-playground/universal-translator v0.18.1",
+			"github.com/go-playground/validator/v10 v10.26.0",
+			"github.com/goccy/go-json v0.10.5",
+			"github.com/json-iterator/go v1.1.12",
+			"github.com/klauspost/cpuid/v2 v2.2.10",
+			"github.com/leodido/go-urn v1.4.0",
+			"github.com/mattn/go-isatty v0.0.20",
+			"github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd",
+			"github.com/modern-go/reflect2 v1.0.2",
+			"github.com/pelletier/go-toml/v2 v2.2.3",
+			"github.com/twitchyliquid64/golang-asm v0.15.1",
+			"github.com/ugorji/go/codec v1.2.12",
+			"golang.org/x/arch v0.16.0",
+			"golang.org/x/crypto v0.37.0",
+			"golang.org/x/net v0.38.0",
+			"golang.org/x/sys v0.32.0",
+			"golang.org/x/text v0.24.0",
+			"google.golang.org/protobuf v1.36.6",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+		raises: []string{
+			"github.com/cloudwego/iasm v0.2.0",
+			"golang.org/x/sync v0.13.0",
+		},
+	},
+	"github.com/gin-gonic/gin v1.10.0": {
+		indirect: []string{
+			"github.com/bytedance/sonic v1.11.6",
+			"github.com/bytedance/sonic/loader v0.1.1",
+			"github.com/cloudwego/base64x v0.1.4",
+			"github.com/cloudwego/iasm v0.2.0",
+			"github.com/gabriel-vasile/mimetype v1.4.3",
+			"github.com/gin-contrib/sse v0.1.0",
+			"github.com/go-playground/locales v0.14.1",
+			"github.com/go-playground/universal-translator v0.18.1",
+			"github.com/go-playground/validator/v10 v10.20.0",
+			"github.com/goccy/go-json v0.10.2",
+			"github.com/json-iterator/go v1.1.12",
+			"github.com/klauspost/cpuid/v2 v2.2.7",
+			"github.com/leodido/go-urn v1.4.0",
+			"github.com/mattn/go-isatty v0.0.20",
+			"github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd",
+			"github.com/modern-go/reflect2 v1.0.2",
+			"github.com/pelletier/go-toml/v2 v2.2.2",
+			"github.com/twitchyliquid64/golang-asm v0.15.1",
+			"github.com/ugorji/go/codec v1.2.12",
+			"golang.org/x/arch v0.8.0",
+			"golang.org/x/crypto v0.23.0",
+			"golang.org/x/net v0.25.0",
+			"golang.org/x/sys v0.20.0",
+			"golang.org/x/text v0.15.0",
+			"google.golang.org/protobuf v1.34.1",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"github.com/gin-gonic/gin v1.10.1": {
+		indirect: []string{
+			"github.com/bytedance/sonic v1.11.6",
+			"github.com/bytedance/sonic/loader v0.1.1",
+			"github.com/cloudwego/base64x v0.1.4",
+			"github.com/cloudwego/iasm v0.2.0",
+			"github.com/gabriel-vasile/mimetype v1.4.3",
+			"github.com/gin-contrib/sse v0.1.0",
+			"github.com/go-playground/locales v0.14.1",
+			"github.com/go-playground/universal-translator v0.18.1",
+			"github.com/go-playground/validator/v10 v10.20.0",
+			"github.com/goccy/go-json v0.10.2",
+			"github.com/json-iterator/go v1.1.12",
+			"github.com/klauspost/cpuid/v2 v2.2.7",
+			"github.com/leodido/go-urn v1.4.0",
+			"github.com/mattn/go-isatty v0.0.20",
+			"github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd",
+			"github.com/modern-go/reflect2 v1.0.2",
+			"github.com/pelletier/go-toml/v2 v2.2.2",
+			"github.com/twitchyliquid64/golang-asm v0.15.1",
+			"github.com/ugorji/go/codec v1.2.12",
+			"golang.org/x/arch v0.8.0",
+			"golang.org/x/crypto v0.23.0",
+			"golang.org/x/net v0.25.0",
+			"golang.org/x/sys v0.20.0",
+			"golang.org/x/text v0.15.0",
+			"google.golang.org/protobuf v1.34.1",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"github.com/go-sql-driver/mysql v1.8.1": {
+		indirect: []string{
+			"filippo.io/edwards25519 v1.1.0",
+			"github.com/golang-migrate/migrate/v4 v4.17.0",
+			"github.com/hashicorp/errwrap v1.1.0",
+			"github.com/hashicorp/go-multierror v1.1.1",
+			"go.uber.org/atomic v1.7.0",
+		},
+		raises: []string{
+			"github.com/cespare/xxhash/v2 v2.2.0",
+			"github.com/gabriel-vasile/mimetype v1.4.1",
+			"github.com/goccy/go-json v0.9.11",
+			"github.com/google/uuid v1.4.0",
+			"github.com/klauspost/compress v1.15.11",
+			"github.com/klauspost/cpuid/v2 v2.0.9",
+			"github.com/lib/pq v1.10.9",
+			"github.com/mattn/go-colorable v0.1.6",
+			"github.com/mattn/go-isatty v0.0.16",
+			"github.com/remyoudompheng/bigfft v0.0.0-20200410134404-eec4a21b6bb0",
+			"golang.org/x/crypto v0.17.0",
+			"golang.org/x/net v0.18.0",
+			"golang.org/x/sync v0.5.0",
+			"golang.org/x/sys v0.15.0",
+			"golang.org/x/text v0.14.0",
+			"golang.org/x/time v0.3.0",
+			"google.golang.org/genproto/googleapis/api v0.0.0-20231016165738-49dd2c1f3d0b",
+			"google.golang.org/genproto/googleapis/rpc v0.0.0-20231030173426-d783a09b4405",
+			"google.golang.org/grpc v1.59.0",
+			"google.golang.org/protobuf v1.31.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+			"modernc.org/libc v1.17.1",
+			"modernc.org/mathutil v1.5.0",
+			"modernc.org/memory v1.2.1",
+			"modernc.org/sqlite v1.18.1",
+			"modernc.org/strutil v1.1.3",
+			"modernc.org/token v1.0.0",
+		},
+	},
+	"github.com/goccy/go-json v0.10.5": {},
+	"github.com/golang-migrate/migrate/v4 v4.17.0": {
+		indirect: []string{
+			"github.com/hashicorp/errwrap v1.1.0",
+			"github.com/hashicorp/go-multierror v1.1.1",
+			"go.uber.org/atomic v1.7.0",
+		},
+		raises: []string{
+			"github.com/cespare/xxhash/v2 v2.2.0",
+			"github.com/gabriel-vasile/mimetype v1.4.1",
+			"github.com/go-sql-driver/mysql v1.5.0",
+			"github.com/goccy/go-json v0.9.11",
+			"github.com/google/uuid v1.4.0",
+			"github.com/klauspost/compress v1.15.11",
+			"github.com/klauspost/cpuid/v2 v2.0.9",
+			"github.com/lib/pq v1.10.9",
+			"github.com/mattn/go-colorable v0.1.6",
+			"github.com/mattn/go-isatty v0.0.16",
+			"github.com/remyoudompheng/bigfft v0.0.0-20200410134404-eec4a21b6bb0",
+			"golang.org/x/crypto v0.17.0",
+			"golang.org/x/net v0.18.0",
+			"golang.org/x/sync v0.5.0",
+			"golang.org/x/sys v0.15.0",
+			"golang.org/x/text v0.14.0",
+			"golang.org/x/time v0.3.0",
+			"google.golang.org/genproto/googleapis/api v0.0.0-20231016165738-49dd2c1f3d0b",
+			"google.golang.org/genproto/googleapis/rpc v0.0.0-20231030173426-d783a09b4405",
+			"google.golang.org/grpc v1.59.0",
+			"google.golang.org/protobuf v1.31.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+			"modernc.org/libc v1.17.1",
+			"modernc.org/mathutil v1.5.0",
+			"modernc.org/memory v1.2.1",
+			"modernc.org/sqlite v1.18.1",
+			"modernc.org/strutil v1.1.3",
+			"modernc.org/token v1.0.0",
+		},
+	},
+	"github.com/jackc/pgx/v5 v5.7.4": {
+		indirect: []string{
+			"github.com/golang-migrate/migrate/v4 v4.17.0",
+			"github.com/hashicorp/errwrap v1.1.0",
+			"github.com/hashicorp/go-multierror v1.1.1",
+			"github.com/jackc/pgerrcode v0.0.0-20220416144525-469b46aa5efa",
+			"github.com/jackc/pgpassfile v1.0.0",
+			"github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761",
+			"github.com/jackc/puddle/v2 v2.2.2",
+			"go.uber.org/atomic v1.7.0",
+			"golang.org/x/crypto v0.31.0",
+			"golang.org/x/sync v0.10.0",
+			"golang.org/x/text v0.21.0",
+		},
+		raises: []string{
+			"github.com/cespare/xxhash/v2 v2.2.0",
+			"github.com/gabriel-vasile/mimetype v1.4.1",
+			"github.com/go-sql-driver/mysql v1.5.0",
+			"github.com/goccy/go-json v0.9.11",
+			"github.com/google/uuid v1.4.0",
+			"github.com/klauspost/compress v1.15.11",
+			"github.com/klauspost/cpuid/v2 v2.0.9",
+			"github.com/lib/pq v1.10.9",
+			"github.com/mattn/go-colorable v0.1.6",
+			"github.com/mattn/go-isatty v0.0.16",
+			"github.com/remyoudompheng/bigfft v0.0.0-20200410134404-eec4a21b6bb0",
+			"golang.org/x/net v0.21.0",
+			"golang.org/x/sys v0.28.0",
+			"golang.org/x/time v0.3.0",
+			"google.golang.org/genproto/googleapis/api v0.0.0-20231016165738-49dd2c1f3d0b",
+			"google.golang.org/genproto/googleapis/rpc v0.0.0-20231030173426-d783a09b4405",
+			"google.golang.org/grpc v1.59.0",
+			"google.golang.org/protobuf v1.31.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+			"modernc.org/libc v1.17.1",
+			"modernc.org/mathutil v1.5.0",
+			"modernc.org/memory v1.2.1",
+			"modernc.org/sqlite v1.18.1",
+			"modernc.org/strutil v1.1.3",
+			"modernc.org/token v1.0.0",
+		},
+	},
+	"github.com/jmoiron/sqlx v1.3.5": {
+		raises: []string{
+			"github.com/go-sql-driver/mysql v1.6.0",
+			"github.com/lib/pq v1.2.0",
+		},
+	},
+	"github.com/json-iterator/go v1.1.12": {
+		indirect: []string{
+			"github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421",
+			"github.com/modern-go/reflect2 v1.0.2",
+		},
+	},
+	"github.com/labstack/echo/v4 v4.12.0": {
+		indirect: []string{
+			"github.com/golang-jwt/jwt v3.2.2+incompatible",
+			"github.com/labstack/gommon v0.4.2",
+			"github.com/mattn/go-colorable v0.1.13",
+			"github.com/mattn/go-isatty v0.0.20",
+			"github.com/valyala/bytebufferpool v1.0.0",
+			"github.com/valyala/fasttemplate v1.2.2",
+			"golang.org/x/crypto v0.22.0",
+			"golang.org/x/net v0.24.0",
+			"golang.org/x/sys v0.19.0",
+			"golang.org/x/text v0.14.0",
+			"golang.org/x/time v0.5.0",
+		},
+		raises: []string{
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"github.com/labstack/echo/v4 v4.13.4": {
+		indirect: []string{
+			"github.com/labstack/gommon v0.4.2",
+			"github.com/mattn/go-colorable v0.1.14",
+			"github.com/mattn/go-isatty v0.0.20",
+			"github.com/valyala/bytebufferpool v1.0.0",
+			"github.com/valyala/fasttemplate v1.2.2",
+			"golang.org/x/crypto v0.38.0",
+			"golang.org/x/net v0.40.0",
+			"golang.org/x/sys v0.33.0",
+			"golang.org/x/text v0.25.0",
+			"golang.org/x/time v0.11.0",
+		},
+		raises: []string{
+			"golang.org/x/sync v0.14.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"github.com/lib/pq v1.10.9": {
+		indirect: []string{
+			"github.com/golang-migrate/migrate/v4 v4.17.0",
+			"github.com/hashicorp/errwrap v1.1.0",
+			"github.com/hashicorp/go-multierror v1.1.1",
+			"go.uber.org/atomic v1.7.0",
+		},
+		raises: []string{
+			"github.com/cespare/xxhash/v2 v2.2.0",
+			"github.com/gabriel-vasile/mimetype v1.4.1",
+			"github.com/go-sql-driver/mysql v1.5.0",
+			"github.com/goccy/go-json v0.9.11",
+			"github.com/google/uuid v1.4.0",
+			"github.com/klauspost/compress v1.15.11",
+			"github.com/klauspost/cpuid/v2 v2.0.9",
+			"github.com/mattn/go-colorable v0.1.6",
+			"github.com/mattn/go-isatty v0.0.16",
+			"github.com/remyoudompheng/bigfft v0.0.0-20200410134404-eec4a21b6bb0",
+			"golang.org/x/crypto v0.17.0",
+			"golang.org/x/net v0.18.0",
+			"golang.org/x/sync v0.5.0",
+			"golang.org/x/sys v0.15.0",
+			"golang.org/x/text v0.14.0",
+			"golang.org/x/time v0.3.0",
+			"google.golang.org/genproto/googleapis/api v0.0.0-20231016165738-49dd2c1f3d0b",
+			"google.golang.org/genproto/googleapis/rpc v0.0.0-20231030173426-d783a09b4405",
+			"google.golang.org/grpc v1.59.0",
+			"google.golang.org/protobuf v1.31.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+			"modernc.org/libc v1.17.1",
+			"modernc.org/mathutil v1.5.0",
+			"modernc.org/memory v1.2.1",
+			"modernc.org/sqlite v1.18.1",
+			"modernc.org/strutil v1.1.3",
+			"modernc.org/token v1.0.0",
+		},
+	},
+	"github.com/prometheus/client_golang v1.20.5": {
+		indirect: []string{
+			"github.com/beorn7/perks v1.0.1",
+			"github.com/cespare/xxhash/v2 v2.3.0",
+			"github.com/klauspost/compress v1.17.9",
+			"github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822",
+			"github.com/prometheus/client_model v0.6.1",
+			"github.com/prometheus/common v0.55.0",
+			"github.com/prometheus/procfs v0.15.1",
+			"golang.org/x/sys v0.22.0",
+			"google.golang.org/protobuf v1.34.2",
+		},
+		raises: []string{
+			"github.com/json-iterator/go v1.1.12",
+			"github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd",
+			"github.com/modern-go/reflect2 v1.0.2",
+			"golang.org/x/net v0.26.0",
+			"golang.org/x/sync v0.7.0",
+			"golang.org/x/text v0.16.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"github.com/redis/go-redis/v9 v9.7.3": {
+		indirect: []string{
+			"github.com/cespare/xxhash/v2 v2.2.0",
+			"github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f",
+		},
+	},
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin v0.63.0": {
+		indirect: []string{
+			"github.com/bytedance/sonic v1.14.0",
+			"github.com/bytedance/sonic/loader v0.3.0",
+			"github.com/cloudwego/base64x v0.1.6",
+			"github.com/gabriel-vasile/mimetype v1.4.10",
+			"github.com/gin-contrib/sse v1.1.0",
+			"github.com/gin-gonic/gin v1.10.1",
+			"github.com/go-logr/logr v1.4.3",
+			"github.com/go-logr/stdr v1.2.2",
+			"github.com/go-playground/locales v0.14.1",
+			"github.com/go-playground/universal-translator v0.18.1",
+			"github.com/go-playground/validator/v10 v10.27.0",
+			"github.com/goccy/go-json v0.10.5",
+			"github.com/json-iterator/go v1.1.12",
+			"github.com/klauspost/cpuid/v2 v2.3.0",
+			"github.com/leodido/go-urn v1.4.0",
+			"github.com/mattn/go-isatty v0.0.20",
+			"github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd",
+			"github.com/modern-go/reflect2 v1.0.2",
+			"github.com/pelletier/go-toml/v2 v2.2.4",
+			"github.com/twitchyliquid64/golang-asm v0.15.1",
+			"github.com/ugorji/go/codec v1.3.0",
+			"go.opentelemetry.io/auto/sdk v1.1.0",
+			"go.opentelemetry.io/otel v1.38.0",
+			"go.opentelemetry.io/otel/metric v1.38.0",
+			"go.opentelemetry.io/otel/trace v1.38.0",
+			"golang.org/x/arch v0.20.0",
+			"golang.org/x/crypto v0.41.0",
+			"golang.org/x/net v0.43.0",
+			"golang.org/x/sys v0.35.0",
+			"golang.org/x/text v0.28.0",
+			"google.golang.org/protobuf v1.36.8",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+		raises: []string{
+			"github.com/cloudwego/iasm v0.2.0",
+			"github.com/google/uuid v1.6.0",
+			"github.com/kr/text v0.2.0",
+			"go.opentelemetry.io/otel/sdk v1.38.0",
+			"golang.org/x/sync v0.16.0",
+		},
+	},
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho v0.63.0": {
+		indirect: []string{
+			"github.com/go-logr/logr v1.4.3",
+			"github.com/go-logr/stdr v1.2.2",
+			"github.com/labstack/echo/v4 v4.13.4",
+			"github.com/labstack/gommon v0.4.2",
+			"github.com/mattn/go-colorable v0.1.14",
+			"github.com/mattn/go-isatty v0.0.20",
+			"github.com/valyala/bytebufferpool v1.0.0",
+			"github.com/valyala/fasttemplate v1.2.2",
+			"go.opentelemetry.io/auto/sdk v1.1.0",
+			"go.opentelemetry.io/otel v1.38.0",
+			"go.opentelemetry.io/otel/metric v1.38.0",
+			"go.opentelemetry.io/otel/trace v1.38.0",
+			"golang.org/x/crypto v0.41.0",
+			"golang.org/x/net v0.43.0",
+			"golang.org/x/sys v0.35.0",
+			"golang.org/x/text v0.28.0",
+			"golang.org/x/time v0.12.0",
+		},
+		raises: []string{
+			"github.com/google/uuid v1.6.0",
+			"github.com/kr/text v0.2.0",
+			"go.opentelemetry.io/otel/sdk v1.38.0",
+			"golang.org/x/sync v0.16.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.63.0": {
+		indirect: []string{
+			"github.com/felixge/httpsnoop v1.0.4",
+			"github.com/go-logr/logr v1.4.3",
+			"github.com/go-logr/stdr v1.2.2",
+			"go.opentelemetry.io/auto/sdk v1.1.0",
+			"go.opentelemetry.io/otel v1.38.0",
+			"go.opentelemetry.io/otel/metric v1.38.0",
+			"go.opentelemetry.io/otel/trace v1.38.0",
+		},
+		raises: []string{
+			"github.com/google/uuid v1.6.0",
+			"github.com/kr/text v0.2.0",
+			"go.opentelemetry.io/otel/sdk v1.38.0",
+			"golang.org/x/sys v0.35.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"go.opentelemetry.io/otel v1.38.0": {
+		indirect: []string{
+			"github.com/go-logr/logr v1.4.3",
+			"github.com/go-logr/stdr v1.2.2",
+			"go.opentelemetry.io/auto/sdk v1.1.0",
+			"go.opentelemetry.io/otel/metric v1.38.0",
+			"go.opentelemetry.io/otel/trace v1.38.0",
+		},
+		raises: []string{
+			"github.com/kr/text v0.2.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp v1.38.0": {
+		indirect: []string{
+			"github.com/cenkalti/backoff/v5 v5.0.3",
+			"github.com/go-logr/logr v1.4.3",
+			"github.com/go-logr/stdr v1.2.2",
+			"github.com/google/uuid v1.6.0",
+			"github.com/grpc-ecosystem/grpc-gateway/v2 v2.27.2",
+			"go.opentelemetry.io/auto/sdk v1.1.0",
+			"go.opentelemetry.io/otel v1.38.0",
+			"go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.38.0",
+			"go.opentelemetry.io/otel/metric v1.38.0",
+			"go.opentelemetry.io/otel/sdk v1.38.0",
+			"go.opentelemetry.io/otel/trace v1.38.0",
+			"go.opentelemetry.io/proto/otlp v1.7.1",
+			"golang.org/x/net v0.43.0",
+			"golang.org/x/sys v0.35.0",
+			"golang.org/x/text v0.28.0",
+			"google.golang.org/genproto/googleapis/api v0.0.0-20250825161204-c5933d9347a5",
+			"google.golang.org/genproto/googleapis/rpc v0.0.0-20250825161204-c5933d9347a5",
+			"google.golang.org/grpc v1.75.0",
+			"google.golang.org/protobuf v1.36.8",
+		},
+		raises: []string{
+			"github.com/cespare/xxhash/v2 v2.3.0",
+			"github.com/kr/text v0.2.0",
+			"golang.org/x/crypto v0.41.0",
+			"golang.org/x/sync v0.16.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"go.opentelemetry.io/otel/sdk v1.38.0": {
+		indirect: []string{
+			"github.com/go-logr/logr v1.4.3",
+			"github.com/go-logr/stdr v1.2.2",
+			"github.com/google/uuid v1.6.0",
+			"go.opentelemetry.io/auto/sdk v1.1.0",
+			"go.opentelemetry.io/otel v1.38.0",
+			"go.opentelemetry.io/otel/metric v1.38.0",
+			"go.opentelemetry.io/otel/trace v1.38.0",
+			"golang.org/x/sys v0.35.0",
+		},
+		raises: []string{
+			"github.com/kr/text v0.2.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"go.opentelemetry.io/otel/trace v1.38.0": {
+		indirect: []string{
+			"go.opentelemetry.io/otel v1.38.0",
+		},
+		raises: []string{
+			"github.com/go-logr/logr v1.4.3",
+			"github.com/go-logr/stdr v1.2.2",
+			"github.com/kr/text v0.2.0",
+			"go.opentelemetry.io/auto/sdk v1.1.0",
+			"go.opentelemetry.io/otel/metric v1.38.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"go.uber.org/zap v1.26.0": {
+		indirect: []string{
+			"go.uber.org/multierr v1.10.0",
+		},
+		raises: []string{
+			"github.com/kr/text v0.2.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+	"golang.org/x/crypto v0.31.0": {
+		indirect: []string{
+			"golang.org/x/sys v0.28.0",
+		},
+		raises: []string{
+			"golang.org/x/net v0.21.0",
+			"golang.org/x/text v0.21.0",
+		},
+	},
+	"golang.org/x/crypto v0.37.0": {
+		indirect: []string{
+			"golang.org/x/sys v0.32.0",
+		},
+		raises: []string{
+			"golang.org/x/net v0.21.0",
+			"golang.org/x/text v0.24.0",
+		},
+	},
+	"golang.org/x/crypto v0.41.0": {
+		indirect: []string{
+			"golang.org/x/sys v0.35.0",
+		},
+		raises: []string{
+			"golang.org/x/net v0.42.0",
+			"golang.org/x/text v0.28.0",
+		},
+	},
+	"google.golang.org/grpc v1.69.4": {
+		indirect: []string{
+			"golang.org/x/net v0.30.0",
+			"golang.org/x/sys v0.26.0",
+			"golang.org/x/text v0.19.0",
+			"google.golang.org/genproto/googleapis/rpc v0.0.0-20241015192408-796eee8c2d53",
+			"google.golang.org/protobuf v1.35.1",
+		},
+		raises: []string{
+			"github.com/cespare/xxhash/v2 v2.3.0",
+			"github.com/go-logr/logr v1.4.2",
+			"github.com/go-logr/stdr v1.2.2",
+			"github.com/google/uuid v1.6.0",
+			"go.opentelemetry.io/otel v1.31.0",
+			"go.opentelemetry.io/otel/metric v1.31.0",
+			"go.opentelemetry.io/otel/sdk v1.31.0",
+			"go.opentelemetry.io/otel/trace v1.31.0",
+			"golang.org/x/crypto v0.28.0",
+			"golang.org/x/sync v0.8.0",
+			"google.golang.org/genproto/googleapis/api v0.0.0-20241015192408-796eee8c2d53",
+		},
+	},
+	"google.golang.org/grpc v1.75.0": {
+		indirect: []string{
+			"golang.org/x/net v0.41.0",
+			"golang.org/x/sys v0.33.0",
+			"golang.org/x/text v0.26.0",
+			"google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7",
+			"google.golang.org/protobuf v1.36.6",
+		},
+		raises: []string{
+			"github.com/cespare/xxhash/v2 v2.3.0",
+			"github.com/go-logr/logr v1.4.3",
+			"github.com/go-logr/stdr v1.2.2",
+			"github.com/google/uuid v1.6.0",
+			"go.opentelemetry.io/auto/sdk v1.1.0",
+			"go.opentelemetry.io/otel v1.37.0",
+			"go.opentelemetry.io/otel/metric v1.37.0",
+			"go.opentelemetry.io/otel/sdk v1.37.0",
+			"go.opentelemetry.io/otel/trace v1.37.0",
+			"golang.org/x/crypto v0.39.0",
+			"golang.org/x/sync v0.15.0",
+			"google.golang.org/genproto/googleapis/api v0.0.0-20250707201910-8d1bb00bc6a7",
+		},
+	},
+	"modernc.org/sqlite v1.34.4": {
+		indirect: []string{
+			"github.com/dustin/go-humanize v1.0.1",
+			"github.com/golang-migrate/migrate/v4 v4.17.0",
+			"github.com/google/uuid v1.6.0",
+			"github.com/hashicorp/errwrap v1.1.0",
+			"github.com/hashicorp/go-multierror v1.1.1",
+			"github.com/hashicorp/golang-lru/v2 v2.0.7",
+			"github.com/mattn/go-isatty v0.0.20",
+			"github.com/ncruces/go-strftime v0.1.9",
+			"github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec",
+			"go.uber.org/atomic v1.7.0",
+			"golang.org/x/sys v0.22.0",
+			"modernc.org/gc/v3 v3.0.0-20240107210532-573471604cb6",
+			"modernc.org/libc v1.55.3",
+			"modernc.org/mathutil v1.6.0",
+			"modernc.org/memory v1.8.0",
+			"modernc.org/strutil v1.2.0",
+			"modernc.org/token v1.1.0",
+		},
+		raises: []string{
+			"github.com/cespare/xxhash/v2 v2.2.0",
+			"github.com/gabriel-vasile/mimetype v1.4.1",
+			"github.com/go-sql-driver/mysql v1.5.0",
+			"github.com/goccy/go-json v0.9.11",
+			"github.com/klauspost/compress v1.15.11",
+			"github.com/klauspost/cpuid/v2 v2.0.9",
+			"github.com/lib/pq v1.10.9",
+			"github.com/mattn/go-colorable v0.1.6",
+			"golang.org/x/crypto v0.17.0",
+			"golang.org/x/net v0.18.0",
+			"golang.org/x/sync v0.5.0",
+			"golang.org/x/text v0.14.0",
+			"golang.org/x/time v0.3.0",
+			"google.golang.org/genproto/googleapis/api v0.0.0-20231016165738-49dd2c1f3d0b",
+			"google.golang.org/genproto/googleapis/rpc v0.0.0-20231030173426-d783a09b4405",
+			"google.golang.org/grpc v1.59.0",
+			"google.golang.org/protobuf v1.31.0",
+			"gopkg.in/yaml.v3 v3.0.1",
+		},
+	},
+}
@@ -0,0 +1,137 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// goModModules returns the modules required by the go.mod file in content
+func goModModules(content string) map[string]bool {
+	modules := make(map[string]bool)
+	inRequire := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "require (":
+			inRequire = true
+		case line == ")":
+			inRequire = false
+		case inRequire && line != "":
+			modules[strings.Fields(line)[0]] = true
+		}
+	}
+	return modules
+}
+
+func TestGoModTemplate(t *testing.T) {
+	const (
+		gin      = "github.com/gin-gonic/gin"
+		ginCORS  = "github.com/gin-contrib/cors"
+		echo     = "github.com/labstack/echo/v4"
+		chi      = "github.com/go-chi/chi/v5"
+		sqlx     = "github.com/jmoiron/sqlx"
+		pq       = "github.com/lib/pq"
+		pgx      = "github.com/jackc/pgx/v5"
+		mysql    = "github.com/go-sql-driver/mysql"
+		migrate  = "github.com/golang-migrate/migrate/v4"
+		plural   = "github.com/gertd/go-pluralize"
+		strcase  = "github.com/iancoleman/strcase"
+		zap      = "go.uber.org/zap"
+		godotenv = "github.com/joho/godotenv"
+		otel     = "go.opentelemetry.io/otel"
+		trace    = "go.opentelemetry.io/otel/trace"
+		grpc     = "google.golang.org/grpc"
+		redis    = "github.com/redis/go-redis/v9"
+	)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "no components",
+			args:    []string{"--preset", "minimal"},
+			want:    []string{zap, godotenv},
+			notWant: []string{gin, ginCORS, echo, chi, sqlx, pq, migrate, plural, strcase, otel, grpc, redis},
+		},
+		{
+			name:    "gin",
+			args:    []string{"--components", "http", "--http-framework", "gin"},
+			want:    []string{zap, godotenv, gin, ginCORS},
+			notWant: []string{echo, chi, sqlx, pq, migrate},
+		},
+		{
+			name:    "net/http",
+			args:    []string{"--components", "http", "--http-framework", "stdlib"},
+			want:    []string{zap, godotenv},
+			notWant: []string{gin, ginCORS, echo, chi},
+		},
+		{
+			name:    "postgres without http",
+			args:    []string{"--components", "postgres"},
+			want:    []string{zap, godotenv, sqlx, pq, migrate, plural, strcase},
+			notWant: []string{gin, ginCORS, pgx},
+		},
+		{
+			name:    "postgres with pgx",
+			args:    []string{"--components", "http,postgres", "--http-framework", "echo", "--postgres-driver", "pgx"},
+			want:    []string{echo, sqlx, pgx, migrate},
+			notWant: []string{gin, pq},
+		},
+		{
+			name:    "mysql",
+			args:    []string{"--components", "grpc,mysql"},
+			want:    []string{grpc, sqlx, mysql, migrate},
+			notWant: []string{gin, pq},
+		},
+		{
+			name:    "tracing with chi",
+			args:    []string{"--components", "http,tracing", "--http-framework", "chi"},
+			want:    []string{chi, otel, trace},
+			notWant: []string{gin, sqlx},
+		},
+		{
+			name:    "tracing with gin",
+			args:    []string{"--components", "http,redis,tracing", "--http-framework", "gin"},
+			want:    []string{gin, otel, redis},
+			notWant: []string{trace, chi},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.ParseArgs(append([]string{"--project", "demo", "--username", "acme", "--output", t.TempDir()}, tt.args...))
+			if err != nil {
+				t.Fatalf("ParseArgs() error = %v", err)
+			}
+
+			content, err := GoModTemplate(cfg.ProjectConfig)
+			if err != nil {
+				t.Fatalf("GoModTemplate() error = %v", err)
+			}
+			if !strings.HasPrefix(content, "module github.com/acme/demo\n\ngo "+cfg.ProjectConfig.Go()+"\n") {
+				t.Errorf("go.mod doesn't start with the module and go directives:\n%s", content)
+			}
+			// go mod tidy adds the indirect requirements
+			if strings.Contains(content, "// indirect") {
+				t.Errorf("go.mod has indirect requirements:\n%s", content)
+			}
+
+			modules := goModModules(content)
+			for _, module := range tt.want {
+				if !modules[module] {
+					t.Errorf("go.mod doesn't require %s:\n%s", module, content)
+				}
+			}
+			for _, module := range tt.notWant {
+				if modules[module] {
+					t.Errorf("go.mod requires %s:\n%s", module, content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// internal/generator/templates/grpc.go - Templates for gRPC files
+package templates
+
+import (
+	"strings"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// ProtoPackage returns the protobuf package name derived from the project name
+func ProtoPackage(cfg config.ProjectConfig) string {
+	name := strings.ToLower(cfg.ProjectName)
+	return strings.NewReplacer("-", "", "_", "", ".", "").Replace(name)
+}
+
+// GRPCServerTemplate returns the content of the gRPC server.go file
+func GRPCServerTemplate() (string, error) {
+	return render("grpc_server.tmpl", nil)
+}
+
+// GRPCInterceptorsTemplate returns the content of the gRPC interceptors.go file
+func GRPCInterceptorsTemplate() (string, error) {
+	return render("grpc_interceptors.tmpl", nil)
+}
+
+// GRPCServerTestTemplate returns the content of the gRPC server_test.go file
+func GRPCServerTestTemplate() (string, error) {
+	return render("grpc_server_test.tmpl", nil)
+}
+
+// ProtoServiceTemplate returns the content of the example service.proto file
+func ProtoServiceTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("grpc_proto_service.tmpl", map[string]any{
+		"Cfg":     cfg,
+		"Package": ProtoPackage(cfg),
+	})
+}
+
+// BufTemplate returns the content of the buf.yaml file
+func BufTemplate() (string, error) {
+	return render("grpc_buf.tmpl", nil)
+}
+
+// BufGenTemplate returns the content of the buf.gen.yaml file
+func BufGenTemplate() (string, error) {
+	return render("grpc_buf_gen.tmpl", nil)
+}
@@ -0,0 +1,91 @@
+// internal/generator/templates/jsonengine.go - Templates for the JSON engine of Gin
+package templates
+
+import (
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// jsonEngine is a JSON library Gin can encode and decode with
+type jsonEngine struct {
+	// Name is the name of the engine in the benchmark
+	Name string
+	// Require is the go.mod requirement of the engine
+	Require string
+	// Import is the import spec of the engine package
+	Import string
+	// Marshal is the function encoding like json.Marshal, as Gin calls it
+	Marshal string
+}
+
+// jsonEngines maps the JSON engines other than encoding/json to their library. The
+// versions are at least the ones the module graph of Gin selects; sonic is raised to
+// a release supporting the newest Go versions.
+var jsonEngines = map[string]jsonEngine{
+	config.JSONEngineGoJSON: {
+		Name:    "go-json",
+		Require: "github.com/goccy/go-json v0.10.5",
+		Import:  `gojson "github.com/goccy/go-json"`,
+		Marshal: "gojson.Marshal",
+	},
+	config.JSONEngineJsoniter: {
+		Name:    "jsoniter",
+		Require: "github.com/json-iterator/go v1.1.12",
+		Import:  `jsoniter "github.com/json-iterator/go"`,
+		Marshal: "jsoniter.ConfigCompatibleWithStandardLibrary.Marshal",
+	},
+	config.JSONEngineSonic: {
+		Name:    "sonic",
+		Require: "github.com/bytedance/sonic v1.15.4",
+		Import:  `"github.com/bytedance/sonic"`,
+		Marshal: "sonic.ConfigStd.Marshal",
+	},
+}
+
+// selectedJSONEngine returns the JSON engine of the project, false when Gin encodes
+// with encoding/json
+func selectedJSONEngine(cfg config.ProjectConfig) (jsonEngine, bool) {
+	if !cfg.Components.HasJSONEngine() {
+		return jsonEngine{}, false
+	}
+	engine, ok := jsonEngines[cfg.Components.JSONEngine]
+	return engine, ok
+}
+
+// APIJSONBenchmarkTemplate returns the content of the handlers/json_bench_test.go file,
+// benchmarking the encoding of the users list with encoding/json, the selected JSON
+// engine and the renderer of Gin
+func APIJSONBenchmarkTemplate(cfg config.ProjectConfig) (string, error) {
+	imports := []string{`"github.com/gin-gonic/gin/render"`}
+	engine, ok := selectedJSONEngine(cfg)
+	if ok {
+		imports = append(imports, engine.Import)
+	}
+
+	// A project without the engine benchmarks the tags of go-json as an example
+	tags := cfg.Components.BuildTags()
+	if tags == "" {
+		tags = "go_json"
+	}
+
+	// The users list is only generated with a database; the IDs are its key type
+	id := "int64(i + 1)"
+	if cfg.Components.HasDatabase() {
+		if idType := databaseEngine(cfg).ModelIDType; idType == "int" {
+			id = "i + 1"
+		} else {
+			id = idType + "(i + 1)"
+		}
+	}
+
+	data := map[string]any{
+		"Imports": importLines(imports),
+		"Engine":  nil,
+		"Tags":    tags,
+		"Users":   cfg.Components.HasDatabase(),
+		"ID":      id,
+	}
+	if ok {
+		data["Engine"] = engine
+	}
+	return render("api_json_bench_test.tmpl", data)
+}
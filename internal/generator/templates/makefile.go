@@ -0,0 +1,117 @@
+// internal/generator/templates/makefile.go - Templates for the Makefile
+package templates
+
+import (
+	"strings"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// crossTarget is a platform the Makefile cross-compiles the binary for
+type crossTarget struct {
+	// Name is the platform as used in the target and directory names, e.g. linux-amd64
+	Name   string
+	GOOS   string
+	GOARCH string
+	// Binary is the file name of the binary, with .exe on Windows
+	Binary string
+}
+
+// MakefileTemplate returns the content of the Makefile
+func MakefileTemplate(cfg config.ProjectConfig) (string, error) {
+	// Gin projects build with GO_TAGS, which select its JSON engine
+	tags := ""
+	gin := cfg.Components.HTTP && cfg.Components.HTTPFramework == config.HTTPFrameworkGin
+	if gin {
+		tags = ` -tags "$(GO_TAGS)"`
+	}
+
+	var targets []crossTarget
+	for _, target := range cfg.BuildTargets {
+		goos, goarch, ok := strings.Cut(target, "/")
+		if !ok {
+			continue
+		}
+		binary := "$(BINARY_NAME)"
+		if goos == "windows" {
+			binary += ".exe"
+		}
+		targets = append(targets, crossTarget{Name: goos + "-" + goarch, GOOS: goos, GOARCH: goarch, Binary: binary})
+	}
+
+	moduleDirs := make([]string, len(cfg.PkgModules))
+	for i, name := range cfg.PkgModules {
+		moduleDirs[i] = "pkg/" + name
+	}
+
+	tlsTargets, err := tlsMakefileTargets(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	// Benchmark comparing the JSON engines of Gin on the users list
+	bench := gin && !cfg.NoTests
+	// Target removing the companion replace directives before a release
+	dropReplaces := cfg.CompanionReplaces && len(cfg.Companions) > 0
+
+	return render("makefile.tmpl", map[string]any{
+		"Cfg":          cfg,
+		"Gin":          gin,
+		"Tags":         tags,
+		"Targets":      targets,
+		"ModuleDirs":   moduleDirs,
+		"TLS":          tlsTargets,
+		"Bench":        bench,
+		"DropReplaces": dropReplaces,
+		"Phony":        makefilePhony(cfg, targets, bench, dropReplaces),
+	})
+}
+
+// makefilePhony returns the targets of the Makefile, in the order they are defined
+func makefilePhony(cfg config.ProjectConfig, targets []crossTarget, bench, dropReplaces bool) []string {
+	phony := []string{"build", "build-all", "dist", "run", "dev", "debug-info", "test", "lint", "fmt", "tidy", "clean"}
+	for _, target := range targets {
+		phony = append(phony, "build-"+target.Name)
+	}
+	if bench {
+		phony = append(phony, "bench-json")
+	}
+	if cfg.Components.HasDatabase() {
+		phony = append(phony, "migrate-up", "migrate-down", "migrate-create", "migrate-verify")
+	}
+	if cfg.Components.HasModelGenerator() {
+		phony = append(phony, "models", "schema-docs", "schema-docs-check")
+	}
+	if cfg.Components.HasSQLC() {
+		phony = append(phony, "sqlc")
+	}
+	if cfg.Components.Docker {
+		phony = append(phony, "docker-build", "docker-up")
+	}
+	if dropReplaces {
+		phony = append(phony, "drop-replaces")
+	}
+	if len(cfg.PkgModules) > 0 {
+		phony = append(phony, "tag-module")
+	}
+	if cfg.HasTLS() {
+		phony = append(phony, "certs", "run-tls")
+	}
+	if cfg.ConventionalCommits {
+		phony = append(phony, "hooks")
+	}
+	if cfg.Components.GRPC {
+		phony = append(phony, "proto", "proto-lint")
+	}
+	return phony
+}
+
+// GolangciConfigTemplate returns the content of the .golangci.yml file; it
+// targets the golangci-lint version pinned by GOLANGCI_LINT_VERSION and the CI
+// pipelines, and lints with the build tags selecting the JSON engine of Gin
+func GolangciConfigTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("golangci_config.tmpl", map[string]any{
+		"Tags": strings.Split(cfg.Components.BuildTags(), ","),
+		"Cfg":  cfg,
+	})
+}
@@ -1,336 +1,131 @@
 // internal/generator/templates/migrations.go - Templates for migration files
 package templates
 
-// MigrationsScriptTemplate returns the content of the migrations.sh script
-func MigrationsScriptTemplate() string {
-	return `#!/bin/sh
-# scripts/migrate.sh - Database migrations runner
-
-# Change to project root directory
-cd "$(dirname "$0")/.." || exit 1
-
-# Parse arguments
-COMMAND="up"
-STEPS=0
-ENV_FILE=".env"
-
-print_usage() {
-  echo "Usage: $0 [options]"
-  echo "Options:"
-  echo "  -c, --command=COMMAND  Migration command (up, down, version) [default: up]"
-  echo "  -s, --steps=STEPS      Number of migrations to apply (0 means all) [default: 0]"
-  echo "  -e, --env=ENV_FILE     Path to .env file [default: .env]"
-  echo "  -h, --help             Show this help message"
-}
-
-while [ $# -gt 0 ]; do
-  case "$1" in
-    -c=*|--command=*)
-      COMMAND="${1#*=}"
-      shift
-      ;;
-    -s=*|--steps=*)
-      STEPS="${1#*=}"
-      shift
-      ;;
-    -e=*|--env=*)
-      ENV_FILE="${1#*=}"
-      shift
-      ;;
-    -h|--help)
-      print_usage
-      exit 0
-      ;;
-    *)
-      echo "Unknown option: $1"
-      print_usage
-      exit 1
-      ;;
-  esac
-done
-
-# Run migrations tool
-go run ./scripts/migtool/migrations.go -command="$COMMAND" -steps="$STEPS" -env="$ENV_FILE"
-`
-}
-
-// MigrationToolTemplate returns the content of the migrations tool
-func MigrationToolTemplate() string {
-	return `// scripts/migtool/migrations.go - Database migrations tool
-package main
-
 import (
-	"flag"
-	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
 
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	"github.com/golang-migrate/migrate/v4/source/iofs"
-	"github.com/joho/godotenv"
-
-	"{{ .ModuleName }}/internal/migrations"
+	"github.com/neor-it/go-project-gen/internal/config"
 )
 
-func main() {
-	// Define flags
-	var (
-		command = flag.String("command", "up", "Migration command (up, down, version)")
-		steps   = flag.Int("steps", 0, "Number of migrations to apply (0 means all)")
-		env     = flag.String("env", ".env", "Path to .env file")
-	)
-
-	flag.Parse()
-
-	// Load environment variables from .env file
-	if err := godotenv.Load(*env); err != nil {
-		fmt.Printf("Warning: Error loading .env file: %v\n", err)
-	}
-
-	// Get database connection string from environment
-	connString := os.Getenv("DB_CONNECTION_STRING")
-	if connString == "" {
-		fmt.Println("Error: DB_CONNECTION_STRING environment variable is not set")
-		os.Exit(1)
-	}
-
-	// Get migrations directory from environment or use default
-	migrationsDir := os.Getenv("MIGRATIONS_DIR")
-	if migrationsDir == "" {
-		// Use embedded migrations
-		if err := runEmbeddedMigrations(connString, *command, *steps); err != nil {
-			if err != migrate.ErrNoChange {
-				fmt.Printf("Error: %v\n", err)
-				os.Exit(1)
-			}
-		}
-	} else {
-		// Use file-based migrations
-		sourceURL := fmt.Sprintf("file://%s", filepath.Clean(migrationsDir))
-		
-		// Create migrate instance
-		m, err := migrate.New(sourceURL, connString)
-		if err != nil {
-			fmt.Printf("Error: Failed to create migrate instance: %v\n", err)
-			os.Exit(1)
-		}
-		
-		// Set logger
-		m.Log = &migrationLogger{}
-		
-		// Execute migration command
-		if err := executeMigrationCommand(m, *command, *steps); err != nil {
-			if err != migrate.ErrNoChange {
-				fmt.Printf("Error: %v\n", err)
-				os.Exit(1)
-			}
-		}
-	}
+// MigrationsScriptTemplate returns the content of the migrations.sh script
+func MigrationsScriptTemplate() (string, error) {
+	return render("migrations_script.tmpl", nil)
 }
 
-// runEmbeddedMigrations runs migrations from embedded filesystem
-func runEmbeddedMigrations(connString, command string, steps int) error {
-	// Create migrations source
-	migrations, err := migrations.GetFS()
-	if err != nil {
-		return fmt.Errorf("failed to access embedded migrations: %w", err)
-	}
-
-	// Create source instance
-	d, err := iofs.New(migrations, ".")
-	if err != nil {
-		return fmt.Errorf("failed to create migrations source: %w", err)
-	}
-
-	// Create migrate instance
-	m, err := migrate.NewWithSourceInstance("iofs", d, connString)
+// MigrationToolTemplate returns the content of the migrations tool
+func MigrationToolTemplate(cfg config.ProjectConfig) (string, error) {
+	engine := databaseEngine(cfg)
+	migrateURL, _, err := migrateURLFunc(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create migrate instance: %w", err)
+		return "", err
 	}
 
-	// Set logger
-	m.Log = &migrationLogger{}
-
-	// Execute migration command
-	return executeMigrationCommand(m, command, steps)
+	return render("migrations_tool.tmpl", map[string]any{
+		"Postgres":      cfg.Components.Database == config.ComponentPostgres,
+		"SQLite":        cfg.Components.Database == config.ComponentSQLite,
+		"Label":         DatabaseLabel(cfg),
+		"DriverImport":  engine.DriverImport,
+		"MigrateDriver": engine.MigrateDriver,
+		// Migrations are applied to the main connection
+		"ConnEnv":    DatabaseEnv(cfg, "", "CONNECTION_STRING"),
+		"MigrateURL": migrateURL,
+	})
 }
 
-// executeMigrationCommand executes the migration command
-func executeMigrationCommand(m *migrate.Migrate, command string, steps int) error {
-	switch strings.ToLower(command) {
-	case "up":
-		if steps > 0 {
-			err := m.Steps(steps)
-			if err != nil && err != migrate.ErrNoChange {
-				return fmt.Errorf("failed to apply migrations: %w", err)
-			}
-			fmt.Printf("Successfully applied %d migrations\n", steps)
-		} else {
-			err := m.Up()
-			if err != nil && err != migrate.ErrNoChange {
-				return fmt.Errorf("failed to apply all migrations: %w", err)
-			}
-			fmt.Println("Successfully applied all migrations")
-		}
-
-	case "down":
-		if steps > 0 {
-			err := m.Steps(-steps)
-			if err != nil && err != migrate.ErrNoChange {
-				return fmt.Errorf("failed to rollback migrations: %w", err)
-			}
-			fmt.Printf("Successfully rolled back %d migrations\n", steps)
-		} else {
-			err := m.Down()
-			if err != nil && err != migrate.ErrNoChange {
-				return fmt.Errorf("failed to rollback all migrations: %w", err)
-			}
-			fmt.Println("Successfully rolled back all migrations")
-		}
-
-	case "version":
-		version, dirty, err := m.Version()
-		if err != nil && err != migrate.ErrNilVersion {
-			return fmt.Errorf("failed to get migration version: %w", err)
-		}
-		fmt.Printf("Current migration version: %d (dirty: %v)\n", version, dirty)
-
-	default:
-		return fmt.Errorf("unknown command: %s", command)
-	}
-
-	return nil
-}
-
-// Custom logger for migrations
-type migrationLogger struct{}
-
-func (l *migrationLogger) Printf(format string, v ...interface{}) {
-	fmt.Printf(format, v...)
+// MigrationDriftTemplate returns the content of the drift detection of the
+// migrations tool; it reads the PostgreSQL catalogs, so only PostgreSQL
+// projects get it
+func MigrationDriftTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("migrations_drift.tmpl", map[string]any{"Pgx": cfg.Components.HasPgx()})
 }
 
-func (l *migrationLogger) Verbose() bool {
-	return true
-}
-`
+// MigrationDriftTestTemplate returns the content of the drift detection tests
+func MigrationDriftTestTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("migrations_drift_test.tmpl", map[string]any{"Pgx": cfg.Components.HasPgx()})
 }
 
 // MigrationsPackageTemplate returns the content of the migrations package
-func MigrationsPackageTemplate() string {
-	return `// internal/migrations/migrations.go - Embedded SQL migrations
-package migrations
-
-import (
-	"embed"
-	"io/fs"
-)
-
-//go:embed sql/*.sql
-var Migrations embed.FS
-
-// GetFS returns a filesystem with SQL migrations
-func GetFS() (fs.FS, error) {
-	return fs.Sub(Migrations, "sql")
+func MigrationsPackageTemplate() (string, error) {
+	return render("migrations_package.tmpl", nil)
 }
-`
-}
-
-// ModelGeneratorScriptTemplate returns the content of the model generator script
-func ModelGeneratorScriptTemplate() string {
-	return `#!/bin/sh
-# scripts/generate_models.sh - Database models generator from SQL migrations
 
-# Change to project root directory
-cd "$(dirname "$0")/.." || exit 1
+// MigrationsEmbedTemplate returns the content of the file embedding the SQL migrations
+func MigrationsEmbedTemplate() (string, error) {
+	return render("migrations_embed.tmpl", nil)
+}
 
-# Parse arguments
-ENV_FILE=".env"
-OUTPUT_DIR="internal/db/models"
-MIGRATIONS_DIR="internal/migrations/sql"
-FROM_MIGRATIONS=true
+// MigrationsExternalTemplate returns the content of the file used instead of
+// embed.go when the SQL migrations are left out of the binary
+func MigrationsExternalTemplate() (string, error) {
+	return render("migrations_external.tmpl", nil)
+}
 
-print_usage() {
-  echo "Usage: $0 [options]"
-  echo "Options:"
-  echo "  -e, --env=ENV_FILE     Path to .env file [default: .env]"
-  echo "  -o, --output=DIR       Output directory for models [default: internal/db/models]"
-  echo "  -m, --migrations=DIR   Directory with migration files [default: internal/migrations/sql]"
-  echo "  -d, --from-db          Generate models from database instead of migrations"
-  echo "  -h, --help             Show this help message"
+// MigrationsPackageTestTemplate returns the content of the migrations package tests
+func MigrationsPackageTestTemplate() (string, error) {
+	return render("migrations_package_test.tmpl", nil)
 }
 
-while [ $# -gt 0 ]; do
-  case "$1" in
-    -e=*|--env=*)
-      ENV_FILE="${1#*=}"
-      shift
-      ;;
-    -o=*|--output=*)
-      OUTPUT_DIR="${1#*=}"
-      shift
-      ;;
-    -m=*|--migrations=*)
-      MIGRATIONS_DIR="${1#*=}"
-      shift
-      ;;
-    -d|--from-db)
-      FROM_MIGRATIONS=false
-      shift
-      ;;
-    -h|--help)
-      print_usage
-      exit 0
-      ;;
-    *)
-      echo "Unknown option: $1"
-      print_usage
-      exit 1
-      ;;
-  esac
-done
+// MigrationChecksumsTemplate returns the content of the checksum manifest of
+// the initial migrations, given the content of each file as written
+func MigrationChecksumsTemplate(files map[string][]byte) (string, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-# Check if .env file exists
-if [ ! -f "$ENV_FILE" ]; then
-  echo "Warning: $ENV_FILE file not found"
-fi
+	type checksum struct{ Name, Sum string }
+	checksums := make([]checksum, len(names))
+	for i, name := range names {
+		sum := sha256.Sum256(files[name])
+		checksums[i] = checksum{Name: name, Sum: hex.EncodeToString(sum[:])}
+	}
+	return render("migrations_checksums.tmpl", map[string]any{"Files": checksums})
+}
 
-# Run model generator tool
-echo "Generating models from migrations..."
-if [ "$FROM_MIGRATIONS" = true ]; then
-  go run ./scripts/modelgen/modelgen.go -env="$ENV_FILE" -output="$OUTPUT_DIR" -migrations="$MIGRATIONS_DIR" -from-migrations=true
-else
-  go run ./scripts/modelgen/modelgen.go -env="$ENV_FILE" -output="$OUTPUT_DIR" -from-migrations=false
-fi
-`
+// ModelGeneratorScriptTemplate returns the content of the model generator script
+func ModelGeneratorScriptTemplate() (string, error) {
+	return render("migrations_generate_models.tmpl", nil)
 }
 
 // MigrationFileTemplate returns the content of the initial migration file
-func MigrationFileTemplate() string {
-	return `-- Create users table
-CREATE TABLE IF NOT EXISTS users (
-    id SERIAL PRIMARY KEY,
-    username VARCHAR(255) NOT NULL UNIQUE,
-    email VARCHAR(255) NOT NULL UNIQUE,
-    password VARCHAR(255) NOT NULL,
-    created_at TIMESTAMP NOT NULL,
-    updated_at TIMESTAMP NOT NULL
-);
-
--- Create indexes
-CREATE INDEX IF NOT EXISTS idx_users_username ON users(username);
-CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
-`
+func MigrationFileTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("migration_up.tmpl", map[string]any{
+		"IDColumn":      databaseEngine(cfg).IDColumn,
+		"MySQL":         cfg.Components.Database == config.ComponentMySQL,
+		"LoginAttempts": cfg.Components.HasLoginAttemptsTable(),
+	})
 }
 
 // MigrationDownFileTemplate returns the content of the initial down migration file
-func MigrationDownFileTemplate() string {
-	return `-- Drop indexes
-DROP INDEX IF EXISTS idx_users_email;
-DROP INDEX IF EXISTS idx_users_username;
+func MigrationDownFileTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("migration_down.tmpl", map[string]any{
+		"MySQL":         cfg.Components.Database == config.ComponentMySQL,
+		"LoginAttempts": cfg.Components.HasLoginAttemptsTable(),
+	})
+}
+
+// migrateURLFunc returns the migrateURL function converting a connection string
+// into a golang-migrate database URL, shared by migtool and the migrations
+// applied at startup, and the standard packages it imports besides fmt.
+// golang-migrate selects its database driver by URL scheme; PostgreSQL
+// connection strings already are URLs, the other engines need the prefix.
+func migrateURLFunc(cfg config.ProjectConfig) (string, []string, error) {
+	var imports []string
+	switch {
+	case cfg.Components.HasPgx(), cfg.Components.Database == config.ComponentMySQL:
+		imports = []string{"strings"}
+	case cfg.Components.Database == config.ComponentSQLite:
+		imports = []string{"os", "path/filepath", "strings"}
+	}
 
--- Drop tables
-DROP TABLE IF EXISTS users;
-`
+	migrateURL, err := render("migrate_url.tmpl", map[string]any{
+		"Scheme": databaseEngine(cfg).MigrateScheme,
+		"Pgx":    cfg.Components.HasPgx(),
+		"MySQL":  cfg.Components.Database == config.ComponentMySQL,
+		"SQLite": cfg.Components.Database == config.ComponentSQLite,
+	})
+	return migrateURL, imports, err
 }
@@ -3,17 +3,102 @@ package templates
 
 import (
 	_ "embed" // Required for go:embed
-	// Keep fmt for Sprintf - Removed fmt import as it's no longer used
-	_ "github.com/lib/pq"
+	"strings"
+
+	"github.com/neor-it/go-project-gen/internal/config"
 )
 
 //go:embed tmpls/modelgen_script.tmpl
 var modelGeneratorScriptContent string
 
+//go:embed tmpls/modelgen_naming.tmpl
+var modelGeneratorNaming string
+
+//go:embed tmpls/modelgen_naming_test.tmpl
+var modelGeneratorNamingTest string
+
+//go:embed tmpls/modelgen_docs.tmpl
+var modelGeneratorDocs string
+
+//go:embed tmpls/modelgen_docs_test.tmpl
+var modelGeneratorDocsTest string
+
+//go:embed tmpls/modelgen_repository.tmpl
+var modelGeneratorRepository string
+
+//go:embed tmpls/modelgen_repository_test.tmpl
+var modelGeneratorRepositoryTest string
+
+//go:embed tmpls/modelgen_dialect_postgres.tmpl
+var modelGeneratorPostgresDialect string
+
+//go:embed tmpls/modelgen_dialect_mysql.tmpl
+var modelGeneratorMySQLDialect string
+
+//go:embed tmpls/modelgen_dialect_sqlite.tmpl
+var modelGeneratorSQLiteDialect string
+
 // ModelGeneratorFullTemplate returns the complete content of the model generator tool,
 // read from the embedded modelgen_script.tmpl file.
-func ModelGeneratorFullTemplate() string {
+func ModelGeneratorFullTemplate() (string, error) {
 	// The content is embedded directly into the modelGeneratorScriptContent variable by the go:embed directive.
 	// We no longer need fmt.Sprintf here.
-	return modelGeneratorScriptContent
+	return modelGeneratorScriptContent, nil
 } // End of ModelGeneratorFullTemplate
+
+// ModelGeneratorDialectTemplate returns the database-specific part of the model generator:
+// the driver, the schema introspection queries and the SQL to Go type mapping
+func ModelGeneratorDialectTemplate(cfg config.ProjectConfig) (string, error) {
+	switch cfg.Components.Database {
+	case config.ComponentMySQL:
+		return modelGeneratorMySQLDialect, nil
+	case config.ComponentSQLite:
+		return modelGeneratorSQLiteDialect, nil
+	}
+	if cfg.Components.HasPgx() {
+		// The catalogs are the same, only the driver reading them changes
+		return strings.NewReplacer(
+			`_ "github.com/lib/pq"`, `_ "`+pgxEngine.DriverImport+`"`,
+			`driverName = "postgres"`, `driverName = "`+pgxEngine.DriverName+`"`,
+		).Replace(modelGeneratorPostgresDialect), nil
+	}
+	return modelGeneratorPostgresDialect, nil
+}
+
+// ModelGeneratorNamingTemplate returns the naming conventions part of the model generator,
+// read from modelgen.yaml
+func ModelGeneratorNamingTemplate() (string, error) {
+	return modelGeneratorNaming, nil
+}
+
+// ModelGeneratorNamingTestTemplate returns the tests of the model generator naming conventions
+func ModelGeneratorNamingTestTemplate() (string, error) {
+	return modelGeneratorNamingTest, nil
+}
+
+// ModelGeneratorDocsTemplate returns the schema documentation part of the model
+// generator, rendering the parsed migrations as Markdown with a Mermaid ER diagram
+func ModelGeneratorDocsTemplate() (string, error) {
+	return modelGeneratorDocs, nil
+}
+
+// ModelGeneratorDocsTestTemplate returns the tests of the schema documentation
+func ModelGeneratorDocsTestTemplate() (string, error) {
+	return modelGeneratorDocsTest, nil
+}
+
+// ModelGeneratorRepositoryTemplate returns the repository part of the model
+// generator, writing a repository for every table with -repositories
+func ModelGeneratorRepositoryTemplate() (string, error) {
+	return modelGeneratorRepository, nil
+}
+
+// ModelGeneratorRepositoryTestTemplate returns the tests of the repository generation
+func ModelGeneratorRepositoryTestTemplate() (string, error) {
+	return modelGeneratorRepositoryTest, nil
+}
+
+// ModelGeneratorConfigTemplate returns the content of the modelgen.yaml file
+func ModelGeneratorConfigTemplate() (string, error) {
+	return render("modelgen_config.tmpl", nil)
+}
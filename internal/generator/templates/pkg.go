@@ -0,0 +1,106 @@
+// internal/generator/templates/pkg.go - Templates for the reusable packages under pkg/
+package templates
+
+import (
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// ClockTemplate returns the content of the pkg/clock/clock.go file
+func ClockTemplate() (string, error) {
+	return render("pkg_clock.tmpl", nil)
+}
+
+// ClockTestTemplate returns the content of the pkg/clock/clock_test.go file
+func ClockTestTemplate() (string, error) {
+	return render("pkg_clock_test.tmpl", nil)
+}
+
+// IDTemplate returns the content of the pkg/id/id.go file
+func IDTemplate() (string, error) {
+	return render("pkg_id.tmpl", nil)
+}
+
+// IDTestTemplate returns the content of the pkg/id/id_test.go file
+func IDTestTemplate() (string, error) {
+	return render("pkg_id_test.tmpl", nil)
+}
+
+// HTTPClientTemplate returns the content of the pkg/httpclient/httpclient.go file;
+// with tracing, every attempt is sent through otelhttp, and with metrics it is counted
+func HTTPClientTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("pkg_http_client.tmpl", map[string]any{"Cfg": cfg})
+}
+
+// HTTPClientMetricsTemplate returns the content of the pkg/httpclient/metrics.go file
+func HTTPClientMetricsTemplate() (string, error) {
+	return render("pkg_http_client_metrics.tmpl", nil)
+}
+
+// HTTPClientTestTemplate returns the content of the pkg/httpclient/httpclient_test.go file
+func HTTPClientTestTemplate() (string, error) {
+	return render("pkg_http_client_test.tmpl", nil)
+}
+
+// HasPasswords reports whether the project gets pkg/password: it hashes the passwords
+// of the users signing up and of the users created through the users API
+func HasPasswords(cfg config.ProjectConfig) bool {
+	return cfg.Components.Auth || (cfg.Components.HTTP && cfg.Components.HasDatabase())
+}
+
+// PasswordTemplate returns the content of the pkg/password/password.go file
+func PasswordTemplate() (string, error) {
+	return render("pkg_password.tmpl", nil)
+}
+
+// PasswordTestTemplate returns the content of the pkg/password/password_test.go file
+func PasswordTestTemplate() (string, error) {
+	return render("pkg_password_test.tmpl", nil)
+}
+
+// RateLimitTemplate returns the content of the pkg/ratelimit/ratelimit.go file
+func RateLimitTemplate() (string, error) {
+	return render("pkg_ratelimit.tmpl", nil)
+}
+
+// RateLimitTestTemplate returns the content of the pkg/ratelimit/ratelimit_test.go file
+func RateLimitTestTemplate() (string, error) {
+	return render("pkg_ratelimit_test.tmpl", nil)
+}
+
+// HTTPCacheTemplate returns the content of the pkg/httpcache/httpcache.go file;
+// with metrics, every lookup is counted by result
+func HTTPCacheTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("pkg_httpcache.tmpl", map[string]any{"Metrics": cfg.Components.Metrics})
+}
+
+// HTTPCacheMetricsTemplate returns the content of the pkg/httpcache/metrics.go file
+func HTTPCacheMetricsTemplate() (string, error) {
+	return render("pkg_httpcache_metrics.tmpl", nil)
+}
+
+// HTTPCacheTestTemplate returns the content of the pkg/httpcache/httpcache_test.go file
+func HTTPCacheTestTemplate() (string, error) {
+	return render("pkg_httpcache_test.tmpl", nil)
+}
+
+// HasCircuitBreakers reports whether the project gets pkg/breaker: it guards the
+// database and the outbound HTTP client, so it comes with either of them
+func HasCircuitBreakers(cfg config.ProjectConfig) bool {
+	return cfg.Components.HTTP || cfg.Components.GRPC || cfg.Components.HasDatabase()
+}
+
+// BreakerTemplate returns the content of the pkg/breaker/breaker.go file; with
+// metrics, every state change is exported as a gauge
+func BreakerTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("pkg_breaker.tmpl", map[string]any{"Cfg": cfg})
+}
+
+// BreakerMetricsTemplate returns the content of the pkg/breaker/metrics.go file
+func BreakerMetricsTemplate() (string, error) {
+	return render("pkg_breaker_metrics.tmpl", nil)
+}
+
+// BreakerTestTemplate returns the content of the pkg/breaker/breaker_test.go file
+func BreakerTestTemplate() (string, error) {
+	return render("pkg_breaker_test.tmpl", nil)
+}
@@ -0,0 +1,47 @@
+// internal/generator/templates/pkgmodules.go - Templates for the nested modules of pkg/
+package templates
+
+import (
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// pkgModule is a nested module of pkg/ as the templates see it
+type pkgModule struct {
+	// Name is the directory of the module in pkg/ and Path its module path
+	Name string
+	Path string
+}
+
+// PkgModuleGoModTemplate returns the content of the go.mod file of the nested module
+// published from pkg/<name>
+func PkgModuleGoModTemplate(cfg config.ProjectConfig, name string) (string, error) {
+	return render("pkg_module_go_mod.tmpl", map[string]any{
+		"Cfg":  cfg,
+		"Path": cfg.PkgModulePath(name),
+	})
+}
+
+// PkgModuleDocTemplate returns the content of the doc.go file of the nested module
+// published from pkg/<name>
+func PkgModuleDocTemplate(cfg config.ProjectConfig, name string) (string, error) {
+	return render("pkg_module_doc.tmpl", map[string]any{
+		"Name": name,
+		"Path": cfg.PkgModulePath(name),
+	})
+}
+
+// pkgModulesReadmeSection returns the README section on the nested modules of pkg/
+func pkgModulesReadmeSection(cfg config.ProjectConfig) (string, error) {
+	if len(cfg.PkgModules) == 0 {
+		return "", nil
+	}
+
+	modules := make([]pkgModule, len(cfg.PkgModules))
+	for i, name := range cfg.PkgModules {
+		modules[i] = pkgModule{Name: name, Path: cfg.PkgModulePath(name)}
+	}
+	return render("pkg_modules_readme.tmpl", map[string]any{
+		"Modules": modules,
+		"First":   modules[0],
+	})
+}
@@ -0,0 +1,123 @@
+// internal/generator/templates/registry.go - Templates for container registry documentation
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// registryLabels maps registry kinds to their display names
+var registryLabels = map[string]string{
+	config.RegistryDockerHub: "Docker Hub",
+	config.RegistryGHCR:      "GitHub Container Registry",
+	config.RegistryGitLab:    "GitLab Container Registry",
+	config.RegistryECR:       "Amazon ECR",
+	config.RegistryGAR:       "Google Artifact Registry",
+	config.RegistryCustom:    "a private registry",
+}
+
+// registryCISecrets describes the CI secrets needed to push to the project registry
+func registryCISecrets(cfg config.ProjectConfig) string {
+	registry := cfg.Registry
+	switch cfg.Components.CIProvider {
+	case config.CIProviderGitLab:
+		return registryGitLabVariables(registry)
+	case config.CIProviderBitbucket:
+		return registryBitbucketVariables(registry)
+	case config.CIProviderGitea:
+		return registryGiteaSecrets(registry)
+	}
+
+	switch registry.Kind {
+	case config.RegistryGHCR:
+		return "No secrets are needed: the workflow pushes with the built-in `GITHUB_TOKEN` and the `packages: write` permission."
+	case config.RegistryECR:
+		return "The workflow assumes an IAM role through GitHub OIDC, without stored keys. Set the `AWS_ROLE_ARN` secret to a role that trusts `token.actions.githubusercontent.com` for this repository and may push to the ECR repository."
+	case config.RegistryGAR:
+		return "The workflow authenticates with Workload Identity Federation, without stored keys. Set the `GCP_WORKLOAD_IDENTITY_PROVIDER` and `GCP_SERVICE_ACCOUNT` secrets to a provider trusting this repository and a service account with the Artifact Registry Writer role."
+	case config.RegistryGitLab:
+		return "Set the `REGISTRY_USERNAME` and `REGISTRY_PASSWORD` secrets to a GitLab deploy token with the `write_registry` scope."
+	case config.RegistryCustom:
+		return "Set the `REGISTRY_USERNAME` and `REGISTRY_PASSWORD` secrets to credentials that may push to `" + registry.Host + "`."
+	}
+	return "Set the `DOCKER_USERNAME` and `DOCKER_PASSWORD` secrets; use a Docker Hub access token as the password."
+}
+
+// registryGitLabVariables describes the GitLab CI/CD variables needed to push to the project registry
+func registryGitLabVariables(registry config.Registry) string {
+	switch registry.Kind {
+	case config.RegistryGitLab:
+		return "No variables are needed: the pipeline logs in with the predefined `CI_REGISTRY_USER` and `CI_REGISTRY_PASSWORD` job credentials and pushes to `$CI_REGISTRY_IMAGE`."
+	case config.RegistryECR:
+		return "The pipeline assumes an IAM role with a GitLab ID token, without stored keys. Set the `AWS_ROLE_ARN` CI/CD variable to a role that trusts the GitLab OIDC provider for this project and may push to the ECR repository."
+	case config.RegistryGAR:
+		return "Set the `GCP_SERVICE_ACCOUNT_KEY` CI/CD variable, of type File, to the JSON key of a service account with the Artifact Registry Writer role."
+	case config.RegistryGHCR:
+		return "Set the masked `REGISTRY_USERNAME` and `REGISTRY_PASSWORD` CI/CD variables to your GitHub username and a personal access token with the `write:packages` scope."
+	case config.RegistryCustom:
+		return "Set the masked `REGISTRY_USERNAME` and `REGISTRY_PASSWORD` CI/CD variables to credentials that may push to `" + registry.Host + "`."
+	}
+	return "Set the masked `DOCKER_USERNAME` and `DOCKER_PASSWORD` CI/CD variables; use a Docker Hub access token as the password."
+}
+
+// registryBitbucketVariables describes the Bitbucket repository variables needed to push to the project registry
+func registryBitbucketVariables(registry config.Registry) string {
+	switch registry.Kind {
+	case config.RegistryECR:
+		return "The pipeline assumes an IAM role with the OIDC token of the step, without stored keys. Set the `AWS_ROLE_ARN` repository variable to a role that trusts the Bitbucket OIDC provider of your workspace and may push to the ECR repository."
+	case config.RegistryGAR:
+		return "Set the secured `GCP_SERVICE_ACCOUNT_KEY` repository variable to the base64-encoded JSON key of a service account with the Artifact Registry Writer role."
+	case config.RegistryGHCR:
+		return "Set the secured `REGISTRY_USERNAME` and `REGISTRY_PASSWORD` repository variables to your GitHub username and a personal access token with the `write:packages` scope."
+	case config.RegistryGitLab:
+		return "Set the secured `REGISTRY_USERNAME` and `REGISTRY_PASSWORD` repository variables to a GitLab deploy token with the `write_registry` scope."
+	case config.RegistryCustom:
+		return "Set the secured `REGISTRY_USERNAME` and `REGISTRY_PASSWORD` repository variables to credentials that may push to `" + registry.Host + "`."
+	}
+	return "Set the secured `DOCKER_USERNAME` and `DOCKER_PASSWORD` repository variables; use a Docker Hub access token as the password."
+}
+
+// registryGiteaSecrets describes the Gitea Actions secrets needed to push to the project registry
+func registryGiteaSecrets(registry config.Registry) string {
+	switch registry.Kind {
+	case config.RegistryECR:
+		return "Gitea Actions has no OIDC tokens: set the `AWS_ACCESS_KEY_ID` and `AWS_SECRET_ACCESS_KEY` secrets to the keys of an IAM user that may push to the ECR repository."
+	case config.RegistryGAR:
+		return "Set the `GCP_SERVICE_ACCOUNT_KEY` secret to the JSON key of a service account with the Artifact Registry Writer role."
+	case config.RegistryGHCR:
+		return "Set the `REGISTRY_USERNAME` and `REGISTRY_PASSWORD` secrets to your GitHub username and a personal access token with the `write:packages` scope."
+	case config.RegistryGitLab:
+		return "Set the `REGISTRY_USERNAME` and `REGISTRY_PASSWORD` secrets to a GitLab deploy token with the `write_registry` scope."
+	case config.RegistryCustom:
+		return "Set the `REGISTRY_USERNAME` and `REGISTRY_PASSWORD` secrets to credentials that may push to `" + registry.Host + "`; for the package registry of your Gitea instance, a user and an access token with the `write:package` scope."
+	}
+	return "Set the `DOCKER_USERNAME` and `DOCKER_PASSWORD` secrets; use a Docker Hub access token as the password."
+}
+
+// registryReadmeSection returns the README section describing where the image is pushed
+func registryReadmeSection(cfg config.ProjectConfig) (string, error) {
+	if !cfg.Components.Docker {
+		return "", nil
+	}
+
+	registry := cfg.Registry
+	label := registryLabels[registry.Kind]
+	if label == "" {
+		label = registryLabels[config.RegistryDockerHub]
+	}
+
+	// Kubernetes expects the legacy index URL for Docker Hub credentials
+	server := registry.LoginHost()
+	if registry.Kind == config.RegistryDockerHub || registry.Kind == "" {
+		server = "https://index.docker.io/v1/"
+	}
+
+	return render("registry_readme.tmpl", map[string]any{
+		"Cfg":       cfg,
+		"Label":     label,
+		"Image":     cfg.Image(),
+		"LoginHost": registry.LoginHost(),
+		"Server":    server,
+		"Pipeline":  cfg.Components.CICD && cfg.Components.CIProvider != config.CIProviderNone,
+		"Secrets":   registryCISecrets(cfg),
+		"GitLab":    cfg.Components.CIProvider == config.CIProviderGitLab,
+		"Bitbucket": cfg.Components.CIProvider == config.CIProviderBitbucket,
+	})
+}
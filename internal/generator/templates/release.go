@@ -0,0 +1,73 @@
+// internal/generator/templates/release.go - Templates for the release automation
+package templates
+
+import (
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// semanticReleaseNode is the Node.js version semantic-release runs on in the pipelines
+const semanticReleaseNode = "22"
+
+// releaseTool returns the tool cutting the releases: release-please on GitHub, which
+// works through release pull requests, and semantic-release on the other providers
+func releaseTool(cfg config.ProjectConfig) string {
+	if cfg.Components.CIProvider == config.CIProviderGitHub {
+		return "release-please"
+	}
+	return "semantic-release"
+}
+
+// ChangelogTemplate returns the content of the CHANGELOG.md file, holding an Unreleased
+// section until the first release replaces it
+func ChangelogTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("release_changelog.tmpl", map[string]any{"Tool": releaseTool(cfg)})
+}
+
+// ReleasePleaseConfigTemplate returns the content of the release-please-config.json file
+func ReleasePleaseConfigTemplate() (string, error) {
+	return render("release_please_config.tmpl", nil)
+}
+
+// ReleasePleaseManifestTemplate returns the content of the .release-please-manifest.json
+// file, which release-please updates with the version of each release
+func ReleasePleaseManifestTemplate() (string, error) {
+	return render("release_please_manifest.tmpl", nil)
+}
+
+// GitHubReleaseWorkflowTemplate returns the content of the GitHub Actions workflow running
+// release-please on pushes to the default branch
+func GitHubReleaseWorkflowTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("release_github_workflow.tmpl", map[string]any{"Cfg": cfg})
+}
+
+// SemanticReleaseConfigTemplate returns the content of the .releaserc.yml file. The
+// changelog is committed back with [skip ci], so the release doesn't trigger another one.
+func SemanticReleaseConfigTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("release_semantic_config.tmpl", map[string]any{
+		"Cfg":    cfg,
+		"GitLab": cfg.Components.CIProvider == config.CIProviderGitLab,
+	})
+}
+
+// semanticReleaseCommand returns the command running semantic-release with the plugins
+// .releaserc.yml lists, without a package.json to install them from
+func semanticReleaseCommand(cfg config.ProjectConfig) string {
+	packages := "-p semantic-release@24 -p @semantic-release/exec@6 -p @semantic-release/changelog@6 -p @semantic-release/git@10"
+	if cfg.Components.CIProvider == config.CIProviderGitLab {
+		packages += " -p @semantic-release/gitlab@13"
+	}
+	return "npx --yes " + packages + " semantic-release"
+}
+
+// releaseReadmeSection returns the README section describing how releases are cut
+func releaseReadmeSection(cfg config.ProjectConfig) (string, error) {
+	if !cfg.HasRelease() {
+		return "", nil
+	}
+	return render("release_readme.tmpl", map[string]any{
+		"Cfg":       cfg,
+		"GitHub":    cfg.Components.CIProvider == config.CIProviderGitHub,
+		"GitLab":    cfg.Components.CIProvider == config.CIProviderGitLab,
+		"Bitbucket": cfg.Components.CIProvider == config.CIProviderBitbucket,
+	})
+}
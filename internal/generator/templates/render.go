@@ -0,0 +1,73 @@
+// internal/generator/templates/render.go - Rendering of the embedded template files
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	pathpkg "path"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// templateFiles holds the template files in tmpls/. The modelgen_*.tmpl files are
+// embedded on their own and copied verbatim; every other file is rendered by render.
+//
+//go:embed tmpls/*.tmpl
+var templateFiles embed.FS
+
+// Template files delimit their actions with {% and %}, since {{ is common in the
+// generated code: Go composite literals, GitHub Actions expressions and the
+// {{ .ModuleName }} placeholders the generator fills in when writing a file
+const (
+	leftDelim  = "{%"
+	rightDelim = "%}"
+)
+
+// templateFuncs are the functions available to the template files
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"quote": strconv.Quote,
+	"upper": strings.ToUpper,
+}
+
+// parsedTemplates are the parsed template files, by file name, and parseErr the
+// error of the first file that failed to parse, returned by every render
+var parsedTemplates, parseErr = parseTemplates()
+
+// parseTemplates parses the template files one at a time, so an error names
+// the file and shows its source
+func parseTemplates() (*template.Template, error) {
+	set := template.New("").Delims(leftDelim, rightDelim).Option("missingkey=error").Funcs(templateFuncs)
+	paths, err := fs.Glob(templateFiles, "tmpls/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the template files: %w", err)
+	}
+	for _, path := range paths {
+		source, err := templateFiles.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+		}
+		if _, err := set.New(pathpkg.Base(path)).Parse(string(source)); err != nil {
+			return nil, NewTemplateError(path, "", "parse", string(source), err)
+		}
+	}
+	return set, nil
+}
+
+// render executes the template file tmpls/name with data. The templates only
+// read the configuration, so an error is a bug in the template file; it is a
+// *TemplateError showing the offending line.
+func render(name string, data map[string]any) (string, error) {
+	if parseErr != nil {
+		return "", parseErr
+	}
+
+	var b strings.Builder
+	if err := parsedTemplates.ExecuteTemplate(&b, name, data); err != nil {
+		source, _ := templateFiles.ReadFile("tmpls/" + name)
+		return "", NewTemplateError("tmpls/"+name, "", "execute", string(source), err)
+	}
+	return b.String(), nil
+}
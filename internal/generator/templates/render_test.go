@@ -0,0 +1,59 @@
+package templates
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestTemplateFilesParse(t *testing.T) {
+	count := 0
+	err := fs.WalkDir(templateFiles, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		count++
+
+		source, err := templateFiles.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if _, err := template.New(path).Delims(leftDelim, rightDelim).Funcs(templateFuncs).Parse(string(source)); err != nil {
+			t.Errorf("template %s doesn't parse: %v", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk the template files: %v", err)
+	}
+	if count == 0 {
+		t.Fatal("no template files are embedded")
+	}
+	if parseErr != nil {
+		t.Errorf("parseTemplates() error = %v", parseErr)
+	}
+}
+
+func TestRenderReturnsTemplateError(t *testing.T) {
+	// The compose file reads .Cfg, which the data lacks
+	_, err := render("docker_compose.tmpl", map[string]any{})
+
+	var tmplErr *TemplateError
+	if !errors.As(err, &tmplErr) {
+		t.Fatalf("render() error = %v, want a *TemplateError", err)
+	}
+	if tmplErr.Template != "tmpls/docker_compose.tmpl" || tmplErr.Phase != "execute" {
+		t.Errorf("Template, Phase = %q, %q, want tmpls/docker_compose.tmpl, execute", tmplErr.Template, tmplErr.Phase)
+	}
+	if tmplErr.Line == 0 || !strings.Contains(tmplErr.Snippet, ".Cfg") {
+		t.Errorf("Line = %d, Snippet = %q, want the line reading .Cfg", tmplErr.Line, tmplErr.Snippet)
+	}
+}
+
+func TestRenderUnknownTemplate(t *testing.T) {
+	if _, err := render("missing.tmpl", nil); err == nil {
+		t.Error("render() error = nil, want an error for a template file that doesn't exist")
+	}
+}
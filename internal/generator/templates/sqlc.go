@@ -0,0 +1,43 @@
+// internal/generator/templates/sqlc.go - Templates for the sqlc data layer
+package templates
+
+import (
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// SQLCConfigTemplate returns the content of the sqlc.yaml file
+func SQLCConfigTemplate() (string, error) {
+	return render("sqlc_config.tmpl", nil)
+}
+
+// SQLCQueriesTemplate returns the content of the internal/db/queries/users.sql file,
+// the queries of the user repository
+func SQLCQueriesTemplate() (string, error) {
+	return render("sqlc_queries.tmpl", nil)
+}
+
+// SQLCScriptTemplate returns the content of the scripts/sqlc.sh file
+func SQLCScriptTemplate() (string, error) {
+	return render("sqlc_script.tmpl", nil)
+}
+
+// SQLCDBTemplate returns the content of the internal/db/sqlc/db.go file. The files
+// of internal/db/sqlc are what sqlc generate writes for the queries and the initial
+// migration, so the project builds without sqlc installed and make sqlc leaves them
+// unchanged.
+func SQLCDBTemplate() (string, error) {
+	return render("sqlc_db.tmpl", nil)
+}
+
+// SQLCModelsTemplate returns the content of the internal/db/sqlc/models.go file,
+// with a struct per table of the initial migration
+func SQLCModelsTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("sqlc_models.tmpl", map[string]any{
+		"LoginAttempts": cfg.Components.HasLoginAttemptsTable(),
+	})
+}
+
+// SQLCUsersTemplate returns the content of the internal/db/sqlc/users.sql.go file
+func SQLCUsersTemplate() (string, error) {
+	return render("sqlc_users.tmpl", nil)
+}
@@ -0,0 +1,62 @@
+// internal/generator/templates/stats.go - Templates for the request coalescing example
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// statsFramework holds the framework-specific parts of handlers/stats.go
+type statsFramework struct {
+	// Import is the framework import, empty for net/http, and RouterParam is the
+	// parameter of Register
+	Import      string
+	RouterParam string
+	// Route registers the Get handler on /stats, see usersPath
+	Route string
+	// HandlerSignature is the parameter list and result of a handler
+	HandlerSignature string
+	// Ctx reads the request context
+	Ctx string
+	// Header sets the response header %[1]s to %[2]s
+	Header string
+	// Respond writes the body %[2]s as JSON with the status %[1]s, returning from
+	// the handler if needed
+	Respond string
+}
+
+// APIStatsHandlerTemplate returns the content of the handlers/stats.go file
+func APIStatsHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	return frameworkFor(cfg).StatsHandler(cfg)
+}
+
+// statsHandlerTemplate returns the content of the handlers/stats.go file; the
+// coalescing logic is shared and only the thin handler depends on the framework
+func statsHandlerTemplate(cfg config.ProjectConfig, f statsFramework) (string, error) {
+	thirdParty := []string{`"golang.org/x/sync/singleflight"`}
+	if f.Import != "" {
+		thirdParty = append(thirdParty, f.Import)
+	}
+	if cfg.Components.Metrics {
+		thirdParty = append(thirdParty,
+			`"github.com/prometheus/client_golang/prometheus"`,
+			`"github.com/prometheus/client_golang/prometheus/promauto"`,
+		)
+	}
+
+	return render("api_stats.tmpl", map[string]any{
+		"Cfg":               cfg,
+		"Framework":         f,
+		"ThirdPartyImports": importLines(thirdParty),
+	})
+}
+
+// APIStatsHandlerTestTemplate returns the content of the handlers/stats_test.go file
+func APIStatsHandlerTestTemplate(cfg config.ProjectConfig) (string, error) {
+	return render("api_stats_test.tmpl", map[string]any{
+		"Cfg":    cfg,
+		"Router": frameworkFor(cfg).TestRouter,
+	})
+}
+
+// DBStatsRepositoryTemplate returns the content of the repositories/stats.go file
+func DBStatsRepositoryTemplate() (string, error) {
+	return render("db_stats_repository.tmpl", nil)
+}
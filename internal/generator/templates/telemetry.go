@@ -0,0 +1,7 @@
+// internal/generator/templates/telemetry.go - Templates for OpenTelemetry tracing
+package templates
+
+// TracerTemplate returns the content of the tracer.go file
+func TracerTemplate() (string, error) {
+	return render("telemetry_tracer.tmpl", nil)
+}
@@ -9,67 +9,231 @@ import (
 type AllTemplates struct {
 	Config    ConfigTemplates
 	API       APITemplates
+	GRPC      GRPCTemplates
 	DB        DBTemplates
+	Cache     CacheTemplates
 	Migration MigrationTemplates
 	Docker    DockerTemplates
 	Main      MainTemplates
 	Logger    LoggerTemplates
 	CICD      CICDTemplates
+	Makefile  MakefileTemplates
+	Dev       DevTemplates
+	Workspace WorkspaceTemplates
+	PkgModule PkgModuleTemplates
+	Companion CompanionTemplates
+	Telemetry TelemetryTemplates
+	Auth      AuthTemplates
+	Pkg       PkgTemplates
+	Admin     AdminTemplates
 }
 
 // ConfigTemplates interface represents templates for configuration
 type ConfigTemplates interface {
-	ConfigTemplate(config.ProjectConfig) string
+	ConfigTemplate(config.ProjectConfig) (string, error)
+	ConfigTestTemplate(config.ProjectConfig) (string, error)
 }
 
 // APITemplates interface contains methods for generating API templates
 type APITemplates interface {
-	APIServerTemplate() string
-	APIHandlersTemplate() string
-	APIMiddlewareTemplate() string
-	APIRoutesTemplate() string
+	APIServerTemplate(config.ProjectConfig) (string, error)
+	APIServerTestTemplate() (string, error)
+	APIBasePathTemplate() (string, error)
+	APIBasePathTestTemplate() (string, error)
+	APIHandlersTemplate(config.ProjectConfig) (string, error)
+	APIHealthHandlerTemplate(config.ProjectConfig) (string, error)
+	APIStatusHandlerTemplate(config.ProjectConfig) (string, error)
+	APIReadyHandlerTemplate(config.ProjectConfig) (string, error)
+	APIMiddlewareTemplate(config.ProjectConfig) (string, error)
+	APIRequestIDTemplate() (string, error)
+	APIRoutesTemplate(config.ProjectConfig) (string, error)
+	APIMetricsMiddlewareTemplate(config.ProjectConfig) (string, error)
+	APITracingMiddlewareTemplate(config.ProjectConfig) (string, error)
+	APIMiddlewareTestTemplate(config.ProjectConfig) (string, error)
+	APIHandlersTestTemplate(config.ProjectConfig) (string, error)
+	APIHandlerFixturesTemplate(config.ProjectConfig) ([]Fixture, error)
+	APIAuthMiddlewareTemplate(config.ProjectConfig) (string, error)
+	APIRateLimitMiddlewareTemplate(config.ProjectConfig) (string, error)
+	APICacheMiddlewareTemplate(config.ProjectConfig) (string, error)
+	APIAuthHandlerTemplate(config.ProjectConfig) (string, error)
+	APIUsersHandlerTemplate(config.ProjectConfig) (string, error)
+	APIStatsHandlerTemplate(config.ProjectConfig) (string, error)
+	APIStatsHandlerTestTemplate(config.ProjectConfig) (string, error)
+	APIDescriptorHandlerTemplate(config.ProjectConfig) (string, error)
+	APIOpenAPITemplate(config.ProjectConfig) (string, error)
+	APIOpenAPIEmbedTemplate() (string, error)
+	APIValidationTemplate() (string, error)
+	APIJSONBenchmarkTemplate(config.ProjectConfig) (string, error)
+}
+
+// TelemetryTemplates interface contains methods for generating OpenTelemetry templates
+type TelemetryTemplates interface {
+	TracerTemplate() (string, error)
+}
+
+// AuthTemplates interface contains methods for generating token authentication templates
+type AuthTemplates interface {
+	AuthTokensTemplate(config.ProjectConfig) (string, error)
+	AuthServiceTemplate() (string, error)
+	AuthStoreTemplate() (string, error)
+	AuthDatabaseStoreTemplate() (string, error)
+	AuthLockoutTemplate() (string, error)
+	AuthAttemptsRedisTemplate() (string, error)
+	AuthAttemptsDatabaseTemplate() (string, error)
+	AuthTestTemplate(config.ProjectConfig) (string, error)
+}
+
+// PkgTemplates interface contains methods for generating the reusable packages under pkg/
+type PkgTemplates interface {
+	ClockTemplate() (string, error)
+	ClockTestTemplate() (string, error)
+	IDTemplate() (string, error)
+	IDTestTemplate() (string, error)
+	HTTPClientTemplate(config.ProjectConfig) (string, error)
+	HTTPClientMetricsTemplate() (string, error)
+	HTTPClientTestTemplate() (string, error)
+	BreakerTemplate(config.ProjectConfig) (string, error)
+	BreakerMetricsTemplate() (string, error)
+	BreakerTestTemplate() (string, error)
+	PasswordTemplate() (string, error)
+	PasswordTestTemplate() (string, error)
+	RateLimitTemplate() (string, error)
+	RateLimitTestTemplate() (string, error)
+	HTTPCacheTemplate(config.ProjectConfig) (string, error)
+	HTTPCacheMetricsTemplate() (string, error)
+	HTTPCacheTestTemplate() (string, error)
+}
+
+// GRPCTemplates interface contains methods for generating gRPC and protobuf templates
+type GRPCTemplates interface {
+	GRPCServerTemplate() (string, error)
+	GRPCInterceptorsTemplate() (string, error)
+	GRPCServerTestTemplate() (string, error)
+	ProtoServiceTemplate(config.ProjectConfig) (string, error)
+	BufTemplate() (string, error)
+	BufGenTemplate() (string, error)
 }
 
 // DBTemplates interface contains methods for generating database templates
 type DBTemplates interface {
-	DBTemplate() string
+	DBTemplate(cfg config.ProjectConfig) (string, error)
+	DBTestTemplate(cfg config.ProjectConfig) (string, error)
 	DBModelsTemplate() string
-	DBRepositoriesTemplate() string
+	DBRepositoriesTemplate(cfg config.ProjectConfig) (string, error)
+	DBRepositoriesTestTemplate(cfg config.ProjectConfig) (string, error)
+	DBStatsRepositoryTemplate() (string, error)
+	DBLoginAttemptsRepositoryTemplate(cfg config.ProjectConfig) (string, error)
+	SQLCConfigTemplate() (string, error)
+	SQLCQueriesTemplate() (string, error)
+	SQLCScriptTemplate() (string, error)
+	SQLCDBTemplate() (string, error)
+	SQLCModelsTemplate(cfg config.ProjectConfig) (string, error)
+	SQLCUsersTemplate() (string, error)
+}
+
+// CacheTemplates interface contains methods for generating cache templates
+type CacheTemplates interface {
+	RedisTemplate() (string, error)
+	ResponseStoreTemplate() (string, error)
 }
 
 // MigrationTemplates interface represents templates for migrations
 type MigrationTemplates interface {
-	MigrationsScriptTemplate() string
-	MigrationToolTemplate() string
-	MigrationsPackageTemplate() string
-	ModelGeneratorScriptTemplate() string
-	ModelGeneratorFullTemplate() string
-	MigrationFileTemplate() string
-	MigrationDownFileTemplate() string
+	MigrationsScriptTemplate() (string, error)
+	MigrationToolTemplate(cfg config.ProjectConfig) (string, error)
+	MigrationDriftTemplate(cfg config.ProjectConfig) (string, error)
+	MigrationDriftTestTemplate(cfg config.ProjectConfig) (string, error)
+	MigrationsPackageTemplate() (string, error)
+	MigrationsEmbedTemplate() (string, error)
+	MigrationsExternalTemplate() (string, error)
+	MigrationsPackageTestTemplate() (string, error)
+	MigrationChecksumsTemplate(files map[string][]byte) (string, error)
+	ModelGeneratorScriptTemplate() (string, error)
+	ModelGeneratorFullTemplate() (string, error)
+	ModelGeneratorDialectTemplate(cfg config.ProjectConfig) (string, error)
+	ModelGeneratorNamingTemplate() (string, error)
+	ModelGeneratorNamingTestTemplate() (string, error)
+	ModelGeneratorDocsTemplate() (string, error)
+	ModelGeneratorDocsTestTemplate() (string, error)
+	ModelGeneratorRepositoryTemplate() (string, error)
+	ModelGeneratorRepositoryTestTemplate() (string, error)
+	ModelGeneratorConfigTemplate() (string, error)
+	MigrationFileTemplate(cfg config.ProjectConfig) (string, error)
+	MigrationDownFileTemplate(cfg config.ProjectConfig) (string, error)
 }
 
 // DockerTemplates represents templates for Docker
 type DockerTemplates interface {
-	DockerfileTemplate(config.ProjectConfig) string
-	DockerComposeTemplate(config.ProjectConfig) string
-	DockerignoreTemplate() string
+	DockerfileTemplate(config.ProjectConfig) (string, error)
+	DockerComposeTemplate(config.ProjectConfig) (string, error)
+	DockerignoreTemplate(config.ProjectConfig) (string, error)
+	PrometheusConfigTemplate(config.ProjectConfig) (string, error)
+	DockerTuningTemplate() (string, error)
+	DockerTuningTestTemplate() (string, error)
 }
 
 // MainTemplates represents templates for main application files
 type MainTemplates interface {
-	MainTemplate(config.ProjectConfig) string
-	GoModTemplate(string) string
-	GitignoreTemplate() string
-	ReadmeTemplate(config.ProjectConfig) string
-	AppTemplate(config.ProjectConfig) string
+	MainTemplate(config.ProjectConfig) (string, error)
+	GoModTemplate(config.ProjectConfig) (string, error)
+	GitignoreTemplate(config.ProjectConfig) (string, error)
+	ReadmeTemplate(config.ProjectConfig) (string, error)
+	AppTemplate(config.ProjectConfig) (string, error)
+	AppShutdownTemplate() (string, error)
+	AppShutdownTestTemplate() (string, error)
+}
+
+// AdminTemplates represents templates for the admin server
+type AdminTemplates interface {
+	AdminServerTemplate() (string, error)
+	AdminInfoTemplate(config.ProjectConfig) (string, error)
+	AdminTestTemplate() (string, error)
 }
 
 // LoggerTemplates represents templates for logging
 type LoggerTemplates interface {
-	LoggerTemplate() string
+	LoggerTemplate() (string, error)
 }
 
 // CICDTemplates represents templates for CI/CD
 type CICDTemplates interface {
-	GitHubWorkflowTemplate(config.ProjectConfig) string
+	GitHubWorkflowTemplate(config.ProjectConfig) (string, error)
+	GitLabCIPipelineTemplate(config.ProjectConfig) (string, error)
+}
+
+// MakefileTemplates represents templates for build automation
+type MakefileTemplates interface {
+	MakefileTemplate(config.ProjectConfig) (string, error)
+	GolangciConfigTemplate(config.ProjectConfig) (string, error)
+}
+
+// DevTemplates represents templates for local development tooling
+type DevTemplates interface {
+	AirConfigTemplate(config.ProjectConfig) (string, error)
+	DevScriptTemplate(config.ProjectConfig) (string, error)
+	ContributingTemplate(config.ProjectConfig) (string, error)
+}
+
+// WorkspaceTemplates represents templates for the root of a monorepo workspace
+type WorkspaceTemplates interface {
+	GoWorkTemplate(config.WorkspaceConfig) (string, error)
+	WorkspacePkgGoModTemplate(config.WorkspaceConfig) (string, error)
+	WorkspacePkgDocTemplate(config.WorkspaceConfig) (string, error)
+	WorkspaceMakefileTemplate(config.WorkspaceConfig) (string, error)
+	WorkspaceDockerComposeTemplate(config.WorkspaceConfig) (string, error)
+	WorkspaceGitignoreTemplate() (string, error)
+	WorkspaceReadmeTemplate(config.WorkspaceConfig) (string, error)
+}
+
+// PkgModuleTemplates represents templates for the nested modules published from pkg/
+type PkgModuleTemplates interface {
+	PkgModuleGoModTemplate(cfg config.ProjectConfig, name string) (string, error)
+	PkgModuleDocTemplate(cfg config.ProjectConfig, name string) (string, error)
+}
+
+// CompanionTemplates represents templates for modules developed alongside the project
+type CompanionTemplates interface {
+	ProjectGoWorkTemplate(config.ProjectConfig) (string, error)
+	CompanionReplacesTemplate(config.ProjectConfig) (string, error)
+	CompanionGitignoreTemplate() (string, error)
 }
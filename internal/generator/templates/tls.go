@@ -0,0 +1,63 @@
+// internal/generator/templates/tls.go - Templates for HTTPS and the local development certificates
+package templates
+
+import (
+	"strings"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+)
+
+// devCertsDir is the git-ignored directory make certs writes the certificates to
+const devCertsDir = ".certs"
+
+// HasWindowsTarget reports whether the project is cross-compiled for Windows,
+// which gets a PowerShell variant of the certificate script
+func HasWindowsTarget(cfg config.ProjectConfig) bool {
+	for _, target := range cfg.BuildTargets {
+		if strings.HasPrefix(target, "windows/") {
+			return true
+		}
+	}
+	return false
+}
+
+// DevCertsToolTemplate returns the content of the scripts/certs/main.go file
+func DevCertsToolTemplate() (string, error) {
+	return render("dev_certs_tool.tmpl", map[string]any{"CertsDir": devCertsDir})
+}
+
+// DevCertsToolTestTemplate returns the content of the scripts/certs/main_test.go file
+func DevCertsToolTestTemplate() (string, error) {
+	return render("dev_certs_tool_test.tmpl", nil)
+}
+
+// DevCertsScriptTemplate returns the content of the scripts/gen_dev_certs.sh script
+func DevCertsScriptTemplate() (string, error) {
+	return render("dev_certs_script.tmpl", map[string]any{"CertsDir": devCertsDir})
+}
+
+// DevCertsPowerShellTemplate returns the content of the scripts/gen_dev_certs.ps1 script
+func DevCertsPowerShellTemplate() (string, error) {
+	return render("dev_certs_powershell.tmpl", map[string]any{"CertsDir": devCertsDir})
+}
+
+// tlsMakefileTargets returns the Makefile targets creating the certificates and
+// serving HTTPS with them
+func tlsMakefileTargets(cfg config.ProjectConfig) (string, error) {
+	if !cfg.HasTLS() {
+		return "", nil
+	}
+	return render("tls_makefile.tmpl", map[string]any{"CertsDir": devCertsDir})
+}
+
+// tlsReadmeSection returns the README section on serving HTTPS locally
+func tlsReadmeSection(cfg config.ProjectConfig) (string, error) {
+	if !cfg.HasTLS() {
+		return "", nil
+	}
+	return render("tls_readme.tmpl", map[string]any{
+		"Cfg":      cfg,
+		"CertsDir": devCertsDir,
+		"Windows":  HasWindowsTarget(cfg),
+	})
+}
@@ -0,0 +1,66 @@
+// internal/generator/templates/users.go - Templates for the users CRUD handlers
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// usersFramework holds the framework-specific parts of handlers/users.go
+type usersFramework struct {
+	// DecodesJSON is set for frameworks decoding request bodies with encoding/json
+	DecodesJSON bool
+	// Import is the framework import, empty for net/http, and RouterParam is the
+	// parameter of Register
+	Import      string
+	RouterParam string
+	// Routes are the route registrations of the handlers, see usersPath
+	Routes string
+	// HandlerSignature is the parameter list and result of a handler
+	HandlerSignature string
+	// Ctx and ID read the request context and the id path parameter, and Query
+	// reads the query parameter %s
+	Ctx   string
+	ID    string
+	Query string
+	// Writer is the http.ResponseWriter of the request, which the CSV export streams to
+	Writer string
+	// Decode is an expression decoding the request body into req and returning an error
+	Decode string
+	// Respond writes the body %[2]s as JSON with the status %[1]s; NoContent writes
+	// an empty response with the status %s
+	Respond   string
+	NoContent string
+	// Returns is set when Respond and NoContent already return from the handler
+	Returns bool
+}
+
+// APIUsersHandlerTemplate returns the content of the handlers/users.go file
+func APIUsersHandlerTemplate(cfg config.ProjectConfig) (string, error) {
+	return frameworkFor(cfg).UsersHandler(cfg)
+}
+
+// usersPath returns the Go expression of a users route path, such as
+// routes.APIV1Prefix+"/users"; with authentication the routes are protected, so the
+// path is relative to routes.APIV1Prefix. method prefixes net/http patterns, as in "GET ".
+func usersPath(cfg config.ProjectConfig, method, path string) string {
+	switch {
+	case cfg.Components.Auth:
+		return `"` + method + path + `"`
+	case method != "":
+		return `"` + method + `"+routes.APIV1Prefix+"` + path + `"`
+	}
+	return `routes.APIV1Prefix+"` + path + `"`
+}
+
+// usersHandlerTemplate returns the content of the handlers/users.go file; the CRUD
+// logic is shared and only the thin handler functions depend on the framework
+func usersHandlerTemplate(cfg config.ProjectConfig, f usersFramework) (string, error) {
+	return render("api_users.tmpl", map[string]any{
+		"Cfg":       cfg,
+		"Framework": f,
+		"IDType":    databaseEngine(cfg).ModelIDType,
+	})
+}
+
+// APIValidationTemplate returns the content of the handlers/validation.go file
+func APIValidationTemplate() (string, error) {
+	return render("api_validation.tmpl", nil)
+}
@@ -0,0 +1,101 @@
+// internal/generator/templates/workspace.go - Templates for monorepo workspace files
+package templates
+
+import "github.com/neor-it/go-project-gen/internal/config"
+
+// GoWorkTemplate returns the content of the go.work file
+func GoWorkTemplate(ws config.WorkspaceConfig) (string, error) {
+	return render("workspace_go_work.tmpl", map[string]any{
+		"Go":       ws.Go(),
+		"Services": ws.Services,
+	})
+}
+
+// WorkspacePkgGoModTemplate returns the content of the go.mod file of the shared pkg module
+func WorkspacePkgGoModTemplate(ws config.WorkspaceConfig) (string, error) {
+	return render("workspace_pkg_go_mod.tmpl", map[string]any{
+		"Go":         ws.Go(),
+		"ModuleName": ws.ModuleName,
+	})
+}
+
+// WorkspacePkgDocTemplate returns the content of the doc.go file of the shared pkg module
+func WorkspacePkgDocTemplate(ws config.WorkspaceConfig) (string, error) {
+	return render("workspace_pkg_doc.tmpl", map[string]any{
+		"Name":       ws.Name,
+		"ModuleName": ws.ModuleName,
+	})
+}
+
+// WorkspaceMakefileTemplate returns the content of the root Makefile fanning out to each service
+func WorkspaceMakefileTemplate(ws config.WorkspaceConfig) (string, error) {
+	return render("workspace_makefile.tmpl", map[string]any{
+		"Name":     ws.Name,
+		"Services": ws.Services,
+	})
+}
+
+// composeService is a service of the root docker-compose.yml with its backing containers
+type composeService struct {
+	Name string
+	// Port is the host port of the service, 8080 plus its position in the workspace
+	Port                           int
+	Postgres, MySQL, SQLite, Redis bool
+	// ConnectionEnvs are the connection string variables of its database connections,
+	// pointed at the database container; SQLite has none
+	ConnectionEnvs []string
+}
+
+// WorkspaceDockerComposeTemplate returns the content of the root docker-compose.yml
+// aggregating every service with the Docker component
+func WorkspaceDockerComposeTemplate(ws config.WorkspaceConfig) (string, error) {
+	var services []composeService
+	var volumes []string
+	for i, service := range ws.Services {
+		if !service.Components.Docker {
+			continue
+		}
+
+		// Each service gets its own backing containers, addressed by service-prefixed names
+		name := service.ProjectName
+		database := service.Components.Database
+		compose := composeService{
+			Name:     name,
+			Port:     8080 + i,
+			Postgres: database == config.ComponentPostgres,
+			MySQL:    database == config.ComponentMySQL,
+			SQLite:   database == config.ComponentSQLite,
+			Redis:    service.Components.Redis,
+		}
+		if compose.Postgres || compose.MySQL {
+			compose.ConnectionEnvs = connectionStringEnvs(service)
+		}
+		services = append(services, compose)
+		if service.Components.HasDatabase() {
+			volumes = append(volumes, name+"_"+database+"_data")
+		}
+		if service.Components.Redis {
+			volumes = append(volumes, name+"_redis_data")
+		}
+	}
+
+	return render("workspace_docker_compose.tmpl", map[string]any{
+		"Name":     ws.Name,
+		"Services": services,
+		"Volumes":  volumes,
+	})
+}
+
+// WorkspaceGitignoreTemplate returns the content of the root .gitignore file
+func WorkspaceGitignoreTemplate() (string, error) {
+	return render("workspace_gitignore.tmpl", nil)
+}
+
+// WorkspaceReadmeTemplate returns the content of the root README.md file
+func WorkspaceReadmeTemplate(ws config.WorkspaceConfig) (string, error) {
+	return render("workspace_readme.tmpl", map[string]any{
+		"Name":       ws.Name,
+		"ModuleName": ws.ModuleName,
+		"Services":   ws.Services,
+	})
+}
@@ -0,0 +1,147 @@
+// internal/generator/verify.go - Compile checks for the generated project
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+// verifyCommands returns the commands that must succeed in the generated
+// project; its tests must pass too, unless they were left out, and so must
+// golangci-lint with the generated .golangci.yml when it is installed. The go
+// commands get the build tags selecting the JSON engine of Gin, like make does.
+func (g *Generator) verifyCommands() [][]string {
+	goCommand := func(name string) []string {
+		if tags := g.config.ProjectConfig.Components.BuildTags(); tags != "" {
+			return []string{"go", name, "-tags", tags, "./..."}
+		}
+		return []string{"go", name, "./..."}
+	}
+
+	commands := [][]string{
+		goCommand("build"),
+		goCommand("vet"),
+	}
+	if !g.config.ProjectConfig.NoTests {
+		commands = append(commands, goCommand("test"))
+	}
+	if _, err := exec.LookPath("golangci-lint"); err == nil {
+		commands = append(commands, []string{"golangci-lint", "run", "./..."})
+	} else {
+		g.log.Debug("golangci-lint not found, skipping the lint check")
+	}
+	return commands
+}
+
+// maxVerifyOutputLines limits the tool output quoted in a verification error
+const maxVerifyOutputLines = 20
+
+// verifyProject runs go build, go vet, go test and golangci-lint in the project directory
+func (g *Generator) verifyProject(projectDir string) error {
+	if g.recordOnly() {
+		g.log.Info("Dry run, skipping verification")
+		return nil
+	}
+
+	g.log.Info("Verifying the generated project builds and passes its tests")
+
+	start := time.Now()
+	defer func() {
+		g.verifyTime += time.Since(start)
+	}()
+
+	for _, args := range g.verifyCommands() {
+		if err := g.runVerifyCommand(projectDir, args); err != nil {
+			return err
+		}
+	}
+
+	g.log.Info("Generated project verified", "took", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// runVerifyCommand runs a command, streaming its output through the logger
+func (g *Generator) runVerifyCommand(projectDir string, args []string) error {
+	name := strings.Join(args, " ")
+
+	output := &logWriter{log: g.log, command: name}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = projectDir
+	// Check the project on its own, even when a go.work lists it
+	cmd.Env = append(os.Environ(), "GOWORK=off")
+	if g.config.ProjectConfig.Vendor {
+		// Build from vendor/ with the module proxy disabled, like the Docker build
+		cmd.Env = append(cmd.Env, "GOFLAGS=-mod=vendor", "GOPROXY=off")
+	}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	err := cmd.Run()
+	output.Flush()
+	if err != nil {
+		return fmt.Errorf("%s failed in %s: %w\n%s", name, projectDir, err, output.Tail(maxVerifyOutputLines))
+	}
+
+	return nil
+}
+
+// logWriter logs every line written to it and keeps them for error reports
+type logWriter struct {
+	log     logger.Logger
+	command string
+	partial []byte
+	lines   []string
+}
+
+// Write logs the complete lines in p and buffers the rest
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(string(w.partial[:i]))
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush logs the buffered incomplete line, if any
+func (w *logWriter) Flush() {
+	if len(w.partial) > 0 {
+		w.logLine(string(w.partial))
+		w.partial = nil
+	}
+}
+
+// Tail returns the last n logged lines
+func (w *logWriter) Tail(n int) string {
+	lines := w.lines
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (w *logWriter) logLine(line string) {
+	line = strings.TrimRight(line, "\r")
+	if line == "" {
+		return
+	}
+	w.lines = append(w.lines, line)
+
+	// go test reports every package it passed or found no tests in; only the
+	// failures deserve a warning
+	if strings.HasPrefix(line, "ok  \t") || strings.HasPrefix(line, "?   \t") {
+		w.log.Debug(w.command, "output", line)
+		return
+	}
+	w.log.Warn(w.command, "output", line)
+}
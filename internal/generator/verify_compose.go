@@ -0,0 +1,267 @@
+// internal/generator/verify_compose.go - End-to-end checks of the generated project under Docker Compose
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/doctor"
+)
+
+// composeWaitTimeout bounds how long docker compose up waits for the services to become healthy
+const composeWaitTimeout = 3 * time.Minute
+
+// composeRequestTimeout bounds the /health request to the running app
+const composeRequestTimeout = 10 * time.Second
+
+// composeLogLines is the number of app log lines quoted when the compose check fails
+const composeLogLines = 50
+
+// verifyCompose builds the image, starts the project with docker compose under a temporary
+// project name, checks /health and the applied migrations, and tears everything down again.
+// It is skipped with a notice when the project has no Docker component or the daemon is unavailable.
+func (g *Generator) verifyCompose(projectDir string) error {
+	if g.recordOnly() {
+		g.log.Info("Dry run, skipping Docker Compose verification")
+		return nil
+	}
+
+	cfg := g.config.ProjectConfig
+	if !cfg.Components.Docker {
+		g.composeSkipped = "the project has no docker component"
+		g.log.Warn("Skipping Docker Compose verification", "reason", g.composeSkipped)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	result := doctor.DockerDaemon(g.dockerProbe).Run(ctx)
+	cancel()
+	if result.Status != doctor.Pass {
+		g.composeSkipped = result.Detail
+		g.log.Warn("Skipping Docker Compose verification, the Docker daemon is unavailable", "reason", result.Detail)
+		return nil
+	}
+
+	g.log.Info("Verifying the generated project with Docker Compose")
+
+	start := time.Now()
+	defer func() {
+		g.composeTime += time.Since(start)
+	}()
+
+	override, err := os.CreateTemp("", "compose-verify-*.yml")
+	if err != nil {
+		return fmt.Errorf("failed to create compose override file: %w", err)
+	}
+	defer os.Remove(override.Name())
+
+	project := composeProjectName(cfg.ProjectName)
+	if _, err := override.WriteString(composeOverride(cfg, project)); err != nil {
+		override.Close()
+		return fmt.Errorf("failed to write compose override file: %w", err)
+	}
+	if err := override.Close(); err != nil {
+		return fmt.Errorf("failed to write compose override file: %w", err)
+	}
+
+	compose := func(args ...string) (string, error) {
+		base := []string{"compose", "-p", project, "-f", "docker-compose.yml", "-f", override.Name()}
+		return g.runDocker(projectDir, append(base, args...)...)
+	}
+
+	// Always remove the containers, volumes and the image built for the check
+	defer func() {
+		if _, err := compose("down", "--volumes", "--remove-orphans", "--rmi", "local"); err != nil {
+			g.log.Warn("Failed to tear down the Docker Compose project", "project", project, "error", err)
+		}
+	}()
+
+	if err := g.runComposeChecks(cfg, compose); err != nil {
+		if logs, logErr := compose("logs", "--no-color", "--tail", strconv.Itoa(composeLogLines), "app"); logErr == nil {
+			return fmt.Errorf("%w\napp logs:\n%s", err, strings.TrimSpace(logs))
+		}
+		return err
+	}
+
+	g.log.Info("Docker Compose verification passed", "took", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// runComposeChecks builds and starts the project, then checks the app and the database
+func (g *Generator) runComposeChecks(cfg config.ProjectConfig, compose func(args ...string) (string, error)) error {
+	g.log.Info("Building the Docker image")
+	if _, err := compose("build", "app"); err != nil {
+		return err
+	}
+
+	g.log.Info("Starting the services and waiting for them to become healthy")
+	wait := strconv.Itoa(int(composeWaitTimeout.Seconds()))
+	if _, err := compose("up", "--detach", "--wait", "--wait-timeout", wait); err != nil {
+		return err
+	}
+
+	// Apply the migrations with the tool shipped in the image, through the compose network
+	if cfg.Components.HasDatabase() {
+		g.log.Info("Applying database migrations")
+		if _, err := compose("run", "--rm", "--no-deps", "app", "./migtool", "--command=up"); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Components.HTTP {
+		output, err := compose("port", "app", "8080")
+		if err != nil {
+			return err
+		}
+		if err := checkHealth(strings.TrimSpace(output)); err != nil {
+			return err
+		}
+		g.log.Info("Health check passed")
+	}
+
+	if cfg.Components.Database == config.ComponentPostgres {
+		output, err := compose("exec", "-T", "postgres", "psql", "-U", "postgres", "-d", cfg.ProjectName,
+			"-tAc", "SELECT version, dirty FROM schema_migrations")
+		if err != nil {
+			return err
+		}
+		version, dirty, _ := strings.Cut(strings.TrimSpace(output), "|")
+		switch {
+		case version == "":
+			return fmt.Errorf("no migration recorded in schema_migrations")
+		case dirty != "f":
+			return fmt.Errorf("migration %s is marked dirty in schema_migrations", version)
+		}
+		g.log.Info("Migrations applied", "version", version)
+	}
+
+	return nil
+}
+
+// checkHealth requests /health from the app published on address, as printed by docker compose port
+func checkHealth(address string) error {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("unexpected published address %q: %w", address, err)
+	}
+
+	client := &http.Client{Timeout: composeRequestTimeout}
+	url := "http://" + net.JoinHostPort("localhost", port) + "/health"
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check failed: GET %s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+// runDocker runs a docker command and returns its standard output;
+// the error quotes the end of the combined output
+func (g *Generator) runDocker(projectDir string, args ...string) (string, error) {
+	var stdout, combined bytes.Buffer
+	cmd := exec.Command("docker", args...)
+	cmd.Dir = projectDir
+	cmd.Stdout = io.MultiWriter(&stdout, &combined)
+	cmd.Stderr = &combined
+
+	if err := cmd.Run(); err != nil {
+		lines := strings.Split(strings.TrimSpace(combined.String()), "\n")
+		if len(lines) > maxVerifyOutputLines {
+			lines = lines[len(lines)-maxVerifyOutputLines:]
+		}
+		return "", fmt.Errorf("docker %s failed in %s: %w\n%s", strings.Join(args, " "), projectDir, err, strings.Join(lines, "\n"))
+	}
+	return stdout.String(), nil
+}
+
+// composeProjectName returns a compose project name for the check that cannot clash
+// with a stack the user started from the same directory
+func composeProjectName(projectName string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return '-'
+	}, projectName)
+	return "verify-" + name + "-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// composeOverride returns a compose file layered over docker-compose.yml for the check:
+// containers are named after the temporary project, ports are published on random host ports
+// so a running stack doesn't block the check, and the healthchecks docker-compose.yml defines
+// poll faster so up --wait returns soon after the services accept connections. Resetting
+// ports needs Docker Compose 2.24 or newer.
+func composeOverride(cfg config.ProjectConfig, project string) string {
+	ports := `      - "8080"
+`
+	if cfg.Components.GRPC {
+		ports += `      - "9090"
+`
+	}
+
+	// polling overrides the interval and retries of a healthcheck, keeping its test
+	polling := func(retries int) string {
+		return `    healthcheck:
+      interval: 2s
+      retries: ` + strconv.Itoa(retries) + `
+`
+	}
+
+	app := `services:
+  app:
+    container_name: ` + project + `-app
+    ports: !override
+` + ports
+	if cfg.Components.HTTP {
+		app += polling(30)
+	}
+
+	services := ""
+	if cfg.Components.Database == config.ComponentPostgres {
+		services += `
+  postgres:
+    container_name: ` + project + `-postgres
+    ports: !reset []
+` + polling(60)
+	}
+	if cfg.Components.Database == config.ComponentMySQL {
+		services += `
+  mysql:
+    container_name: ` + project + `-mysql
+    ports: !reset []
+` + polling(60)
+	}
+	if cfg.Components.Redis {
+		services += `
+  redis:
+    container_name: ` + project + `-redis
+    ports: !reset []
+` + polling(30)
+	}
+	if cfg.Components.Metrics {
+		services += `
+  prometheus:
+    container_name: ` + project + `-prometheus
+    ports: !reset []
+`
+	}
+
+	return app + services
+}
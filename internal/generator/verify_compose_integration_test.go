@@ -0,0 +1,58 @@
+//go:build integration
+
+package generator
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+// TestVerifyComposeIntegration boots a generated project with Docker Compose, which
+// checks /health and the schema_migrations table, then checks the teardown. It needs
+// the module proxy and a running Docker daemon: go test -tags integration ./internal/generator
+func TestVerifyComposeIntegration(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := config.ParseArgs([]string{
+		"--project", "demo",
+		"--username", "acme",
+		"--output", dir,
+		"--components", "http,postgres,docker",
+		"--verify-docker",
+		"--no-doctor",
+	})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+
+	g := NewGenerator(logger.NewLogger(), cfg)
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	took, skipped := g.ComposeVerification()
+	if skipped != "" {
+		t.Fatalf("Docker Compose verification was skipped: %s", skipped)
+	}
+	if took == 0 {
+		t.Error("Docker Compose verification took no time")
+	}
+
+	// The containers and volumes of the temporary compose project are removed
+	for _, list := range [][]string{
+		{"ps", "--all", "--format", "{{.Names}}"},
+		{"volume", "ls", "--format", "{{.Name}}"},
+	} {
+		output, err := exec.Command("docker", list...).Output()
+		if err != nil {
+			t.Fatalf("docker %s failed: %v", strings.Join(list, " "), err)
+		}
+		for _, name := range strings.Fields(string(output)) {
+			if strings.HasPrefix(name, "verify-demo-") {
+				t.Errorf("docker %s lists %s after the teardown", list[0], name)
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/logger"
+)
+
+func TestVerifyComposeSkipsWithoutDaemon(t *testing.T) {
+	tests := []struct {
+		name       string
+		components string
+		probeErr   error
+		wantProbe  bool
+		wantReason string
+	}{
+		{
+			name:       "docker not installed",
+			components: "http,postgres,docker",
+			probeErr:   &exec.Error{Name: "docker", Err: exec.ErrNotFound},
+			wantProbe:  true,
+			wantReason: "docker is not installed",
+		},
+		{
+			name:       "daemon not running",
+			components: "http,postgres,docker",
+			probeErr:   errors.New("exit status 1"),
+			wantProbe:  true,
+			wantReason: "docker version failed",
+		},
+		{
+			name:       "no docker component",
+			components: "http,postgres",
+			wantReason: "the project has no docker component",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := generateProject(t, "--components", tt.components)
+
+			cfg, err := config.ParseArgs([]string{
+				"--project", "demo",
+				"--username", "acme",
+				"--components", tt.components,
+				"--no-doctor",
+			})
+			if err != nil {
+				t.Fatalf("ParseArgs() error = %v", err)
+			}
+
+			// The probe answers for the daemon, any other docker command would
+			// start the compose checks
+			var commands []string
+			g := NewGenerator(logger.NewLogger(), cfg)
+			g.dockerProbe = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+				commands = append(commands, name+" "+strings.Join(args, " "))
+				return []byte("Cannot connect to the Docker daemon"), tt.probeErr
+			}
+
+			if err := g.verifyCompose(projectDir); err != nil {
+				t.Fatalf("verifyCompose() error = %v, want the check skipped", err)
+			}
+
+			took, reason := g.ComposeVerification()
+			if !strings.HasPrefix(reason, tt.wantReason) {
+				t.Errorf("skip reason = %q, want it to start with %q", reason, tt.wantReason)
+			}
+			if took != 0 {
+				t.Errorf("compose checks took %v, want them skipped", took)
+			}
+			if tt.wantProbe && (len(commands) != 1 || !strings.HasPrefix(commands[0], "docker version")) {
+				t.Errorf("probe ran %q, want docker version only", commands)
+			}
+			if !tt.wantProbe && len(commands) > 0 {
+				t.Errorf("probe ran %q, want no docker command", commands)
+			}
+		})
+	}
+}
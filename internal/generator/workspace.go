@@ -0,0 +1,263 @@
+// internal/generator/workspace.go - Monorepo workspace generation
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/generator/templates"
+)
+
+// workspaceStateFile records the services that were generated successfully,
+// so an interrupted run resumes with the remaining services
+const workspaceStateFile = ".generated-services"
+
+// Service generation statuses reported at the end of a workspace run
+const (
+	ServiceGenerated = "generated"
+	ServiceSkipped   = "skipped"
+	ServiceFailed    = "failed"
+)
+
+// ServiceResult is the outcome of generating one service of the workspace
+type ServiceResult struct {
+	Name   string
+	Path   string
+	Status string
+	Err    error
+}
+
+// WorkspaceReport summarizes the generation of a workspace
+type WorkspaceReport struct {
+	Root     string
+	Services []ServiceResult
+}
+
+// Failed returns the errors of the services that failed to generate
+func (r *WorkspaceReport) Failed() []error {
+	var errs []error
+	for _, result := range r.Services {
+		if result.Status == ServiceFailed {
+			errs = append(errs, fmt.Errorf("service %s: %w", result.Name, result.Err))
+		}
+	}
+	return errs
+}
+
+// generateWorkspace generates the workspace root and every service
+func (g *Generator) generateWorkspace() error {
+	ws := g.config.Workspace
+
+	g.log.Info("Generating workspace",
+		"name", ws.Name,
+		"moduleName", ws.ModuleName,
+		"services", len(ws.Services),
+		"outputDir", g.config.OutputDir,
+	)
+
+	if err := g.checkWritable(); err != nil {
+		return err
+	}
+
+	rootDir := filepath.Join(g.config.OutputDir, ws.Name)
+	for _, dir := range []string{"pkg", "services"} {
+		if err := g.writer.MkdirAll(filepath.Join(rootDir, dir), 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	// Root files are cheap to regenerate and always reflect the full service list
+	if err := g.generateWorkspaceFiles(rootDir); err != nil {
+		return fmt.Errorf("failed to generate workspace files: %w", err)
+	}
+
+	done, err := loadWorkspaceState(rootDir)
+	if err != nil {
+		return err
+	}
+
+	report := &WorkspaceReport{Root: rootDir}
+	for _, service := range ws.Services {
+		result := ServiceResult{
+			Name: service.ProjectName,
+			Path: filepath.Join(rootDir, "services", service.ProjectName),
+		}
+
+		if done[service.ProjectName] {
+			g.log.Info("Service already generated, skipping", "service", service.ProjectName)
+			result.Status = ServiceSkipped
+			report.Services = append(report.Services, result)
+			continue
+		}
+
+		// Generate the service as a standalone project inside services/
+		serviceCfg := &config.Config{
+			OutputDir:     filepath.Join(rootDir, "services"),
+			ProjectConfig: service,
+			Provided:      g.config.Provided,
+			SkipVerify:    g.config.SkipVerify,
+			VerifyDocker:  g.config.VerifyDocker,
+			NoHeaders:     g.config.NoHeaders,
+			Offline:       g.config.Offline,
+		}
+		serviceGen := NewGenerator(g.log, serviceCfg)
+		serviceGen.writer, serviceGen.dryRun, serviceGen.plan, serviceGen.progress = g.writer, g.dryRun, g.plan, g.progress
+		err := serviceGen.Generate()
+		g.verifyTime += serviceGen.verifyTime
+		g.composeTime += serviceGen.composeTime
+		if serviceGen.composeSkipped != "" {
+			g.composeSkipped = serviceGen.composeSkipped
+		}
+		if err != nil {
+			g.log.Error("Failed to generate service", "service", service.ProjectName, "error", err)
+			result.Status = ServiceFailed
+			result.Err = err
+			report.Services = append(report.Services, result)
+			continue
+		}
+
+		if !g.recordOnly() {
+			if err := saveWorkspaceState(rootDir, service.ProjectName); err != nil {
+				return err
+			}
+		}
+		result.Status = ServiceGenerated
+		report.Services = append(report.Services, result)
+	}
+
+	g.logWorkspaceReport(report)
+
+	if errs := report.Failed(); len(errs) > 0 {
+		return fmt.Errorf("%d of %d services failed, re-run to resume: %w", len(errs), len(ws.Services), errors.Join(errs...))
+	}
+
+	// The manifest of the root only covers the root files, each service has its own
+	if err := g.saveManifest(rootDir); err != nil {
+		return err
+	}
+
+	// Align the go.work go version with the modules once they are all in place
+	if err := g.runGoWorkUse(rootDir); err != nil {
+		return fmt.Errorf("failed to sync go.work: %w", err)
+	}
+
+	// The hooks run once, in the workspace root
+	return g.runPostHooks(rootDir, ws.Name, ws.ModuleName)
+}
+
+// runGoWorkUse runs go work use in the workspace root
+func (g *Generator) runGoWorkUse(rootDir string) error {
+	if g.recordOnly() {
+		g.log.Info("Dry run, skipping go work use")
+		return nil
+	}
+
+	g.log.Info("Running go work use in the workspace root")
+
+	cmd := exec.Command("go", "work", "use")
+	cmd.Dir = rootDir
+	if g.config.Offline {
+		// Fail rather than download a newer toolchain the go.work may ask for
+		cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOPROXY=off")
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run go work use: %w", err)
+	}
+
+	return nil
+}
+
+// generateWorkspaceFiles generates the files at the root of the workspace
+func (g *Generator) generateWorkspaceFiles(rootDir string) error {
+	ws := *g.config.Workspace
+
+	files := map[string]templateContent{
+		"go.work":    rendered(templates.GoWorkTemplate(ws)),
+		"pkg/go.mod": rendered(templates.WorkspacePkgGoModTemplate(ws)),
+		"pkg/doc.go": rendered(templates.WorkspacePkgDocTemplate(ws)),
+		"Makefile":   rendered(templates.WorkspaceMakefileTemplate(ws)),
+		".gitignore": rendered(templates.WorkspaceGitignoreTemplate()),
+		"README.md":  rendered(templates.WorkspaceReadmeTemplate(ws)),
+	}
+
+	// Aggregate the services with Docker support into a single compose file
+	for _, service := range ws.Services {
+		if service.Components.Docker {
+			files["docker-compose.yml"] = rendered(templates.WorkspaceDockerComposeTemplate(ws))
+			break
+		}
+	}
+
+	for name, content := range files {
+		if content.err != nil {
+			return content.err
+		}
+		if err := g.writeFile(filepath.Join(rootDir, name), content.text); err != nil {
+			return fmt.Errorf("failed to create %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// logWorkspaceReport logs the outcome of every service
+func (g *Generator) logWorkspaceReport(report *WorkspaceReport) {
+	counts := map[string]int{}
+	for _, result := range report.Services {
+		counts[result.Status]++
+		if result.Err != nil {
+			g.log.Error("Service", "name", result.Name, "status", result.Status, "path", result.Path, "error", result.Err)
+			continue
+		}
+		g.log.Info("Service", "name", result.Name, "status", result.Status, "path", result.Path)
+	}
+
+	g.log.Info("Workspace report",
+		"root", report.Root,
+		ServiceGenerated, counts[ServiceGenerated],
+		ServiceSkipped, counts[ServiceSkipped],
+		ServiceFailed, counts[ServiceFailed],
+	)
+}
+
+// loadWorkspaceState returns the names of the services already generated
+func loadWorkspaceState(rootDir string) (map[string]bool, error) {
+	done := map[string]bool{}
+
+	content, err := os.ReadFile(filepath.Join(rootDir, workspaceStateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace state: %w", err)
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			done[name] = true
+		}
+	}
+	return done, nil
+}
+
+// saveWorkspaceState records a service as generated
+func saveWorkspaceState(rootDir, service string) error {
+	file, err := os.OpenFile(filepath.Join(rootDir, workspaceStateFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open workspace state: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := fmt.Fprintln(file, service); err != nil {
+		return fmt.Errorf("failed to write workspace state: %w", err)
+	}
+	return nil
+}
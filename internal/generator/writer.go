@@ -0,0 +1,150 @@
+// internal/generator/writer.go - Filesystem abstraction used to write generated files
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FileWriter performs the filesystem changes of the generator
+type FileWriter interface {
+	MkdirAll(path string, perm os.FileMode) error
+	WriteFile(path string, data []byte, perm os.FileMode) error
+	Chmod(path string, mode os.FileMode) error
+}
+
+// osWriter writes to the real filesystem
+type osWriter struct{}
+
+// MkdirAll creates a directory and any missing parents
+func (osWriter) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// WriteFile writes data to a file, creating it if needed
+func (osWriter) WriteFile(path string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(path, data, perm)
+}
+
+// Chmod changes the mode of a file
+func (osWriter) Chmod(path string, mode os.FileMode) error {
+	return os.Chmod(path, mode)
+}
+
+// DryRunWriter records the files and directories the generator would create
+// without touching the filesystem; it is safe for concurrent use
+type DryRunWriter struct {
+	mu    sync.Mutex
+	root  string
+	dirs  map[string]bool
+	files map[string]int
+}
+
+// NewDryRunWriter creates a dry-run writer for paths under root
+func NewDryRunWriter(root string) *DryRunWriter {
+	return &DryRunWriter{
+		root:  root,
+		dirs:  map[string]bool{},
+		files: map[string]int{},
+	}
+}
+
+// MkdirAll records a directory and its parents below the root
+func (w *DryRunWriter) MkdirAll(path string, perm os.FileMode) error {
+	rel, err := w.rel(path)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.addDir(rel)
+	return nil
+}
+
+// WriteFile records a file and its size
+func (w *DryRunWriter) WriteFile(path string, data []byte, perm os.FileMode) error {
+	rel, err := w.rel(path)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.files[rel] = len(data)
+	w.addDir(filepath.Dir(rel))
+	return nil
+}
+
+// Chmod does nothing; the mode of recorded files is not reported
+func (w *DryRunWriter) Chmod(path string, mode os.FileMode) error {
+	return nil
+}
+
+// Tree renders the recorded directories and files as a tree with file sizes
+func (w *DryRunWriter) Tree() string {
+	children := map[string][]string{}
+	for dir := range w.dirs {
+		parent := filepath.Dir(dir)
+		children[parent] = append(children[parent], dir)
+	}
+	total := 0
+	for file, size := range w.files {
+		parent := filepath.Dir(file)
+		children[parent] = append(children[parent], file)
+		total += size
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s/\n", w.root)
+	w.renderTree(&b, children, ".", "")
+	fmt.Fprintf(&b, "\n%d directories, %d files, %s\n", len(w.dirs), len(w.files), formatSize(total))
+	return b.String()
+}
+
+func (w *DryRunWriter) renderTree(b *strings.Builder, children map[string][]string, dir, indent string) {
+	entries := children[dir]
+	sort.Strings(entries)
+
+	for i, entry := range entries {
+		branch, next := "├── ", "│   "
+		if i == len(entries)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		name := filepath.Base(entry)
+		if w.dirs[entry] {
+			fmt.Fprintf(b, "%s%s%s/\n", indent, branch, name)
+			w.renderTree(b, children, entry, indent+next)
+			continue
+		}
+		fmt.Fprintf(b, "%s%s%s (%s)\n", indent, branch, name, formatSize(w.files[entry]))
+	}
+}
+
+// rel returns path relative to the root, rejecting paths outside of it
+func (w *DryRunWriter) rel(path string) (string, error) {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside of %s", path, w.root)
+	}
+	return rel, nil
+}
+
+// addDir records dir and its parents, stopping at the root
+func (w *DryRunWriter) addDir(dir string) {
+	for dir != "." && !w.dirs[dir] {
+		w.dirs[dir] = true
+		dir = filepath.Dir(dir)
+	}
+}
+
+// formatSize formats a size in bytes for display
+func formatSize(size int) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KB", float64(size)/1024)
+}
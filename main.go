@@ -2,55 +2,175 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/neor-it/go-project-gen/internal/cli"
 	"github.com/neor-it/go-project-gen/internal/config"
+	"github.com/neor-it/go-project-gen/internal/doctor"
 	"github.com/neor-it/go-project-gen/internal/generator"
 	"github.com/neor-it/go-project-gen/internal/logger"
+	"golang.org/x/term"
 )
 
 func main() {
+	// The schema command prints JSON only, so it runs before the logger starts
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		os.Exit(runSchema(os.Args[2:]))
+	}
+
 	// Initialize logger
 	log := logger.NewLogger()
 	log.Info("Starting Go Project Generator")
 
-	// Check if /output directory exists and is writable when running in Docker
-	outputDir := "."
-	if _, err := os.Stat("/output"); err == nil {
-		// We're inside Docker with mounted volume
-		outputDir = "/output"
-		log.Info("Using Docker volume output directory", "path", outputDir)
+	// The doctor command only checks the environment
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		os.Exit(runDoctor(os.Args[2:]))
+	}
+
+	// The remove command takes a component out of an existing project
+	if len(os.Args) > 1 && os.Args[1] == "remove" {
+		os.Exit(runRemove(log, os.Args[2:]))
+	}
+
+	// The update command regenerates an existing project from its manifest
+	if len(os.Args) > 1 && os.Args[1] == "update" {
+		os.Exit(runUpdate(log, os.Args[2:]))
+	}
+
+	// The add command adds a component to an existing project
+	if len(os.Args) > 1 && os.Args[1] == "add" {
+		os.Exit(runAdd(log, os.Args[2:]))
 	}
 
 	// Parse command line arguments
 	cfg, err := config.ParseArgs(os.Args[1:])
 	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
 		log.Fatal("Failed to parse arguments", "error", err)
 	}
 
-	// Set the output directory
+	// Without --output, use the /output volume when running in Docker
+	if !cfg.Provided["output"] {
+		if _, err := os.Stat("/output"); err == nil {
+			cfg.OutputDir = "/output"
+			log.Info("Using Docker volume output directory", "path", cfg.OutputDir)
+		}
+	}
+
+	// Resolve the output directory, creating it if needed
+	outputDir, exists, err := config.ResolveOutputDir(cfg.OutputDir)
+	if err != nil {
+		log.Fatal("Invalid output directory", "error", err)
+	}
+	if !exists && writesFiles(cfg) {
+		if cfg.IsInteractive {
+			create, err := cli.NewWizard(log).ConfirmCreateDir(outputDir)
+			if err != nil {
+				log.Fatal("Failed to confirm output directory", "error", err)
+			}
+			if !create {
+				log.Info("Output directory not created, aborting")
+				os.Exit(1)
+			}
+		}
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			log.Fatal("Failed to create output directory", "error", err)
+		}
+		log.Info("Created output directory", "path", outputDir)
+	}
 	cfg.OutputDir = outputDir
 
-	// Run CLI wizard if no configuration file provided
+	// Run CLI wizard for any answers not provided via flags
 	if cfg.IsInteractive {
 		wizard := cli.NewWizard(log)
-		projectCfg, err := wizard.Run()
+		projectCfg, err := wizard.Run(cfg)
 		if err != nil {
 			log.Fatal("Failed to run wizard", "error", err)
 		}
 		cfg.ProjectConfig = projectCfg
+
+		// Offer to save the answers so the run can be reproduced with --config
+		if writesFiles(cfg) {
+			if err := wizard.OfferSave(filepath.Join(outputDir, "project.yaml"), projectCfg); err != nil {
+				log.Fatal("Failed to save project configuration", "error", err)
+			}
+		}
+	}
+
+	generate(log, cfg)
+}
+
+// generate checks the environment, generates the project of cfg into its
+// resolved output directory and reports the result
+func generate(log logger.Logger, cfg *config.Config) {
+	outputDir := cfg.OutputDir
+
+	// Check the environment before writing anything
+	if !cfg.NoDoctor && writesFiles(cfg) {
+		report := doctor.Run(context.Background(), doctor.Checks(doctor.ExecRunner, doctor.Options{
+			OutputDir: outputDir,
+			Docker:    usesDocker(cfg),
+			GoVersion: goVersion(cfg),
+		}))
+		if report.Failed() || report.Warned() {
+			report.Print(os.Stdout)
+		}
+		if report.Failed() {
+			log.Fatal("Environment checks failed, fix the problems above or re-run with --no-doctor")
+		}
+		log.Info("Environment checks passed")
 	}
 
 	// Generate project
 	gen := generator.NewGenerator(log, cfg)
+	if writesFiles(cfg) {
+		gen.SetProgress(os.Stderr)
+	}
 	if err := gen.Generate(); err != nil {
 		log.Fatal("Failed to generate project", "error", err)
 	}
 
+	// In dry-run mode, show what would have been written instead
+	if plan := gen.DryRun(); plan != nil {
+		fmt.Println("📝 Dry run, nothing was written. The generator would create:")
+		fmt.Println()
+		fmt.Print(plan.Tree())
+		return
+	}
+
+	// In plan mode, show the differences with the files on disk
+	if plan := gen.Plan(); plan != nil {
+		if !cfg.Apply {
+			fmt.Print(plan.Diff())
+			fmt.Println()
+			fmt.Printf("📝 Plan, nothing was written: %s", plan.Summary())
+			printPaths(plan.Skipped(), "⚠️  Edited since they were generated, kept by --apply (%d):\n")
+			_, orphans, edited := findOrphans(log, gen)
+			printPaths(orphans, "🗑️  No longer generated, deleted by --prune (%d):\n")
+			printPaths(edited, "⚠️  No longer generated but edited, kept by --prune (%d):\n")
+			return
+		}
+		fmt.Printf("📝 Applied plan: %s", plan.Summary())
+		if resolve := conflictResolver(log, cfg); resolve != nil {
+			resolveConflicts(log, gen, resolve)
+		} else {
+			printPaths(plan.Skipped(), "⚠️  Kept the files you edited, merge the changes shown by --plan by hand (%d):\n")
+		}
+		pruneOrphans(log, cfg, gen)
+	}
+
 	// Show success message with correct path information
-	projectPath := fmt.Sprintf("%s/%s", outputDir, cfg.ProjectConfig.ProjectName)
+	projectPath := filepath.Join(outputDir, cfg.ProjectConfig.ProjectName)
 	if outputDir == "/output" {
 		// When running in Docker, show the path relative to the user's current directory
 		projectPath = cfg.ProjectConfig.ProjectName
@@ -58,4 +178,389 @@ func main() {
 
 	fmt.Println("✅ Project successfully generated!")
 	fmt.Printf("📂 Location: %s\n", projectPath)
+	switch {
+	case cfg.Offline:
+		printOffline(cfg, "go mod tidy and the verification were")
+	case cfg.SkipVerify:
+		fmt.Println("⚠️  Verification skipped (--skip-verify)")
+	default:
+		fmt.Printf("🔍 Verified with go build and go vet in %s\n", gen.VerifyDuration().Round(time.Millisecond))
+	}
+	if cfg.VerifyDocker {
+		if took, skipped := gen.ComposeVerification(); skipped != "" {
+			fmt.Printf("⚠️  Docker Compose verification skipped: %s\n", skipped)
+		} else {
+			fmt.Printf("🐳 Verified with Docker Compose in %s\n", took.Round(time.Millisecond))
+		}
+	}
+}
+
+// runDoctor checks the environment and returns the process exit code
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	output := fs.String("output", ".", "Directory the project would be generated in")
+	docker := fs.Bool("docker", true, "Also check the Docker daemon")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	outputDir, _, err := config.ResolveOutputDir(*output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	report := doctor.Run(context.Background(), doctor.Checks(doctor.ExecRunner, doctor.Options{
+		OutputDir: outputDir,
+		Docker:    *docker,
+	}))
+	report.Print(os.Stdout)
+
+	if report.Failed() {
+		return 1
+	}
+	return 0
+}
+
+// runSchema prints the JSON Schema of the project configuration files read by
+// --config and returns the process exit code
+func runSchema(args []string) int {
+	fs := flag.NewFlagSet("schema", flag.ContinueOnError)
+	output := fs.String("output", "", "File to write the schema to instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	schema, err := config.ProjectFileSchema()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	content, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	content = append(content, '\n')
+
+	if *output == "" {
+		_, err = os.Stdout.Write(content)
+	} else {
+		err = os.WriteFile(*output, content, 0644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// runUpdate regenerates the project in a directory, the current one by default,
+// from the configuration recorded in its manifest changed by the flags, and
+// returns the process exit code
+func runUpdate(log logger.Logger, args []string) int {
+	cfg, manifest, code := parseRecorded("update", args)
+	if cfg == nil {
+		return code
+	}
+
+	if manifest.GeneratorVersion != generator.ToolVersion() {
+		log.Info("Updating a project generated by another version", "from", manifest.GeneratorVersion, "to", generator.ToolVersion())
+	}
+	generate(log, cfg)
+	return 0
+}
+
+// runAdd adds a component to the project in a directory, the current one by
+// default, and returns the process exit code
+func runAdd(log logger.Logger, args []string) int {
+	if len(args) < 1 || strings.HasPrefix(args[0], "-") {
+		fmt.Fprintln(os.Stderr, "usage: go-project-gen add <component> [project directory] [flags for the settings of the component]")
+		return 2
+	}
+	component := args[0]
+
+	cfg, _, code := parseRecorded("add", args[1:])
+	if cfg == nil {
+		return code
+	}
+	if cfg.Plan && !cfg.Apply {
+		fmt.Fprintf(os.Stderr, "add has no --plan; preview the change with go-project-gen update --components %s,%s --plan\n", strings.Join(cfg.ProjectConfig.Components.Names(), ","), component)
+		return 2
+	}
+
+	report, err := generator.AddComponent(log, cfg, component, conflictResolver(log, cfg))
+	if report != nil {
+		fmt.Printf("🧩 Added the %s component to %s\n", component, filepath.Join(cfg.OutputDir, cfg.ProjectConfig.ProjectName))
+		printPaths(report.Created, "Created (%d):\n")
+		printPaths(report.Updated, "Regenerated with it (%d):\n")
+		printPaths(report.Merged, "Merged into the files you edited, review them (%d):\n")
+		printPaths(report.Overwritten, "Overwrote the files you edited (%d):\n")
+		printPaths(report.Marked, "⚠️  Wrote conflict markers into the files you edited, resolve them (%d):\n")
+		printPaths(report.Kept, "⚠️  Kept the files you edited, add the changes shown by update --plan by hand (%d):\n")
+		if cfg.Offline {
+			printOffline(cfg, "go mod tidy was")
+		}
+	}
+	if err != nil {
+		log.Error("Failed to add component", "component", component, "error", err)
+		return 1
+	}
+	return 0
+}
+
+// parseRecorded parses the arguments of a command working on an existing
+// project: an optional project directory, the current one by default, and
+// flags changing the configuration recorded in its manifest. It returns a nil
+// config and the process exit code when the arguments or the project are
+// invalid.
+func parseRecorded(command string, args []string) (*config.Config, *generator.Manifest, int) {
+	dir := "."
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		dir, args = args[0], args[1:]
+	}
+
+	projectDir, exists, err := config.ResolveOutputDir(dir)
+	if err == nil && !exists {
+		err = fmt.Errorf("%s does not exist", projectDir)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, 2
+	}
+	manifest, err := generator.LoadManifest(projectDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, 2
+	}
+
+	recorded := manifest.Project
+	switch {
+	case recorded == nil:
+		fmt.Fprintf(os.Stderr, "%s has no %s with the configuration of the project; re-run the generator with the settings of the project and --plan --apply\n", projectDir, generator.ManifestFile)
+		return nil, nil, 2
+	case len(recorded.Services) > 0:
+		fmt.Fprintf(os.Stderr, "%s is a workspace; run %s on its services one at a time, e.g. in %s\n", projectDir, command, filepath.Join(dir, "services", recorded.Services[0].ProjectName))
+		return nil, nil, 2
+	case recorded.ProjectName != filepath.Base(projectDir):
+		fmt.Fprintf(os.Stderr, "%s was generated as %s; rename the directory back to %s to %s it\n", projectDir, recorded.ProjectName, recorded.ProjectName, command)
+		return nil, nil, 2
+	}
+
+	cfg, err := config.ParseUpdateArgs(args, recorded)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, nil, 0
+		}
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, 2
+	}
+	cfg.OutputDir = filepath.Dir(projectDir)
+	return cfg, manifest, 0
+}
+
+// runRemove removes a component from an existing project and returns the process exit code
+func runRemove(log logger.Logger, args []string) int {
+	if len(args) < 2 || args[0] != "component" {
+		fmt.Fprintln(os.Stderr, "usage: go-project-gen remove component <name> [the flags or --config the project was generated with]")
+		return 2
+	}
+	component := args[1]
+
+	cfg, err := config.ParseArgs(args[2:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if cfg.IsInteractive {
+		fmt.Fprintln(os.Stderr, "remove needs the settings the project was generated with: pass --username and --project, or --config")
+		return 2
+	}
+
+	outputDir, exists, err := config.ResolveOutputDir(cfg.OutputDir)
+	if err != nil || !exists {
+		fmt.Fprintf(os.Stderr, "invalid output directory %s: %v\n", cfg.OutputDir, err)
+		return 2
+	}
+	cfg.OutputDir = outputDir
+
+	report, err := generator.RemoveComponent(log, cfg, component)
+	if report != nil {
+		fmt.Printf("🧹 Removed the %s component from %s\n", component, filepath.Join(outputDir, cfg.ProjectConfig.ProjectName))
+		printPaths(report.Deleted, "Deleted (%d):\n")
+		printPaths(report.Updated, "Regenerated without it (%d):\n")
+		printPaths(report.Kept, "⚠️  Kept the files of the component you edited, delete them by hand (%d):\n")
+		printPaths(report.Edited, "⚠️  Kept the files you edited, take the component out of them by hand (%d):\n")
+		if files := report.ReferencingFiles(); len(files) > 0 {
+			fmt.Printf("⚠️  Files still referring to %s (%d):\n", component, len(files))
+			for _, path := range files {
+				fmt.Printf("   %s: %s\n", filepath.ToSlash(path), strings.Join(report.References[path], ", "))
+			}
+		}
+		if cfg.Offline {
+			printOffline(cfg, "go mod tidy was")
+		}
+	}
+	if err != nil {
+		log.Error("Failed to remove component", "component", component, "error", err)
+		return 1
+	}
+	return 0
+}
+
+// printPaths prints a heading with the number of paths followed by the paths, if there are any
+func printPaths(paths []string, heading string) {
+	if len(paths) == 0 {
+		return
+	}
+	fmt.Printf(heading, len(paths))
+	for _, path := range paths {
+		fmt.Printf("   %s\n", filepath.ToSlash(path))
+	}
+}
+
+// conflictResolver returns how the conflicts of the files edited since they
+// were generated are resolved: with --accept-all or --keep-all, or by asking
+// on a terminal, except for the conflicts resolved before. It returns nil
+// otherwise, so they are kept without recording a decision.
+func conflictResolver(log logger.Logger, cfg *config.Config) generator.ConflictResolver {
+	switch {
+	case cfg.AcceptAll:
+		return func(*generator.Conflict) (generator.Resolution, error) { return generator.ResolveOverwrite, nil }
+	case cfg.KeepAll:
+		return func(*generator.Conflict) (generator.Resolution, error) { return generator.ResolveKeep, nil }
+	case isTerminal(os.Stdin) && isTerminal(os.Stdout):
+		wizard := cli.NewWizard(log)
+		return generator.KeepResolved(func(c *generator.Conflict) (generator.Resolution, error) {
+			answer, err := wizard.ResolveConflict(os.Stdout, filepath.ToSlash(c.Path), c.Diff(), c.Merged != nil)
+			return generator.Resolution(answer), err
+		})
+	}
+	return nil
+}
+
+// resolveConflicts resolves the conflicts of the files the applied plan left
+// alone because they were edited since they were generated
+func resolveConflicts(log logger.Logger, gen *generator.Generator, resolve generator.ConflictResolver) {
+	conflicts, err := gen.Conflicts()
+	if err != nil {
+		log.Fatal("Failed to compare the edited files with the generated ones", "error", err)
+	}
+	if len(conflicts) == 0 {
+		return
+	}
+
+	report, err := gen.ResolveConflicts(conflicts, resolve)
+	if report != nil {
+		printPaths(report.Overwritten, "Overwrote the files you edited (%d):\n")
+		printPaths(report.Merged, "Merged the changes into the files you edited, review them (%d):\n")
+		printPaths(report.Marked, "⚠️  Wrote conflict markers into the files you edited, resolve them (%d):\n")
+		printPaths(report.Kept, "Kept the files you edited (%d):\n")
+	}
+	if err != nil {
+		log.Fatal("Failed to resolve the conflicts of the edited files", "error", err)
+	}
+}
+
+// findOrphans returns the files of the manifest the run no longer generated,
+// with their paths split into the unedited ones, which pruning deletes, and
+// the edited ones
+func findOrphans(log logger.Logger, gen *generator.Generator) (orphans []generator.Orphan, unedited, edited []string) {
+	orphans, err := gen.Orphans()
+	if err != nil {
+		log.Fatal("Failed to find the files no longer generated", "error", err)
+	}
+	for _, orphan := range orphans {
+		if orphan.Edited {
+			edited = append(edited, orphan.Path)
+		} else {
+			unedited = append(unedited, orphan.Path)
+		}
+	}
+	return orphans, unedited, edited
+}
+
+// pruneOrphans lists the files the applied plan no longer generated, then
+// deletes the unedited ones with --prune, or once confirmed on a terminal
+func pruneOrphans(log logger.Logger, cfg *config.Config, gen *generator.Generator) {
+	orphans, unedited, edited := findOrphans(log, gen)
+	printPaths(edited, "⚠️  No longer generated but edited, kept (%d):\n")
+	if len(unedited) == 0 {
+		return
+	}
+	printPaths(unedited, "🗑️  No longer generated and unchanged since they were generated (%d):\n")
+
+	prune := cfg.Prune
+	if !prune && isTerminal(os.Stdin) {
+		var err error
+		if prune, err = cli.NewWizard(log).ConfirmPrune(len(unedited)); err != nil {
+			log.Fatal("Failed to confirm deleting the files", "error", err)
+		}
+	}
+	if !prune {
+		fmt.Println("   Kept them; re-run with --prune to delete them")
+		return
+	}
+
+	deleted, err := gen.PruneOrphans(orphans)
+	printPaths(deleted, "🗑️  Deleted (%d):\n")
+	if err != nil {
+		log.Fatal("Failed to delete the files no longer generated", "error", err)
+	}
+}
+
+// isTerminal reports whether f is a terminal a prompt can be answered on;
+// /dev/null is a character device too, so the mode alone doesn't tell
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// printOffline tells that the steps needing the module proxy were skipped, because of
+// --offline or of the environment, and the commands to run once it is reachable
+func printOffline(cfg *config.Config, skipped string) {
+	reason := "--offline"
+	if setting := config.OfflineEnvironment(); setting != "" && !cfg.Provided["offline"] {
+		reason = setting
+	}
+	commands := "go mod tidy"
+	if cfg.ProjectConfig.Vendor {
+		commands += " and go mod vendor"
+	}
+	fmt.Printf("📴 Offline (%s): %s skipped; run %s in the project once the module proxy is reachable\n", reason, skipped, commands)
+}
+
+// writesFiles reports whether the run changes the filesystem, which a dry run
+// and a plan without --apply don't
+func writesFiles(cfg *config.Config) bool {
+	return !cfg.DryRun && (!cfg.Plan || cfg.Apply)
+}
+
+// goVersion returns the Go version of the project, or the newest one of the workspace services
+func goVersion(cfg *config.Config) string {
+	if cfg.Workspace != nil {
+		return cfg.Workspace.Go()
+	}
+	return cfg.ProjectConfig.Go()
+}
+
+// usesDocker reports whether the project, or any workspace service, has the Docker component
+func usesDocker(cfg *config.Config) bool {
+	if cfg.Workspace != nil {
+		for _, service := range cfg.Workspace.Services {
+			if service.Components.Docker {
+				return true
+			}
+		}
+		return false
+	}
+	return cfg.ProjectConfig.Components.Docker
 }